@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sochoa/sochoa.dev/api/internal/config"
+	"github.com/sochoa/sochoa.dev/api/internal/content"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	"github.com/sochoa/sochoa.dev/api/internal/logger"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncDryRun         bool
+	syncArchiveMissing bool
+	exportOutput       string
+
+	contentCmd = &cobra.Command{
+		Use:   "content",
+		Short: "Manage post content",
+	}
+
+	contentSyncCmd = &cobra.Command{
+		Use:   "sync <dir>",
+		Short: "Create and update posts from markdown files with front matter",
+		Long: `Sync reads every *.md file beneath <dir> and upserts it as a post, matched by slug.
+
+Each file starts with YAML (---) or TOML (+++) front matter:
+
+  ---
+  slug: hello-world          # defaults to the file name
+  title: Hello, World
+  summary: A first post
+  tags: [go, meta]
+  status: published          # draft, published, scheduled or archived; defaults to published
+  published_at: 2024-01-02   # defaults to the first sync that publishes the post
+  publish_at: 2030-01-02     # required when status is scheduled
+  ---
+
+Status changes follow the same publish, unpublish and archive rules as the API and emit the same events;
+a move they reject, such as an archived post back to draft, fails the sync before anything is written.
+
+With --archive-missing, posts whose files were removed are archived. Use --dry-run in CI to review the diff first.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runContentSync,
+	}
+
+	contentExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export all content to a zip archive",
+		Long: `Export writes a zip of every post as markdown with front matter, approved guestbook
+entries as guestbook.json, and the media posts reference under media/.
+
+Exports of identical data are byte-identical, so the archive can be committed to git.
+The posts/ directory can be fed back to "api content sync".`,
+		Args: cobra.NoArgs,
+		RunE: runContentExport,
+	}
+)
+
+func init() {
+	contentSyncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Print the planned changes without writing them")
+	contentSyncCmd.Flags().BoolVar(&syncArchiveMissing, "archive-missing", false, "Archive posts that have no file in the directory")
+	contentExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "export.zip", "Archive path, or - for stdout")
+	contentCmd.AddCommand(contentSyncCmd)
+	contentCmd.AddCommand(contentExportCmd)
+	rootCmd.AddCommand(contentCmd)
+}
+
+func runContentSync(cmd *cobra.Command, args []string) error {
+	docs, err := content.LoadDir(args[0])
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ctx := context.Background()
+	database, err := db.Connect(ctx, cfg.DBDsn)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if err := db.MigrateUp(ctx, database); err != nil {
+		return err
+	}
+
+	postRepo := model.NewPostRepository(database)
+	existing, err := postRepo.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes := content.Plan(docs, existing, syncArchiveMissing)
+	out := cmd.OutOrStdout()
+	content.WriteDiff(out, changes)
+
+	counts := content.Summarize(changes)
+	create, update, archive, unchanged := counts[content.ActionCreate], counts[content.ActionUpdate], counts[content.ActionArchive], counts[content.ActionUnchanged]
+
+	if syncDryRun {
+		fmt.Fprintf(out, "dry run: %d to create, %d to update, %d to archive, %d unchanged\n", create, update, archive, unchanged)
+		return nil
+	}
+
+	// Status changes emit the same events as the API, so their subscribers run for synced posts too
+	eventBus := newEventBus(logger.Setup(cfg.LogLevel), model.NewRelatedPostRepository(database))
+	if err := content.Apply(ctx, postRepo, eventBus, changes); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "synced: %d created, %d updated, %d archived, %d unchanged\n", create, update, archive, unchanged)
+	return nil
+}
+
+func runContentExport(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ctx := context.Background()
+	database, err := db.Connect(ctx, cfg.DBDsn)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if err := db.MigrateUp(ctx, database); err != nil {
+		return err
+	}
+
+	mediaLibrary, err := newMediaLibrary(cfg, database)
+	if err != nil {
+		return err
+	}
+	exporter := content.NewExporter(model.NewPostRepository(database), model.NewGuestbookRepository(database), mediaLibrary)
+
+	if exportOutput == "-" {
+		return exporter.Export(ctx, cmd.OutOrStdout())
+	}
+
+	// Write beside the target and rename, so a failed export never leaves a truncated archive
+	tmp, err := os.CreateTemp(filepath.Dir(exportOutput), ".export-*.zip")
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := exporter.Export(ctx, tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), exportOutput); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "exported to %s\n", exportOutput)
+	return nil
+}
@@ -0,0 +1,7 @@
+-- Rollback: Scheduled publishing and unpublishing for posts
+
+DROP INDEX IF EXISTS idx_posts_unpublish_at;
+DROP INDEX IF EXISTS idx_posts_publish_at;
+
+ALTER TABLE posts DROP COLUMN unpublish_at;
+ALTER TABLE posts DROP COLUMN publish_at;
@@ -0,0 +1,4 @@
+-- Rollback: Tag catalog
+
+DROP TABLE IF EXISTS tag_aliases;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,9 @@
+-- Historical slugs so old post links redirect to the current slug
+
+CREATE TABLE post_slug_history (
+    slug VARCHAR(255) PRIMARY KEY,
+    post_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_post_slug_history_post_id ON post_slug_history(post_id);
@@ -0,0 +1,19 @@
+-- Post series: ordered collections of multi-part posts
+
+CREATE TABLE series (
+    id TEXT PRIMARY KEY,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE series_posts (
+    series_id TEXT NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    post_id TEXT NOT NULL UNIQUE REFERENCES posts(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (series_id, post_id)
+);
+
+CREATE INDEX idx_series_posts_position ON series_posts(series_id, position);
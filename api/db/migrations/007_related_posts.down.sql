@@ -0,0 +1,4 @@
+-- Rollback: Related posts
+
+DROP TABLE IF EXISTS related_index_state;
+DROP TABLE IF EXISTS post_similarity;
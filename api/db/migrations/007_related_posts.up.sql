@@ -0,0 +1,15 @@
+-- Related posts: precomputed similarity index between posts
+
+CREATE TABLE post_similarity (
+    post_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    related_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (post_id, related_id)
+);
+
+-- Single row recording when the index was last rebuilt, used to detect staleness
+CREATE TABLE related_index_state (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    post_count INTEGER NOT NULL,
+    built_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,4 @@
+-- Rollback: Media library
+
+DROP TABLE IF EXISTS media_variants;
+DROP TABLE IF EXISTS media_assets;
@@ -0,0 +1,26 @@
+-- Media library: uploaded files and their resized variants
+
+CREATE TABLE media_assets (
+    id TEXT PRIMARY KEY,
+    sha256 CHAR(64) NOT NULL UNIQUE,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    width INTEGER,
+    height INTEGER,
+    storage_key VARCHAR(512) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE media_variants (
+    asset_id TEXT NOT NULL REFERENCES media_assets(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key VARCHAR(512) NOT NULL,
+    PRIMARY KEY (asset_id, name)
+);
+
+CREATE INDEX idx_media_assets_created_at ON media_assets(created_at);
@@ -0,0 +1,4 @@
+-- Rollback: Post reactions
+
+DROP TABLE IF EXISTS reaction_salts;
+DROP TABLE IF EXISTS post_reactions;
@@ -0,0 +1,6 @@
+-- Post trash: deleting a post sets deleted_at instead of removing the row
+-- Trashed posts are hidden everywhere, can be restored, and are purged after the retention period
+
+ALTER TABLE posts ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_posts_deleted_at ON posts(deleted_at);
@@ -0,0 +1,4 @@
+-- Rollback: Row versions
+
+ALTER TABLE visitor_stats DROP COLUMN version;
+ALTER TABLE posts DROP COLUMN version;
@@ -0,0 +1,5 @@
+-- Row versions for optimistic concurrency
+-- Every write increments version; updates only apply when the version the client read is still current
+
+ALTER TABLE posts ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE visitor_stats ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
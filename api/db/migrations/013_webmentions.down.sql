@@ -0,0 +1,3 @@
+-- Rollback: Webmentions
+
+DROP TABLE IF EXISTS webmentions;
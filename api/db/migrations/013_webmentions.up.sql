@@ -0,0 +1,26 @@
+-- Webmentions: links to our posts from other sites, verified in the background and moderated before display
+
+-- status tracks verification: pending until the worker fetches the source, then verified or failed
+-- A source resending a mention for the same target re-queues the existing row
+CREATE TABLE webmentions (
+    id TEXT PRIMARY KEY,
+    post_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    source VARCHAR(2048) NOT NULL,
+    target VARCHAR(2048) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    approved BOOLEAN NOT NULL DEFAULT FALSE,
+    author_name VARCHAR(255) NOT NULL DEFAULT '',
+    author_url VARCHAR(2048) NOT NULL DEFAULT '',
+    author_photo VARCHAR(2048) NOT NULL DEFAULT '',
+    content TEXT NOT NULL DEFAULT '',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    verified_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (source, target),
+    CONSTRAINT valid_webmention_status CHECK (status IN ('pending', 'verified', 'failed'))
+);
+
+CREATE INDEX idx_webmentions_post ON webmentions(post_id, status, approved);
+CREATE INDEX idx_webmentions_status ON webmentions(status, updated_at);
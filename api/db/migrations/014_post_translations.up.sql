@@ -0,0 +1,23 @@
+-- Post translations: a post is written in its own locale and may carry translations into others
+-- Each translation has its own slug, so /blog/<slug> works in every language; slugs are unique across
+-- posts and translations, which the application checks since they live in different tables
+
+ALTER TABLE posts ADD COLUMN locale VARCHAR(10) NOT NULL DEFAULT 'en';
+
+CREATE TABLE post_translations (
+    post_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    title VARCHAR(255) NOT NULL,
+    summary TEXT,
+    body TEXT NOT NULL,
+    word_count INTEGER NOT NULL DEFAULT 0,
+    reading_time INTEGER NOT NULL DEFAULT 0,
+    toc TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (post_id, locale)
+);
+
+CREATE INDEX idx_posts_locale ON posts(locale);
+CREATE INDEX idx_post_translations_locale ON post_translations(locale);
@@ -0,0 +1,5 @@
+-- Rollback: Resumes
+
+DROP TABLE IF EXISTS resume_variant_sections;
+DROP TABLE IF EXISTS resume_variants;
+DROP TABLE IF EXISTS resume_sections;
@@ -0,0 +1,3 @@
+-- Rollback: Talks
+
+DROP TABLE IF EXISTS talks;
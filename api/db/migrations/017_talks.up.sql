@@ -0,0 +1,23 @@
+-- Talks: speaking and media appearances such as talks, workshops, podcasts and features
+-- A talk may point at the post that accompanies it; deleting the post keeps the talk
+
+CREATE TABLE talks (
+    id TEXT PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    event VARCHAR(255) NOT NULL,
+    event_url TEXT,
+    location VARCHAR(255),
+    abstract TEXT,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP,
+    slides_url TEXT,
+    video_url TEXT,
+    recording_url TEXT,
+    post_id TEXT REFERENCES posts(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT valid_talk_type CHECK (type IN ('talk', 'workshop', 'podcast', 'feature'))
+);
+
+CREATE INDEX idx_talks_starts_at ON talks(starts_at);
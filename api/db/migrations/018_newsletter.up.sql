@@ -0,0 +1,21 @@
+-- Newsletter subscribers with double opt-in
+-- Pending addresses expire like contact submissions and are purged; confirming clears the expiry.
+-- Confirmation tokens are stored as SHA-256 hashes. Unsubscribe tokens are kept as issued because
+-- every newsletter carries them in its List-Unsubscribe header; they only grant unsubscribing.
+
+CREATE TABLE newsletter_subscribers (
+    id TEXT PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL,
+    confirm_token_hash VARCHAR(64) UNIQUE,
+    unsubscribe_token VARCHAR(64) NOT NULL UNIQUE,
+    confirmation_sent_at TIMESTAMP,
+    confirmations_sent INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP,
+    confirmed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT valid_subscriber_status CHECK (status IN ('pending', 'confirmed'))
+);
+
+CREATE INDEX idx_newsletter_subscribers_expires_at ON newsletter_subscribers(expires_at);
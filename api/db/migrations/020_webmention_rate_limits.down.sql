@@ -0,0 +1,6 @@
+-- Rollback: Webmention rate limits
+
+DROP INDEX IF EXISTS idx_webmentions_source_host;
+DROP INDEX IF EXISTS idx_webmentions_sender;
+ALTER TABLE webmentions DROP COLUMN source_host;
+ALTER TABLE webmentions DROP COLUMN sender_hash;
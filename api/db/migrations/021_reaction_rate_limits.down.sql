@@ -0,0 +1,4 @@
+-- Rollback: Reaction rate limits
+
+CREATE INDEX idx_post_reactions_visitor ON post_reactions(visitor_hash, created_at);
+DROP TABLE IF EXISTS reaction_rate_limits;
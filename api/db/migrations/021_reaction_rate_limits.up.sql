@@ -0,0 +1,13 @@
+-- Reaction rate limits: how many reactions each address has left per UTC day
+
+-- sender_hash is an HMAC of the address alone under that day's reaction salt, so changing the
+-- user agent does not reset the budget and no address is stored
+CREATE TABLE reaction_rate_limits (
+    day VARCHAR(10) NOT NULL,
+    sender_hash CHAR(64) NOT NULL,
+    reactions INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, sender_hash)
+);
+
+-- The limit is no longer counted from post_reactions
+DROP INDEX IF EXISTS idx_post_reactions_visitor;
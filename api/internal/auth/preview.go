@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// previewPayloadSize is the encoded size of a token ID, post ID and expiry timestamp
+const previewPayloadSize = 16 + 16 + 8
+
+// PreviewClaims identifies the post and token a preview link grants access to
+type PreviewClaims struct {
+	TokenID   uuid.UUID
+	PostID    uuid.UUID
+	ExpiresAt time.Time
+}
+
+// PreviewSigner mints and verifies HMAC-signed draft preview tokens
+// Tokens are compact and URL-safe: base64url(token_id|post_id|expiry) "." base64url(hmac)
+type PreviewSigner struct {
+	secret []byte
+}
+
+// NewPreviewSigner creates a preview signer using the given HMAC secret
+func NewPreviewSigner(secret []byte) *PreviewSigner {
+	return &PreviewSigner{secret: secret}
+}
+
+// NewRandomPreviewSecret generates a random HMAC secret
+// Tokens signed with it stop verifying once the process restarts
+func NewRandomPreviewSecret() ([]byte, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate preview secret: %w", err)
+	}
+	return secret, nil
+}
+
+// Sign encodes and signs preview claims into a token
+func (s *PreviewSigner) Sign(claims PreviewClaims) string {
+	payload := make([]byte, previewPayloadSize)
+	copy(payload[0:16], claims.TokenID[:])
+	copy(payload[16:32], claims.PostID[:])
+	binary.BigEndian.PutUint64(payload[32:40], uint64(claims.ExpiresAt.Unix()))
+
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(payload) + "." + encoding.EncodeToString(s.mac(payload))
+}
+
+// Verify checks a token's signature and expiry and returns its claims
+func (s *PreviewSigner) Verify(token string, now time.Time) (*PreviewClaims, error) {
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, fmt.Errorf("malformed preview token")
+	}
+
+	encoding := base64.RawURLEncoding
+	payload, err := encoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != previewPayloadSize {
+		return nil, fmt.Errorf("malformed preview token")
+	}
+
+	mac, err := encoding.DecodeString(encodedMAC)
+	if err != nil {
+		return nil, fmt.Errorf("malformed preview token")
+	}
+
+	if !hmac.Equal(mac, s.mac(payload)) {
+		return nil, fmt.Errorf("invalid preview token signature")
+	}
+
+	claims := &PreviewClaims{
+		ExpiresAt: time.Unix(int64(binary.BigEndian.Uint64(payload[32:40])), 0).UTC(),
+	}
+	copy(claims.TokenID[:], payload[0:16])
+	copy(claims.PostID[:], payload[16:32])
+
+	if !claims.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("preview token expired")
+	}
+
+	return claims, nil
+}
+
+// mac computes the HMAC-SHA256 of a token payload
+func (s *PreviewSigner) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPreviewSignerRoundTrip(t *testing.T) {
+	signer := NewPreviewSigner([]byte("secret"))
+	now := time.Now().UTC()
+	claims := PreviewClaims{
+		TokenID:   uuid.New(),
+		PostID:    uuid.New(),
+		ExpiresAt: now.Add(time.Hour).Truncate(time.Second),
+	}
+
+	token := signer.Sign(claims)
+
+	got, err := signer.Verify(token, now)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got.TokenID != claims.TokenID || got.PostID != claims.PostID || !got.ExpiresAt.Equal(claims.ExpiresAt) {
+		t.Errorf("expected claims %+v, got %+v", claims, got)
+	}
+}
+
+func TestPreviewSignerRejectsInvalidTokens(t *testing.T) {
+	signer := NewPreviewSigner([]byte("secret"))
+	now := time.Now().UTC()
+	valid := signer.Sign(PreviewClaims{
+		TokenID:   uuid.New(),
+		PostID:    uuid.New(),
+		ExpiresAt: now.Add(time.Hour),
+	})
+	expired := signer.Sign(PreviewClaims{
+		TokenID:   uuid.New(),
+		PostID:    uuid.New(),
+		ExpiresAt: now.Add(-time.Hour),
+	})
+	payload, _, _ := strings.Cut(valid, ".")
+
+	tests := []struct {
+		name   string
+		signer *PreviewSigner
+		token  string
+	}{
+		{name: "empty token", signer: signer, token: ""},
+		{name: "missing signature", signer: signer, token: payload},
+		{name: "tampered signature", signer: signer, token: payload + ".AAAA"},
+		{name: "not base64", signer: signer, token: "!!!.!!!"},
+		{name: "expired token", signer: signer, token: expired},
+		{name: "different secret", signer: NewPreviewSigner([]byte("other")), token: valid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.signer.Verify(tt.token, now); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
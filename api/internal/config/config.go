@@ -2,9 +2,12 @@ package config
 
 import (
 	"fmt"
+	"net/mail"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 )
 
 // Config holds application configuration
@@ -16,6 +19,43 @@ type Config struct {
 	LogLevel          string
 	DevMode           bool
 	DevUserRole       string
+	SchedulerInterval time.Duration
+	PreviewSecret     string
+	SiteURL           string // Public site origin, e.g. https://sochoa.dev; posts live under /blog/
+
+	// Days a deleted post stays in the trash before it is purged
+	TrashRetentionDays int
+
+	// Reject admin updates that do not send If-Match with 428
+	RequireIfMatch bool
+
+	// Shared cache lifetimes for public reads
+	CachePostListMaxAge       time.Duration
+	CachePostMaxAge           time.Duration
+	CacheGuestbookMaxAge      time.Duration
+	CacheStaleWhileRevalidate time.Duration
+
+	// Related posts ranking
+	RelatedPostsLimit    int
+	RelatedPostsHalfLife time.Duration
+
+	// Media library
+	MediaStorage        string // local or s3
+	MediaDir            string
+	MediaBaseURL        string
+	MediaMaxUploadBytes int
+	MediaS3Bucket       string
+	MediaS3Endpoint     string
+	MediaS3Region       string
+	MediaS3PathStyle    bool
+
+	// Outgoing email; without an SMTP host, messages are kept in memory and not delivered
+	MailFrom        string // Defaults to newsletter@ the site's host
+	SMTPHost        string
+	SMTPPort        int
+	SMTPUsername    string
+	SMTPPassword    string
+	SMTPImplicitTLS bool
 }
 
 // Load loads configuration from environment variables with validation
@@ -39,6 +79,37 @@ func Load() (*Config, error) {
 		LogLevel:          getEnv("LOG_LEVEL", "debug"),
 		DevMode:           getEnvBool("DEV_MODE", true),
 		DevUserRole:       getEnv("DEV_USER_ROLE", "admin"),
+		SchedulerInterval: getEnvDuration("SCHEDULER_INTERVAL", time.Minute),
+		PreviewSecret:     getEnv("PREVIEW_TOKEN_SECRET", ""),
+		SiteURL:           getEnv("SITE_URL", "https://sochoa.dev"),
+
+		TrashRetentionDays: getEnvInt("TRASH_RETENTION_DAYS", 30),
+
+		RequireIfMatch: getEnvBool("REQUIRE_IF_MATCH", false),
+
+		CachePostListMaxAge:       getEnvDuration("CACHE_POST_LIST_MAX_AGE", time.Minute),
+		CachePostMaxAge:           getEnvDuration("CACHE_POST_MAX_AGE", 5*time.Minute),
+		CacheGuestbookMaxAge:      getEnvDuration("CACHE_GUESTBOOK_MAX_AGE", time.Minute),
+		CacheStaleWhileRevalidate: getEnvDuration("CACHE_STALE_WHILE_REVALIDATE", time.Hour),
+
+		RelatedPostsLimit:    getEnvInt("RELATED_POSTS_LIMIT", 5),
+		RelatedPostsHalfLife: getEnvDuration("RELATED_POSTS_RECENCY_HALF_LIFE", 0),
+
+		MediaStorage:        getEnv("MEDIA_STORAGE", "local"),
+		MediaDir:            getEnv("MEDIA_DIR", ""),
+		MediaBaseURL:        getEnv("MEDIA_BASE_URL", "/media"),
+		MediaMaxUploadBytes: getEnvInt("MEDIA_MAX_UPLOAD_BYTES", 10<<20),
+		MediaS3Bucket:       getEnv("MEDIA_S3_BUCKET", ""),
+		MediaS3Endpoint:     getEnv("MEDIA_S3_ENDPOINT", ""),
+		MediaS3Region:       getEnv("MEDIA_S3_REGION", ""),
+		MediaS3PathStyle:    getEnvBool("MEDIA_S3_PATH_STYLE", false),
+
+		MailFrom:        getEnv("MAIL_FROM", ""),
+		SMTPHost:        getEnv("SMTP_HOST", ""),
+		SMTPPort:        getEnvInt("SMTP_PORT", 587),
+		SMTPUsername:    getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:    getEnv("SMTP_PASSWORD", ""),
+		SMTPImplicitTLS: getEnvBool("SMTP_IMPLICIT_TLS", false),
 	}
 
 	// Validate required fields
@@ -63,6 +134,10 @@ func (c *Config) Validate() error {
 		if c.CognitoClientID == "" {
 			return fmt.Errorf("COGNITO_CLIENT_ID environment variable is required")
 		}
+		// A per-process random secret would break preview links across instances and deploys
+		if c.PreviewSecret == "" {
+			return fmt.Errorf("PREVIEW_TOKEN_SECRET environment variable is required")
+		}
 	}
 
 	// Validate log level
@@ -87,6 +162,33 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate site URL, which webmention targets are matched against
+	if c.SiteURL != "" {
+		site, err := url.Parse(c.SiteURL)
+		if err != nil || (site.Scheme != "http" && site.Scheme != "https") || site.Host == "" {
+			return fmt.Errorf("invalid SITE_URL: %s (must be an absolute http or https URL)", c.SiteURL)
+		}
+	}
+
+	// Validate media storage
+	switch c.MediaStorage {
+	case "", "local":
+	case "s3":
+		if c.MediaS3Bucket == "" {
+			return fmt.Errorf("MEDIA_S3_BUCKET environment variable is required when MEDIA_STORAGE is s3")
+		}
+	default:
+		return fmt.Errorf("invalid MEDIA_STORAGE: %s (must be local or s3)", c.MediaStorage)
+	}
+
+	// Validate the sender address, which must be a bare address
+	if c.MailFrom != "" {
+		from, err := mail.ParseAddress(c.MailFrom)
+		if err != nil || from.Address != c.MailFrom {
+			return fmt.Errorf("invalid MAIL_FROM: %s (must be an email address such as newsletter@example.com)", c.MailFrom)
+		}
+	}
+
 	return nil
 }
 
@@ -111,6 +213,32 @@ func getEnvBool(key string, defaultVal bool) bool {
 	return boolVal
 }
 
+// getEnvInt retrieves an environment variable as a positive integer with a default value
+func getEnvInt(key string, defaultVal int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultVal
+	}
+	intVal, err := strconv.Atoi(value)
+	if err != nil || intVal <= 0 {
+		return defaultVal
+	}
+	return intVal
+}
+
+// getEnvDuration retrieves an environment variable as a positive duration with a default value
+func getEnvDuration(key string, defaultVal time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultVal
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return defaultVal
+	}
+	return duration
+}
+
 // getDefaultSQLitePath creates and returns a default SQLite database path in ~/.cache
 func getDefaultSQLitePath() (string, error) {
 	home, err := os.UserHomeDir()
@@ -18,6 +18,7 @@ func TestValidate(t *testing.T) {
 				AWSRegion:         "us-east-1",
 				CognitoUserPoolID: "us-east-1_ABC123",
 				CognitoClientID:   "client123",
+				PreviewSecret:     "preview-secret",
 				LogLevel:          "info",
 				DevMode:           false,
 				DevUserRole:       "user",
@@ -41,6 +42,7 @@ func TestValidate(t *testing.T) {
 				AWSRegion:         "us-east-1",
 				CognitoUserPoolID: "us-east-1_ABC123",
 				CognitoClientID:   "client123",
+				PreviewSecret:     "preview-secret",
 				LogLevel:          "info",
 				DevMode:           false,
 			},
@@ -56,6 +58,18 @@ func TestValidate(t *testing.T) {
 			},
 			shouldErr: true,
 		},
+		{
+			name: "missing preview secret in production",
+			cfg: &Config{
+				DBDsn:             "postgres://localhost/db",
+				AWSRegion:         "us-east-1",
+				CognitoUserPoolID: "us-east-1_ABC123",
+				CognitoClientID:   "client123",
+				LogLevel:          "info",
+				DevMode:           false,
+			},
+			shouldErr: true,
+		},
 		{
 			name: "invalid log level",
 			cfg: &Config{
@@ -63,6 +77,7 @@ func TestValidate(t *testing.T) {
 				AWSRegion:         "us-east-1",
 				CognitoUserPoolID: "us-east-1_ABC123",
 				CognitoClientID:   "client123",
+				PreviewSecret:     "preview-secret",
 				LogLevel:          "invalid",
 				DevMode:           false,
 			},
@@ -79,6 +94,30 @@ func TestValidate(t *testing.T) {
 			},
 			shouldErr: true,
 		},
+		{
+			name: "invalid site URL",
+			cfg: &Config{
+				DBDsn:       "postgres://localhost/db",
+				AWSRegion:   "us-east-1",
+				LogLevel:    "info",
+				DevMode:     true,
+				DevUserRole: "admin",
+				SiteURL:     "sochoa.dev",
+			},
+			shouldErr: true,
+		},
+		{
+			name: "invalid mail from",
+			cfg: &Config{
+				DBDsn:       "postgres://localhost/db",
+				AWSRegion:   "us-east-1",
+				LogLevel:    "info",
+				DevMode:     true,
+				DevUserRole: "admin",
+				MailFrom:    "Newsletter <newsletter@sochoa.dev>",
+			},
+			shouldErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,258 @@
+package content
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"go.yaml.in/yaml/v3"
+)
+
+// Document is a post authored as a markdown file with front matter
+type Document struct {
+	Path        string // Path relative to the synced directory
+	Slug        string
+	Title       string
+	Summary     string
+	Tags        []string
+	Status      model.PostStatus
+	PublishedAt *time.Time
+	PublishAt   *time.Time // When a scheduled post goes live
+	Body        string
+}
+
+// frontMatter holds the recognised front matter keys; any other keys are ignored
+type frontMatter struct {
+	Slug        string      `yaml:"slug" toml:"slug"`
+	Title       string      `yaml:"title" toml:"title"`
+	Summary     string      `yaml:"summary,omitempty" toml:"summary"`
+	Tags        []string    `yaml:"tags,omitempty" toml:"tags"`
+	Status      string      `yaml:"status" toml:"status"`
+	PublishedAt interface{} `yaml:"published_at,omitempty" toml:"published_at"`
+	PublishAt   interface{} `yaml:"publish_at,omitempty" toml:"publish_at"`
+}
+
+// ParseDocument reads a markdown file with a YAML (---) or TOML (+++) front matter block
+// The slug defaults to the file name and the status to published, so a file in the repository is live unless it says otherwise
+func ParseDocument(path string, data []byte) (*Document, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	var delimiter string
+	switch {
+	case strings.HasPrefix(text, "---\n"):
+		delimiter = "---"
+	case strings.HasPrefix(text, "+++\n"):
+		delimiter = "+++"
+	default:
+		return nil, fmt.Errorf("%s: missing front matter; the file must start with --- (YAML) or +++ (TOML)", path)
+	}
+
+	rest := text[len(delimiter)+1:]
+	var header, body string
+	if strings.HasPrefix(rest, delimiter+"\n") || rest == delimiter {
+		body = strings.TrimPrefix(rest, delimiter)
+	} else {
+		end := strings.Index(rest, "\n"+delimiter+"\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n"+delimiter) {
+				return nil, fmt.Errorf("%s: front matter is not closed with %s", path, delimiter)
+			}
+			end = len(rest) - len(delimiter) - 1
+		}
+		header = rest[:end]
+		body = rest[end+len(delimiter)+1:]
+	}
+
+	var fm frontMatter
+	var err error
+	if delimiter == "---" {
+		err = yaml.Unmarshal([]byte(header), &fm)
+	} else {
+		err = toml.Unmarshal([]byte(header), &fm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid front matter: %w", path, err)
+	}
+
+	doc := &Document{
+		Path:    path,
+		Slug:    fm.Slug,
+		Title:   fm.Title,
+		Summary: strings.TrimSpace(fm.Summary),
+		Tags:    model.NormalizeTags(fm.Tags),
+		Status:  model.PostStatus(fm.Status),
+		Body:    strings.TrimSpace(body),
+	}
+	if doc.Slug == "" {
+		doc.Slug = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if doc.Status == "" {
+		doc.Status = model.PostStatusPublished
+	}
+
+	if doc.PublishedAt, err = parseTime(fm.PublishedAt); err != nil {
+		return nil, fmt.Errorf("%s: invalid published_at: %w", path, err)
+	}
+	if doc.PublishAt, err = parseTime(fm.PublishAt); err != nil {
+		return nil, fmt.Errorf("%s: invalid publish_at: %w", path, err)
+	}
+
+	return doc, nil
+}
+
+// FormatPost renders a post as a markdown document with YAML front matter that ParseDocument reads back
+func FormatPost(post *model.Post) ([]byte, error) {
+	fm := frontMatter{
+		Slug:    post.Slug,
+		Title:   post.Title,
+		Summary: post.Summary,
+		Tags:    post.Tags,
+		Status:  string(post.Status),
+	}
+	if post.PublishedAt != nil {
+		fm.PublishedAt = post.PublishedAt.UTC().Format(time.RFC3339)
+	}
+	if post.PublishAt != nil {
+		fm.PublishAt = post.PublishAt.UTC().Format(time.RFC3339)
+	}
+
+	header, err := yaml.Marshal(&fm)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(header)
+	buf.WriteString("---\n\n")
+	buf.WriteString(strings.TrimSpace(post.Body))
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// translationFrontMatter holds the front matter of an exported post translation
+type translationFrontMatter struct {
+	TranslationOf string `yaml:"translation_of"` // Slug of the post this translates
+	Locale        string `yaml:"locale"`
+	Slug          string `yaml:"slug"`
+	Title         string `yaml:"title"`
+	Summary       string `yaml:"summary,omitempty"`
+}
+
+// FormatTranslation renders a post translation as a markdown document with YAML front matter naming the post it translates
+func FormatTranslation(post *model.Post, translation *model.PostTranslation) ([]byte, error) {
+	fm := translationFrontMatter{
+		TranslationOf: post.Slug,
+		Locale:        translation.Locale,
+		Slug:          translation.Slug,
+		Title:         translation.Title,
+		Summary:       translation.Summary,
+	}
+
+	header, err := yaml.Marshal(&fm)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(header)
+	buf.WriteString("---\n\n")
+	buf.WriteString(strings.TrimSpace(translation.Body))
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// parseTime normalises a decoded front matter date: YAML and TOML timestamps, TOML local dates, or strings
+func parseTime(value interface{}) (*time.Time, error) {
+	var t time.Time
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		t = v
+	case toml.LocalDateTime:
+		t = v.AsTime(time.UTC)
+	case toml.LocalDate:
+		t = v.AsTime(time.UTC)
+	case string:
+		parsed, err := parseTimeString(v)
+		if err != nil {
+			return nil, err
+		}
+		t = parsed
+	default:
+		return nil, fmt.Errorf("expected a date, got %v", value)
+	}
+
+	t = t.UTC()
+	return &t, nil
+}
+
+// parseTimeString accepts RFC 3339 timestamps and plain dates
+func parseTimeString(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("'%s' is not an RFC 3339 timestamp or YYYY-MM-DD date", s)
+}
+
+// LoadDir parses every *.md file beneath dir, sorted by path
+// Two files claiming the same slug are an error, since only one of them could win
+func LoadDir(dir string) ([]Document, error) {
+	var docs []Document
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		doc, err := ParseDocument(filepath.ToSlash(rel), data)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, *doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Path < docs[j].Path })
+
+	seen := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		if other, ok := seen[doc.Slug]; ok {
+			return nil, fmt.Errorf("%s and %s both use slug '%s'", other, doc.Path, doc.Slug)
+		}
+		seen[doc.Slug] = doc.Path
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,167 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+)
+
+func TestParseDocument(t *testing.T) {
+	published := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	publishedAt := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		path string
+		data string
+		want Document
+	}{
+		{
+			name: "yaml",
+			path: "posts/hello.md",
+			data: "---\nslug: hello-world\ntitle: Hello, World\nsummary: A first post\ntags: [Go, meta]\nstatus: draft\npublished_at: 2024-01-02\n---\n\n# Hello\n\nBody text.\n",
+			want: Document{Path: "posts/hello.md", Slug: "hello-world", Title: "Hello, World", Summary: "A first post", Tags: []string{"go", "meta"}, Status: model.PostStatusDraft, PublishedAt: &published, Body: "# Hello\n\nBody text."},
+		},
+		{
+			name: "toml",
+			path: "hello.md",
+			data: "+++\ntitle = \"Hello\"\ntags = [\"go\"]\npublished_at = 2024-01-02T15:04:05Z\n+++\nBody\n",
+			want: Document{Path: "hello.md", Slug: "hello", Title: "Hello", Tags: []string{"go"}, Status: model.PostStatusPublished, PublishedAt: &publishedAt, Body: "Body"},
+		},
+		{
+			name: "toml local date",
+			path: "hello.md",
+			data: "+++\ntitle = \"Hello\"\npublished_at = 2024-01-02\n+++\nBody\n",
+			want: Document{Path: "hello.md", Slug: "hello", Title: "Hello", Tags: []string{}, Status: model.PostStatusPublished, PublishedAt: &published, Body: "Body"},
+		},
+		{
+			name: "quoted timestamp with offset",
+			path: "hello.md",
+			data: "---\ntitle: Hello\npublished_at: \"2024-01-02T17:04:05+02:00\"\n---\nBody",
+			want: Document{Path: "hello.md", Slug: "hello", Title: "Hello", Tags: []string{}, Status: model.PostStatusPublished, PublishedAt: &publishedAt, Body: "Body"},
+		},
+		{
+			name: "crlf line endings",
+			path: "hello.md",
+			data: "---\r\ntitle: Hello\r\n---\r\nBody\r\n",
+			want: Document{Path: "hello.md", Slug: "hello", Title: "Hello", Tags: []string{}, Status: model.PostStatusPublished, Body: "Body"},
+		},
+		{
+			name: "front matter closed at end of file",
+			path: "empty.md",
+			data: "---\ntitle: Empty\n---",
+			want: Document{Path: "empty.md", Slug: "empty", Title: "Empty", Tags: []string{}, Status: model.PostStatusPublished},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDocument(tt.path, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want.Tags == nil {
+				tt.want.Tags = []string{}
+			}
+			if got.Tags == nil {
+				got.Tags = []string{}
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDocumentErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"no front matter", "# Hello\n", "missing front matter"},
+		{"unclosed", "---\ntitle: Hello\nBody\n", "not closed"},
+		{"invalid yaml", "---\ntitle: [unclosed\n---\nBody\n", "invalid front matter"},
+		{"invalid date", "---\ntitle: Hello\npublished_at: next tuesday\n---\nBody\n", "invalid published_at"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDocument("post.md", []byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("b.md", "---\ntitle: B\n---\nB body")
+	write("2024/a.md", "---\ntitle: A\n---\nA body")
+	write("README.txt", "not a post")
+	write(".drafts/c.md", "not parsed")
+
+	docs, err := LoadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var paths []string
+	for _, doc := range docs {
+		paths = append(paths, doc.Path)
+	}
+	if !reflect.DeepEqual(paths, []string{"2024/a.md", "b.md"}) {
+		t.Errorf("expected [2024/a.md b.md], got %v", paths)
+	}
+
+	write("other.md", "---\nslug: b\ntitle: Other B\n---\nBody")
+	if _, err := LoadDir(dir); err == nil || !strings.Contains(err.Error(), "both use slug 'b'") {
+		t.Errorf("expected duplicate slug error, got %v", err)
+	}
+}
+
+func TestFormatPostRoundTrip(t *testing.T) {
+	published := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	publishAt := time.Date(2030, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	posts := []model.Post{
+		{Slug: "hello-world", Title: "Hello: World", Summary: "It's \"quoted\"", Tags: []string{"go", "meta"}, Status: model.PostStatusPublished, PublishedAt: &published, Body: "# Hello\n\n---\n\nBody"},
+		{Slug: "draft", Title: "Draft", Status: model.PostStatusDraft, Body: "Draft body"},
+		{Slug: "soon", Title: "Soon", Status: model.PostStatusScheduled, PublishAt: &publishAt, Body: "Soon"},
+	}
+
+	for _, post := range posts {
+		t.Run(post.Slug, func(t *testing.T) {
+			data, err := FormatPost(&post)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			doc, err := ParseDocument(post.Slug+".md", data)
+			if err != nil {
+				t.Fatalf("failed to parse formatted post: %v\n%s", err, data)
+			}
+
+			changes := Plan([]Document{*doc}, []model.Post{post}, false)
+			if len(changes) != 1 || changes[0].Action != ActionUnchanged {
+				t.Errorf("expected the formatted post to sync as unchanged, got %+v\n%s", changes, data)
+			}
+		})
+	}
+}
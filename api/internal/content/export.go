@@ -0,0 +1,310 @@
+package content
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/media"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+)
+
+// exportModTime stamps every archive entry so identical content always produces identical bytes
+// It is the earliest time a zip file can record
+var exportModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// exportPageSize is how many rows each repository page fetches while exporting
+const exportPageSize = 100
+
+// Exporter writes all site content to a portable zip archive
+type Exporter struct {
+	posts     *model.PostRepository
+	guestbook *model.GuestbookRepository
+	media     *media.Library
+}
+
+// NewExporter creates an exporter reading from the given repositories and media library
+func NewExporter(posts *model.PostRepository, guestbook *model.GuestbookRepository, library *media.Library) *Exporter {
+	return &Exporter{posts: posts, guestbook: guestbook, media: library}
+}
+
+// exportedGuestbookEntry is an approved guestbook entry as written to guestbook.json
+type exportedGuestbookEntry struct {
+	ID           string    `json:"id"`
+	UserProvider string    `json:"user_provider"`
+	UserID       string    `json:"user_id"`
+	DisplayName  string    `json:"display_name"`
+	Message      string    `json:"message"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// exportedMediaAsset describes an exported media file and its variants in media.json
+type exportedMediaAsset struct {
+	SHA256      string                 `json:"sha256"`
+	Filename    string                 `json:"filename"`
+	ContentType string                 `json:"content_type"`
+	Width       int                    `json:"width,omitempty"`
+	Height      int                    `json:"height,omitempty"`
+	Path        string                 `json:"path"`
+	Variants    []exportedMediaVariant `json:"variants,omitempty"`
+}
+
+// exportedMediaVariant describes an exported variant file in media.json
+type exportedMediaVariant struct {
+	Name   string `json:"name"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Path   string `json:"path"`
+}
+
+// Export writes the archive: every post as posts/<slug>.md, every post translation as
+// translations/<locale>/<slug>.md, approved guestbook entries as guestbook.json, and the media
+// referenced by posts or translations under media/ with a media.json index
+// Entries are written in a fixed order with fixed timestamps, so exports of identical data are byte-identical
+func (e *Exporter) Export(ctx context.Context, w io.Writer) error {
+	archive := zip.NewWriter(w)
+
+	posts, err := e.posts.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range posts {
+		document, err := FormatPost(&posts[i])
+		if err != nil {
+			return fmt.Errorf("failed to format post '%s': %w", posts[i].Slug, err)
+		}
+		if err := writeEntry(archive, "posts/"+posts[i].Slug+".md", document); err != nil {
+			return err
+		}
+	}
+
+	translations, err := e.translations(ctx, posts)
+	if err != nil {
+		return err
+	}
+
+	for _, translated := range translations {
+		document, err := FormatTranslation(translated.post, &translated.translation)
+		if err != nil {
+			return fmt.Errorf("failed to format translation '%s': %w", translated.translation.Slug, err)
+		}
+		if err := writeEntry(archive, "translations/"+translated.translation.Locale+"/"+translated.translation.Slug+".md", document); err != nil {
+			return err
+		}
+	}
+
+	entries, err := e.approvedGuestbookEntries(ctx)
+	if err != nil {
+		return err
+	}
+	if err := writeJSONEntry(archive, "guestbook.json", entries); err != nil {
+		return err
+	}
+
+	assets, err := e.referencedMedia(ctx, posts, translations)
+	if err != nil {
+		return err
+	}
+
+	index := make([]exportedMediaAsset, 0, len(assets))
+	for _, asset := range assets {
+		exported := exportedMediaAsset{
+			SHA256:      asset.SHA256,
+			Filename:    asset.Filename,
+			ContentType: asset.ContentType,
+			Width:       asset.Width,
+			Height:      asset.Height,
+			Path:        "media/" + asset.StorageKey,
+		}
+		if err := e.copyMedia(ctx, archive, asset.StorageKey); err != nil {
+			return err
+		}
+
+		for _, variant := range asset.Variants {
+			exported.Variants = append(exported.Variants, exportedMediaVariant{
+				Name:   variant.Name,
+				Width:  variant.Width,
+				Height: variant.Height,
+				Path:   "media/" + variant.StorageKey,
+			})
+			if err := e.copyMedia(ctx, archive, variant.StorageKey); err != nil {
+				return err
+			}
+		}
+		index = append(index, exported)
+	}
+
+	if err := writeJSONEntry(archive, "media.json", index); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// exportedTranslation is a post translation with the post it translates
+type exportedTranslation struct {
+	post        *model.Post
+	translation model.PostTranslation
+}
+
+// translations loads the translations of posts, ordered by locale and then slug
+func (e *Exporter) translations(ctx context.Context, posts []model.Post) ([]exportedTranslation, error) {
+	ids := make([]uuid.UUID, len(posts))
+	byID := make(map[uuid.UUID]*model.Post, len(posts))
+	for i := range posts {
+		ids[i] = posts[i].ID
+		byID[posts[i].ID] = &posts[i]
+	}
+
+	translations, err := e.posts.ListTranslations(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var exported []exportedTranslation
+	for postID, list := range translations {
+		for _, translation := range list {
+			exported = append(exported, exportedTranslation{post: byID[postID], translation: translation})
+		}
+	}
+
+	sort.Slice(exported, func(i, j int) bool {
+		if exported[i].translation.Locale != exported[j].translation.Locale {
+			return exported[i].translation.Locale < exported[j].translation.Locale
+		}
+		return exported[i].translation.Slug < exported[j].translation.Slug
+	})
+	return exported, nil
+}
+
+// approvedGuestbookEntries pages through every approved entry, returning them oldest first
+func (e *Exporter) approvedGuestbookEntries(ctx context.Context) ([]exportedGuestbookEntry, error) {
+	var entries []model.GuestbookEntry
+	page := model.PageRequest{Limit: exportPageSize}
+	for {
+		batch, next, err := e.guestbook.ListApproved(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, batch...)
+		if next == nil {
+			break
+		}
+		page.After = next
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+		}
+		return entries[i].ID.String() < entries[j].ID.String()
+	})
+
+	exported := make([]exportedGuestbookEntry, 0, len(entries))
+	for _, entry := range entries {
+		exported = append(exported, exportedGuestbookEntry{
+			ID:           entry.ID.String(),
+			UserProvider: entry.UserProvider,
+			UserID:       entry.UserID,
+			DisplayName:  entry.DisplayName,
+			Message:      entry.Message,
+			CreatedAt:    entry.CreatedAt.UTC(),
+		})
+	}
+	return exported, nil
+}
+
+// referencedMedia pages through the media library and keeps the assets any post or translation mentions, ordered by hash
+func (e *Exporter) referencedMedia(ctx context.Context, posts []model.Post, translations []exportedTranslation) ([]model.MediaAsset, error) {
+	mentions := func(sha string) bool {
+		for i := range posts {
+			if strings.Contains(posts[i].Body, sha) || strings.Contains(posts[i].Summary, sha) {
+				return true
+			}
+		}
+		for _, translated := range translations {
+			if strings.Contains(translated.translation.Body, sha) || strings.Contains(translated.translation.Summary, sha) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Exporting without the media would leave a backup that silently misses files
+	if e.media == nil {
+		return nil, errors.New("media library is unavailable")
+	}
+
+	var referenced []model.MediaAsset
+	page := model.PageRequest{Limit: exportPageSize}
+	for {
+		batch, next, err := e.media.List(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, asset := range batch {
+			if mentions(asset.SHA256) {
+				referenced = append(referenced, asset)
+			}
+		}
+		if next == nil {
+			break
+		}
+		page.After = next
+	}
+
+	sort.Slice(referenced, func(i, j int) bool { return referenced[i].SHA256 < referenced[j].SHA256 })
+	return referenced, nil
+}
+
+// copyMedia copies a stored media file into the archive under media/
+func (e *Exporter) copyMedia(ctx context.Context, archive *zip.Writer, key string) error {
+	file, err := e.media.Open(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to open media file '%s': %w", key, err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("failed to read media file '%s': %w", key, err)
+	}
+
+	return writeEntry(archive, "media/"+key, data)
+}
+
+// writeJSONEntry writes an indented JSON document to the archive
+func writeJSONEntry(archive *zip.Writer, name string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return writeEntry(archive, name, append(data, '\n'))
+}
+
+// writeEntry writes one file to the archive with the fixed export timestamp
+func writeEntry(archive *zip.Writer, name string, data []byte) error {
+	header := &zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: exportModTime,
+	}
+	header.SetMode(0644)
+
+	entry, err := archive.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to export: %w", name, err)
+	}
+	if _, err := entry.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to export: %w", name, err)
+	}
+	return nil
+}
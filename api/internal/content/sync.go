@@ -0,0 +1,304 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sochoa/sochoa.dev/api/internal/events"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+)
+
+// Action is what a sync does to one post
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionUnchanged Action = "unchanged"
+	ActionArchive   Action = "archive"
+)
+
+// Change is the planned sync of one post
+type Change struct {
+	Action Action
+	Slug   string
+	Path   string      // Source file; empty for archived posts
+	Fields []string    // Fields that differ, for updates
+	Before *model.Post // Stored post; nil for creates
+	After  *model.Post // Post as it will be written; nil for unchanged posts
+}
+
+// Plan compares documents with the stored posts, matching them by slug
+// With archiveMissing, stored posts without a document are archived; otherwise they are left alone
+func Plan(docs []Document, existing []model.Post, archiveMissing bool) []Change {
+	stored := make(map[string]*model.Post, len(existing))
+	for i := range existing {
+		stored[existing[i].Slug] = &existing[i]
+	}
+
+	var changes []Change
+	seen := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		seen[doc.Slug] = true
+		before, ok := stored[doc.Slug]
+		if !ok {
+			changes = append(changes, Change{Action: ActionCreate, Slug: doc.Slug, Path: doc.Path, After: doc.post(nil)})
+			continue
+		}
+
+		after := doc.post(before)
+		fields := changedFields(before, after)
+		if len(fields) == 0 {
+			changes = append(changes, Change{Action: ActionUnchanged, Slug: doc.Slug, Path: doc.Path, Before: before})
+			continue
+		}
+		changes = append(changes, Change{Action: ActionUpdate, Slug: doc.Slug, Path: doc.Path, Fields: fields, Before: before, After: after})
+	}
+
+	if archiveMissing {
+		for i := range existing {
+			before := &existing[i]
+			if seen[before.Slug] || before.Status == model.PostStatusArchived {
+				continue
+			}
+
+			after := *before
+			after.Status = model.PostStatusArchived
+			after.PublishAt = nil
+			after.UnpublishAt = nil
+			changes = append(changes, Change{Action: ActionArchive, Slug: before.Slug, Fields: []string{"status"}, Before: before, After: &after})
+		}
+	}
+
+	return changes
+}
+
+// post builds the post a document describes, keeping the stored post's identity and fields the document does not set
+func (d *Document) post(stored *model.Post) *model.Post {
+	post := &model.Post{}
+	if stored != nil {
+		clone := *stored
+		post = &clone
+	}
+
+	post.Slug = d.Slug
+	post.Title = d.Title
+	post.Summary = d.Summary
+	post.Body = d.Body
+	post.Tags = d.Tags
+	post.Status = d.Status
+	if d.PublishedAt != nil {
+		post.PublishedAt = d.PublishedAt
+	}
+
+	// A file only schedules a post through publish_at, and has no unpublish_at at all
+	post.PublishAt = nil
+	if post.Status == model.PostStatusScheduled {
+		post.PublishAt = d.PublishAt
+	}
+	if post.Status != model.PostStatusScheduled && post.Status != model.PostStatusPublished {
+		post.UnpublishAt = nil
+	}
+
+	return post
+}
+
+// changedFields lists the synced fields that differ between the stored and the planned post
+func changedFields(before, after *model.Post) []string {
+	var fields []string
+	if before.Title != after.Title {
+		fields = append(fields, "title")
+	}
+	if before.Summary != after.Summary {
+		fields = append(fields, "summary")
+	}
+	if !slices.Equal(model.NormalizeTags(before.Tags), after.Tags) {
+		fields = append(fields, "tags")
+	}
+	if before.Status != after.Status {
+		fields = append(fields, "status")
+	}
+	if !sameTime(before.PublishedAt, after.PublishedAt) {
+		fields = append(fields, "published_at")
+	}
+	if !sameTime(before.PublishAt, after.PublishAt) {
+		fields = append(fields, "publish_at")
+	}
+	if strings.TrimSpace(before.Body) != after.Body {
+		fields = append(fields, "body")
+	}
+	return fields
+}
+
+// sameTime compares optional timestamps to the second, the precision front matter carries
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+}
+
+// Apply writes the planned creates, updates and archives, stopping at the first failure
+// Status changes follow the post state machine: every move is checked before anything is written,
+// and each transition's event is published on bus once its post is saved.
+func Apply(ctx context.Context, posts *model.PostRepository, bus *events.Bus, changes []Change) error {
+	now := time.Now().UTC()
+	transitions := make([]model.PostTransition, len(changes))
+	for i, change := range changes {
+		if change.Action != ActionUpdate && change.Action != ActionArchive {
+			continue
+		}
+
+		// The planned schedule fields already match the target status, which the transition leaves alone
+		target := change.After.Status
+		change.After.Status = change.Before.Status
+		transition, err := change.After.ChangeStatus(target, now)
+		if err != nil {
+			return changeError(change, err)
+		}
+		transitions[i] = transition
+	}
+
+	for i, change := range changes {
+		var err error
+		switch change.Action {
+		case ActionCreate:
+			err = posts.Create(ctx, change.After)
+		case ActionUpdate, ActionArchive:
+			err = posts.Update(ctx, change.After)
+		default:
+			continue
+		}
+		if err != nil {
+			return changeError(change, err)
+		}
+
+		if transitions[i] != "" {
+			bus.Publish(ctx, events.Event{
+				Type:   events.ForTransition(transitions[i]),
+				PostID: change.After.ID,
+				Slug:   change.After.Slug,
+				From:   change.Before.Status,
+				To:     change.After.Status,
+				At:     now,
+			})
+		}
+	}
+	return nil
+}
+
+// changeError names the file and post a failed change came from
+func changeError(change Change, err error) error {
+	if change.Path != "" {
+		return fmt.Errorf("%s: failed to %s post '%s': %w", change.Path, change.Action, change.Slug, err)
+	}
+	return fmt.Errorf("failed to %s post '%s': %w", change.Action, change.Slug, err)
+}
+
+// WriteDiff describes the planned changes, with a line diff of each changed body
+func WriteDiff(w io.Writer, changes []Change) {
+	for _, change := range changes {
+		switch change.Action {
+		case ActionCreate:
+			fmt.Fprintf(w, "+ create %s (%s)\n", change.Slug, change.Path)
+		case ActionArchive:
+			fmt.Fprintf(w, "- archive %s\n", change.Slug)
+		case ActionUpdate:
+			fmt.Fprintf(w, "~ update %s (%s): %s\n", change.Slug, change.Path, strings.Join(change.Fields, ", "))
+			for _, field := range change.Fields {
+				if field == "body" {
+					for _, line := range diffLines(change.Before.Body, change.After.Body) {
+						fmt.Fprintf(w, "    %s\n", line)
+					}
+					continue
+				}
+				fmt.Fprintf(w, "    %s: %s -> %s\n", field, fieldValue(change.Before, field), fieldValue(change.After, field))
+			}
+		}
+	}
+}
+
+// Summarize counts the planned changes by action
+func Summarize(changes []Change) map[Action]int {
+	counts := make(map[Action]int)
+	for _, change := range changes {
+		counts[change.Action]++
+	}
+	return counts
+}
+
+// fieldValue formats a synced field for the diff
+func fieldValue(post *model.Post, field string) string {
+	switch field {
+	case "title":
+		return fmt.Sprintf("%q", post.Title)
+	case "summary":
+		return fmt.Sprintf("%q", post.Summary)
+	case "tags":
+		return "[" + strings.Join(post.Tags, ", ") + "]"
+	case "status":
+		return string(post.Status)
+	case "published_at":
+		return formatTime(post.PublishedAt)
+	case "publish_at":
+		return formatTime(post.PublishAt)
+	}
+	return ""
+}
+
+// formatTime formats an optional timestamp for the diff
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "(none)"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// diffLines returns the removed (-) and added (+) lines between two texts, in order
+// It uses the longest common subsequence of lines, which is plenty for a post body
+func diffLines(before, after string) []string {
+	a := strings.Split(strings.TrimSpace(before), "\n")
+	b := strings.Split(strings.TrimSpace(after), "\n")
+
+	// lcs[i][j] is the common subsequence length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, "-"+a[i])
+			i++
+		default:
+			lines = append(lines, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, "+"+b[j])
+	}
+	return lines
+}
@@ -0,0 +1,113 @@
+package content
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+)
+
+func TestPlan(t *testing.T) {
+	published := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	unpublishAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	existing := []model.Post{
+		{ID: uuid.New(), Slug: "same", Title: "Same", Body: "Body", Tags: []string{"go"}, Status: model.PostStatusPublished, PublishedAt: &published},
+		{ID: uuid.New(), Slug: "edited", Title: "Old Title", Body: "Line one\nLine two", Status: model.PostStatusPublished, PublishedAt: &published, UnpublishAt: &unpublishAt},
+		{ID: uuid.New(), Slug: "removed", Title: "Removed", Body: "Body", Status: model.PostStatusPublished, PublishedAt: &published},
+		{ID: uuid.New(), Slug: "already-archived", Title: "Archived", Body: "Body", Status: model.PostStatusArchived},
+	}
+
+	docs := []Document{
+		{Path: "same.md", Slug: "same", Title: "Same", Body: "Body", Tags: []string{"go"}, Status: model.PostStatusPublished},
+		{Path: "edited.md", Slug: "edited", Title: "New Title", Body: "Line one\nLine 2", Tags: []string{}, Status: model.PostStatusDraft},
+		{Path: "new.md", Slug: "new", Title: "New", Body: "Body", Status: model.PostStatusPublished, PublishedAt: &published},
+	}
+
+	t.Run("without archiving", func(t *testing.T) {
+		changes := Plan(docs, existing, false)
+		got := map[string]Action{}
+		for _, c := range changes {
+			got[c.Slug] = c.Action
+		}
+		want := map[string]Action{"same": ActionUnchanged, "edited": ActionUpdate, "new": ActionCreate}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		edited := changes[1]
+		if !reflect.DeepEqual(edited.Fields, []string{"title", "status", "body"}) {
+			t.Errorf("expected title, status and body to change, got %v", edited.Fields)
+		}
+		if edited.After.ID != existing[1].ID {
+			t.Errorf("expected update to keep the stored ID")
+		}
+		if edited.After.PublishedAt == nil || !edited.After.PublishedAt.Equal(published) {
+			t.Errorf("expected update to keep the stored published_at, got %v", edited.After.PublishedAt)
+		}
+		if edited.After.UnpublishAt != nil {
+			t.Errorf("expected unpublish_at to be dropped for a draft")
+		}
+		if existing[1].Title != "Old Title" {
+			t.Errorf("expected the stored post to be left untouched")
+		}
+	})
+
+	t.Run("with archiving", func(t *testing.T) {
+		changes := Plan(docs, existing, true)
+		var archived []string
+		for _, c := range changes {
+			if c.Action == ActionArchive {
+				archived = append(archived, c.Slug)
+				if c.After.Status != model.PostStatusArchived {
+					t.Errorf("expected archived status, got %s", c.After.Status)
+				}
+			}
+		}
+		if !reflect.DeepEqual(archived, []string{"removed"}) {
+			t.Errorf("expected only 'removed' to be archived, got %v", archived)
+		}
+	})
+}
+
+func TestWriteDiff(t *testing.T) {
+	published := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	existing := []model.Post{
+		{ID: uuid.New(), Slug: "edited", Title: "Old", Body: "one\ntwo\nthree", Status: model.PostStatusPublished, PublishedAt: &published},
+	}
+	docs := []Document{
+		{Path: "edited.md", Slug: "edited", Title: "New", Body: "one\n2\nthree", Status: model.PostStatusPublished},
+		{Path: "new.md", Slug: "new", Title: "New Post", Body: "Body", Status: model.PostStatusDraft},
+	}
+
+	var out bytes.Buffer
+	WriteDiff(&out, Plan(docs, existing, false))
+
+	want := strings.Join([]string{
+		`~ update edited (edited.md): title, body`,
+		`    title: "Old" -> "New"`,
+		`    -two`,
+		`    +2`,
+		`+ create new (new.md)`,
+		``,
+	}, "\n")
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	got := diffLines("a\nb\nc\nd", "a\nc\nd\ne")
+	want := []string{"-b", "+e"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := diffLines("same", "same"); len(got) != 0 {
+		t.Errorf("expected no differences, got %v", got)
+	}
+}
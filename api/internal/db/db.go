@@ -18,6 +18,11 @@ type QueryExecutor interface {
 	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
 }
 
+// Transactor is implemented by executors that can run work inside a transaction
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(tx QueryExecutor) error) error
+}
+
 // Connection represents a database connection pool (PostgreSQL or SQLite)
 type Connection struct {
 	db     *sql.DB
@@ -31,6 +36,7 @@ func Connect(ctx context.Context, dsn string) (*Connection, error) {
 	// Determine driver based on DSN format
 	if strings.HasPrefix(dsn, "file:") {
 		driver = "sqlite3"
+		dsn = sqliteDSN(dsn)
 	} else {
 		driver = "postgres"
 	}
@@ -59,6 +65,21 @@ func Connect(ctx context.Context, dsn string) (*Connection, error) {
 	return &Connection{db: db, driver: driver}, nil
 }
 
+// sqliteDSN turns on foreign key enforcement, which SQLite leaves off by default, so deletes cascade
+// It goes in the DSN rather than a PRAGMA because the driver applies it to every new pooled connection
+// A DSN that already sets the option is left alone
+func sqliteDSN(dsn string) string {
+	if strings.Contains(dsn, "_foreign_keys=") || strings.Contains(dsn, "_fk=") {
+		return dsn
+	}
+
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	return dsn + separator + "_foreign_keys=on"
+}
+
 // QueryRowContext executes a query that returns at most one row
 func (c *Connection) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
 	return c.db.QueryRowContext(ctx, query, args...)
@@ -79,6 +100,33 @@ func (c *Connection) HealthCheck(ctx context.Context) error {
 	return c.db.PingContext(ctx)
 }
 
+// WithTx runs fn inside a transaction, committing if it succeeds and rolling back otherwise
+func (c *Connection) WithTx(ctx context.Context, fn func(tx QueryExecutor) error) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RunInTx runs fn inside a transaction when exec supports one, otherwise directly against exec
+func RunInTx(ctx context.Context, exec QueryExecutor, fn func(tx QueryExecutor) error) error {
+	if t, ok := exec.(Transactor); ok {
+		return t.WithTx(ctx, fn)
+	}
+	return fn(exec)
+}
+
 // Close closes the database connection pool
 func (c *Connection) Close() error {
 	return c.db.Close()
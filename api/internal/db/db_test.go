@@ -3,6 +3,8 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"path/filepath"
 	"testing"
 )
 
@@ -45,3 +47,98 @@ func TestConnectionMethods(_ *testing.T) {
 	// This test verifies that Connection implements QueryExecutor
 	var _ QueryExecutor = (*Connection)(nil)
 }
+
+func TestWithTx(t *testing.T) {
+	ctx := context.Background()
+	conn, err := Connect(ctx, "file:"+filepath.Join(t.TempDir(), "tx.db"))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE items (name TEXT)`); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	// A failing function rolls back its writes
+	errBoom := errors.New("boom")
+	err = RunInTx(ctx, conn, func(tx QueryExecutor) error {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO items (name) VALUES ('rolled-back')`); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("expected boom error, got %v", err)
+	}
+
+	// A successful function commits its writes
+	err = RunInTx(ctx, conn, func(tx QueryExecutor) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO items (name) VALUES ('committed')`)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var count int
+	if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM items`).Scan(&count); err != nil {
+		t.Fatalf("failed to count items: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 committed row, got %d", count)
+	}
+}
+
+func TestConnectEnforcesForeignKeys(t *testing.T) {
+	ctx := context.Background()
+	// The default DSN from config, which sets no foreign key option of its own
+	conn, err := Connect(ctx, "file:"+filepath.Join(t.TempDir(), "fk.db")+"?cache=shared&mode=rwc")
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	if err := MigrateUp(ctx, conn); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, `INSERT INTO posts (id, slug, title, body) VALUES ('p1', 'gone', 'Gone', 'Body')`); err != nil {
+		t.Fatalf("failed to insert post: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, `INSERT INTO post_slug_history (slug, post_id) VALUES ('old-gone', 'p1')`); err != nil {
+		t.Fatalf("failed to insert slug history: %v", err)
+	}
+
+	// Deleting a post cascades to its dependent rows on every pooled connection
+	if _, err := conn.ExecContext(ctx, `DELETE FROM posts WHERE id = 'p1'`); err != nil {
+		t.Fatalf("failed to delete post: %v", err)
+	}
+
+	var count int
+	if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM post_slug_history`).Scan(&count); err != nil {
+		t.Fatalf("failed to count slug history: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected slug history to be deleted with its post, got %d rows", count)
+	}
+
+	// Rows pointing at missing posts are rejected outright
+	if _, err := conn.ExecContext(ctx, `INSERT INTO post_slug_history (slug, post_id) VALUES ('orphan', 'missing')`); err == nil {
+		t.Error("expected an error inserting slug history for a missing post")
+	}
+}
+
+func TestSQLiteDSN(t *testing.T) {
+	tests := map[string]string{
+		"file:api.db":                       "file:api.db?_foreign_keys=on",
+		"file:api.db?cache=shared&mode=rwc": "file:api.db?cache=shared&mode=rwc&_foreign_keys=on",
+		"file:api.db?_foreign_keys=off":     "file:api.db?_foreign_keys=off",
+		"file:api.db?mode=rwc&_fk=1":        "file:api.db?mode=rwc&_fk=1",
+	}
+	for dsn, want := range tests {
+		if got := sqliteDSN(dsn); got != want {
+			t.Errorf("%s: expected %s, got %s", dsn, want, got)
+		}
+	}
+}
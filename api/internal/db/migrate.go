@@ -2,16 +2,46 @@ package db
 
 import (
 	"context"
-	_ "embed"
+	"embed"
 	"fmt"
 	"strings"
 )
 
-//go:embed migrations/001_init.up.sql
-var migrationUp string
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
 
-//go:embed migrations/001_init.down.sql
-var migrationDown string
+// migration describes a single versioned schema change
+type migration struct {
+	version int64
+	name    string
+}
+
+// migrations lists all schema changes in the order they must be applied
+// Versions only need to be unique: the leading zeros on the early entries make them
+// octal literals, so entries from 008 on are written without the padding
+var migrations = []migration{
+	{version: 001001, name: "001_init"},
+	{version: 002001, name: "002_scheduled_posts"},
+	{version: 003001, name: "003_post_preview_tokens"},
+	{version: 004001, name: "004_tags"},
+	{version: 005001, name: "005_post_slug_history"},
+	{version: 006001, name: "006_series"},
+	{version: 007001, name: "007_related_posts"},
+	{version: 8001, name: "008_post_reading_metadata"},
+	{version: 9001, name: "009_media"},
+	{version: 10001, name: "010_post_reactions"},
+	{version: 11001, name: "011_post_trash"},
+	{version: 12001, name: "012_row_versions"},
+	{version: 13001, name: "013_webmentions"},
+	{version: 14001, name: "014_post_translations"},
+	{version: 15001, name: "015_resumes"},
+	{version: 16001, name: "016_projects"},
+	{version: 17001, name: "017_talks"},
+	{version: 18001, name: "018_newsletter"},
+	{version: 19001, name: "019_pages"},
+	{version: 20001, name: "020_webmention_rate_limits"},
+	{version: 21001, name: "021_reaction_rate_limits"},
+}
 
 // MigrateUp applies all pending migrations
 func MigrateUp(ctx context.Context, conn *Connection) error {
@@ -19,51 +49,71 @@ func MigrateUp(ctx context.Context, conn *Connection) error {
 		return err
 	}
 
-	// Check if migration has already been applied
-	isMigrated, err := isMigrationApplied(ctx, conn, "001_init")
-	if err != nil {
-		return err
-	}
+	for _, m := range migrations {
+		// Check if migration has already been applied
+		isMigrated, err := isMigrationApplied(ctx, conn, m.version)
+		if err != nil {
+			return err
+		}
 
-	if isMigrated {
-		return nil // Already migrated
-	}
+		if isMigrated {
+			continue // Already migrated
+		}
 
-	// Run the migration
-	if _, err := conn.ExecContext(ctx, migrationUp); err != nil {
-		return fmt.Errorf("failed to run migration: %w", err)
-	}
+		migrationUp, err := migrationFiles.ReadFile("migrations/" + m.name + ".up.sql")
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", m.name, err)
+		}
 
-	// Record the migration
-	_, err = conn.ExecContext(ctx, `
-		INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)
-	`, 001001, false)
+		// Run the migration
+		if _, err := conn.ExecContext(ctx, string(migrationUp)); err != nil {
+			return fmt.Errorf("failed to run migration %s: %w", m.name, err)
+		}
 
-	return err
+		// Record the migration
+		if _, err := conn.ExecContext(ctx, `
+			INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)
+		`, m.version, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // MigrateDown rolls back all applied migrations
 func MigrateDown(ctx context.Context, conn *Connection) error {
-	isMigrated, err := isMigrationApplied(ctx, conn, "001_init")
-	if err != nil {
-		return err
-	}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
 
-	if !isMigrated {
-		return nil // Not migrated, nothing to do
-	}
+		isMigrated, err := isMigrationApplied(ctx, conn, m.version)
+		if err != nil {
+			return err
+		}
 
-	// Run the migration
-	if _, err := conn.ExecContext(ctx, migrationDown); err != nil {
-		return fmt.Errorf("failed to rollback migration: %w", err)
-	}
+		if !isMigrated {
+			continue // Not migrated, nothing to do
+		}
 
-	// Record the rollback
-	_, err = conn.ExecContext(ctx, `
-		DELETE FROM schema_migrations WHERE version = ?
-	`, 001001)
+		migrationDown, err := migrationFiles.ReadFile("migrations/" + m.name + ".down.sql")
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", m.name, err)
+		}
 
-	return err
+		// Run the migration
+		if _, err := conn.ExecContext(ctx, string(migrationDown)); err != nil {
+			return fmt.Errorf("failed to rollback migration %s: %w", m.name, err)
+		}
+
+		// Record the rollback
+		if _, err := conn.ExecContext(ctx, `
+			DELETE FROM schema_migrations WHERE version = ?
+		`, m.version); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // createMigrationsTable creates the schema_migrations table if it doesn't exist
@@ -78,10 +128,10 @@ func createMigrationsTable(ctx context.Context, conn *Connection) error {
 }
 
 // isMigrationApplied checks if a migration has been applied
-func isMigrationApplied(ctx context.Context, conn *Connection, name string) (bool, error) {
+func isMigrationApplied(ctx context.Context, conn *Connection, version int64) (bool, error) {
 	row := conn.QueryRowContext(ctx, `
 		SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = ?)
-	`, 001001)
+	`, version)
 
 	var exists bool
 	if err := row.Scan(&exists); err != nil {
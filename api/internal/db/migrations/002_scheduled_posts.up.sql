@@ -0,0 +1,7 @@
+-- Scheduled publishing and unpublishing for posts
+
+ALTER TABLE posts ADD COLUMN publish_at TIMESTAMP;
+ALTER TABLE posts ADD COLUMN unpublish_at TIMESTAMP;
+
+CREATE INDEX idx_posts_publish_at ON posts(publish_at);
+CREATE INDEX idx_posts_unpublish_at ON posts(unpublish_at);
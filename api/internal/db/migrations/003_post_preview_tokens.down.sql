@@ -0,0 +1,3 @@
+-- Rollback: Revocable preview links for unpublished posts
+
+DROP TABLE IF EXISTS post_preview_tokens;
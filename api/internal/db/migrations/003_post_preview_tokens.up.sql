@@ -0,0 +1,11 @@
+-- Revocable preview links for unpublished posts
+
+CREATE TABLE post_preview_tokens (
+    id TEXT PRIMARY KEY,
+    post_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_post_preview_tokens_post_id ON post_preview_tokens(post_id);
@@ -0,0 +1,16 @@
+-- Tag catalog: optional descriptions for tag landing pages and aliases that resolve to canonical tags
+
+CREATE TABLE tags (
+    name VARCHAR(64) PRIMARY KEY,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE tag_aliases (
+    alias VARCHAR(64) PRIMARY KEY,
+    tag VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_tag_aliases_tag ON tag_aliases(tag);
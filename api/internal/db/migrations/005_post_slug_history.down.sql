@@ -0,0 +1,3 @@
+-- Rollback: Historical post slugs
+
+DROP TABLE IF EXISTS post_slug_history;
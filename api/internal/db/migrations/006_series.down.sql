@@ -0,0 +1,4 @@
+-- Rollback: Post series
+
+DROP TABLE IF EXISTS series_posts;
+DROP TABLE IF EXISTS series;
@@ -0,0 +1,5 @@
+-- Rollback: Reading metadata
+
+ALTER TABLE posts DROP COLUMN toc;
+ALTER TABLE posts DROP COLUMN reading_time;
+ALTER TABLE posts DROP COLUMN word_count;
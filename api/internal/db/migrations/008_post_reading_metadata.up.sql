@@ -0,0 +1,6 @@
+-- Reading metadata computed from post bodies when posts are written
+-- NULL toc marks posts written before this migration; a background job fills them in
+
+ALTER TABLE posts ADD COLUMN word_count INTEGER;
+ALTER TABLE posts ADD COLUMN reading_time INTEGER;
+ALTER TABLE posts ADD COLUMN toc TEXT;
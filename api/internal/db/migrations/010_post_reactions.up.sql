@@ -0,0 +1,20 @@
+-- Post reactions: anonymous emoji reactions deduplicated per visitor per day
+
+-- visitor_hash is an HMAC of the visitor's address and user agent under that day's salt
+-- No address is stored, and once the salt is purged the hash cannot be linked to anyone
+CREATE TABLE post_reactions (
+    post_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    emoji VARCHAR(16) NOT NULL,
+    visitor_hash CHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (post_id, emoji, visitor_hash)
+);
+
+CREATE INDEX idx_post_reactions_visitor ON post_reactions(visitor_hash, created_at);
+
+-- One random salt per UTC day; past days are purged by the scheduler
+CREATE TABLE reaction_salts (
+    day VARCHAR(10) PRIMARY KEY,
+    salt CHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
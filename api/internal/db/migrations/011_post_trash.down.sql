@@ -0,0 +1,6 @@
+-- Rollback: Post trash
+-- Trashed posts are purged first so they do not reappear as live posts
+
+DELETE FROM posts WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_posts_deleted_at;
+ALTER TABLE posts DROP COLUMN deleted_at;
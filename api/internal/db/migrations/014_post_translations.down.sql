@@ -0,0 +1,5 @@
+-- Rollback: Post translations
+
+DROP TABLE IF EXISTS post_translations;
+DROP INDEX IF EXISTS idx_posts_locale;
+ALTER TABLE posts DROP COLUMN locale;
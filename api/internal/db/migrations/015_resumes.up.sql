@@ -0,0 +1,37 @@
+-- Resumes: structured sections (experience, skills, education) shared by named variants
+-- Each variant, such as consulting or engineering leadership, picks the sections it includes and their order
+
+CREATE TABLE resume_sections (
+    id TEXT PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    organization VARCHAR(255),
+    location VARCHAR(255),
+    start_date VARCHAR(7),
+    end_date VARCHAR(7),
+    summary TEXT,
+    highlights TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT valid_resume_section_kind CHECK (kind IN ('experience', 'skills', 'education'))
+);
+
+CREATE TABLE resume_variants (
+    id TEXT PRIMARY KEY,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    headline VARCHAR(255),
+    summary TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE resume_variant_sections (
+    variant_id TEXT NOT NULL REFERENCES resume_variants(id) ON DELETE CASCADE,
+    section_id TEXT NOT NULL REFERENCES resume_sections(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (variant_id, section_id)
+);
+
+CREATE INDEX idx_resume_variant_sections_position ON resume_variant_sections(variant_id, position);
+CREATE INDEX idx_resume_variant_sections_section ON resume_variant_sections(section_id);
@@ -0,0 +1,3 @@
+-- Rollback: Projects
+
+DROP TABLE IF EXISTS projects;
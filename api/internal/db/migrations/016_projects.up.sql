@@ -0,0 +1,25 @@
+-- Projects: case studies for the work page, tagged with the same tags as posts
+-- Published projects are listed by position, then newest first; featured ones also appear on the home page
+
+CREATE TABLE projects (
+    id TEXT PRIMARY KEY,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    title VARCHAR(255) NOT NULL,
+    summary TEXT,
+    problem TEXT,
+    role VARCHAR(255),
+    stack TEXT,
+    outcomes TEXT,
+    links TEXT,
+    tags TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    featured BOOLEAN NOT NULL DEFAULT FALSE,
+    position INTEGER NOT NULL DEFAULT 0,
+    published_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT valid_project_status CHECK (status IN ('draft', 'published'))
+);
+
+CREATE INDEX idx_projects_status_position ON projects(status, position, published_at DESC);
+CREATE INDEX idx_projects_featured ON projects(featured) WHERE status = 'published';
@@ -0,0 +1,3 @@
+-- Rollback: Newsletter subscribers
+
+DROP TABLE IF EXISTS newsletter_subscribers;
@@ -0,0 +1,3 @@
+-- Rollback: Pages
+
+DROP TABLE IF EXISTS pages;
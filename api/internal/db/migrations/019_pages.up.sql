@@ -0,0 +1,22 @@
+-- CMS pages such as About, Contact copy and the home intro, addressed by hierarchical paths like about/values
+-- A page's parent is the page at its path without the last segment; word_count, reading_time and toc
+-- are computed from the markdown body the same way as for posts
+
+CREATE TABLE pages (
+    id TEXT PRIMARY KEY,
+    path VARCHAR(255) NOT NULL UNIQUE,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    seo_title VARCHAR(255),
+    seo_description VARCHAR(500),
+    canonical_url TEXT,
+    noindex BOOLEAN NOT NULL DEFAULT FALSE,
+    word_count INTEGER NOT NULL DEFAULT 0,
+    reading_time INTEGER NOT NULL DEFAULT 0,
+    toc TEXT,
+    published_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT valid_page_status CHECK (status IN ('draft', 'published'))
+);
@@ -0,0 +1,8 @@
+-- Webmention rate limits: who sent each mention and which host it came from
+-- sender_hash is a visitor hash under the daily reaction salt, so no IP addresses are stored
+
+ALTER TABLE webmentions ADD COLUMN sender_hash VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE webmentions ADD COLUMN source_host VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_webmentions_sender ON webmentions(sender_hash, updated_at);
+CREATE INDEX idx_webmentions_source_host ON webmentions(source_host, status);
@@ -50,6 +50,15 @@ func (e ConflictError) Error() string {
 	return e.Message
 }
 
+// PreconditionFailedError represents a write based on a stale version of a resource
+type PreconditionFailedError struct {
+	Message string
+}
+
+func (e PreconditionFailedError) Error() string {
+	return e.Message
+}
+
 // RateLimitError represents rate limit exceeded
 type RateLimitError struct {
 	Message string
@@ -83,6 +92,8 @@ func HTTPStatusCode(err error) int {
 		return http.StatusForbidden
 	case ConflictError:
 		return http.StatusConflict
+	case PreconditionFailedError:
+		return http.StatusPreconditionFailed
 	case RateLimitError:
 		return http.StatusTooManyRequests
 	default:
@@ -40,6 +40,13 @@ func TestConflictError(t *testing.T) {
 	}
 }
 
+func TestPreconditionFailedError(t *testing.T) {
+	err := PreconditionFailedError{Message: "resource was modified"}
+	if err.Error() != "resource was modified" {
+		t.Errorf("expected 'resource was modified', got '%s'", err.Error())
+	}
+}
+
 func TestRateLimitError(t *testing.T) {
 	err := RateLimitError{Message: "rate limit exceeded"}
 	if err.Error() != "rate limit exceeded" {
@@ -83,6 +90,11 @@ func TestHTTPStatusCode(t *testing.T) {
 			err:            ConflictError{Message: "conflict"},
 			expectedStatus: http.StatusConflict,
 		},
+		{
+			name:           "precondition failed error",
+			err:            PreconditionFailedError{Message: "modified"},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
 		{
 			name:           "rate limit error",
 			err:            RateLimitError{Message: "rate limit"},
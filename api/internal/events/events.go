@@ -0,0 +1,122 @@
+// Package events delivers domain events to in-process subscribers
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+)
+
+// Type names a kind of event
+type Type string
+
+// Post lifecycle events, emitted by the publish, unpublish and archive transitions, status edits and the scheduler
+const (
+	PostPublished   Type = "post.published"
+	PostUnpublished Type = "post.unpublished"
+	PostArchived    Type = "post.archived"
+)
+
+// transitionTypes maps each post transition to the event it emits
+var transitionTypes = map[model.PostTransition]Type{
+	model.PostTransitionPublish:   PostPublished,
+	model.PostTransitionUnpublish: PostUnpublished,
+	model.PostTransitionArchive:   PostArchived,
+}
+
+// ForTransition returns the type of event a post transition emits
+func ForTransition(transition model.PostTransition) Type {
+	return transitionTypes[transition]
+}
+
+// Event is something that happened to a post
+type Event struct {
+	Type   Type
+	PostID uuid.UUID
+	Slug   string
+	From   model.PostStatus // Status before the change
+	To     model.PostStatus // Status after the change
+	At     time.Time
+}
+
+// Subscriber reacts to an event; its error is logged and does not affect the publisher
+type Subscriber func(ctx context.Context, event Event) error
+
+// Bus dispatches events to the subscribers of their type
+// Delivery is synchronous and in subscription order, after the change has been committed
+type Bus struct {
+	log         *slog.Logger
+	mu          sync.RWMutex
+	subscribers map[Type][]Subscriber
+}
+
+// NewBus creates a bus that logs failing subscribers to log
+func NewBus(log *slog.Logger) *Bus {
+	return &Bus{log: log, subscribers: make(map[Type][]Subscriber)}
+}
+
+// Subscribe registers subscriber for events of the given type
+func (b *Bus) Subscribe(eventType Type, subscriber Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[eventType] = append(b.subscribers[eventType], subscriber)
+}
+
+// Publish delivers event to its subscribers
+// A failing or panicking subscriber is logged and the remaining subscribers still run; a nil bus drops the event
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	subscribers := b.subscribers[event.Type]
+	b.mu.RUnlock()
+
+	for _, subscriber := range subscribers {
+		if err := deliver(ctx, subscriber, event); err != nil {
+			b.log.Error("event subscriber failed",
+				slog.String("event", string(event.Type)),
+				slog.String("post_id", event.PostID.String()),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// deliver runs one subscriber, turning a panic into an error
+func deliver(ctx context.Context, subscriber Subscriber, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return subscriber(ctx, event)
+}
+
+// LogSubscriber records each event it receives in log
+func LogSubscriber(log *slog.Logger) Subscriber {
+	return func(ctx context.Context, event Event) error {
+		log.Info("post status changed",
+			slog.String("event", string(event.Type)),
+			slog.String("post_id", event.PostID.String()),
+			slog.String("slug", event.Slug),
+			slog.String("from", string(event.From)),
+			slog.String("to", string(event.To)),
+		)
+		return nil
+	}
+}
+
+// RefreshRelatedSubscriber rebuilds the related-posts index when posts change status, so reads never rebuild it
+func RefreshRelatedSubscriber(relatedRepo *model.RelatedPostRepository) Subscriber {
+	return func(ctx context.Context, event Event) error {
+		_, err := relatedRepo.RefreshIfStale(ctx, event.At)
+		return err
+	}
+}
@@ -0,0 +1,43 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func TestBusPublish(t *testing.T) {
+	bus := NewBus(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	var got []string
+	bus.Subscribe(PostPublished, func(ctx context.Context, event Event) error {
+		got = append(got, "first:"+event.Slug)
+		return errors.New("boom")
+	})
+	bus.Subscribe(PostPublished, func(ctx context.Context, event Event) error {
+		panic("subscriber bug")
+	})
+	bus.Subscribe(PostPublished, func(ctx context.Context, event Event) error {
+		got = append(got, "last:"+event.Slug)
+		return nil
+	})
+	bus.Subscribe(PostArchived, func(ctx context.Context, event Event) error {
+		got = append(got, "archived:"+event.Slug)
+		return nil
+	})
+
+	bus.Publish(context.Background(), Event{Type: PostPublished, Slug: "hello"})
+
+	want := []string{"first:hello", "last:hello"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestNilBusPublish(t *testing.T) {
+	var bus *Bus
+	bus.Publish(context.Background(), Event{Type: PostPublished})
+}
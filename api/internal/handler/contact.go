@@ -97,7 +97,11 @@ func (h *ContactHandler) SubmitContact(c *gin.Context) {
 // @Param			status	query		string	false	"Filter by status (received, replied, archived)"
 // @Param			limit	query		integer	false	"Number of submissions per page (default: 10)"
 // @Param			offset	query		integer	false	"Number of submissions to skip (default: 0)"
-// @Success		200		{array}		view.ContactSubmissionResponse	"List of submissions"
+// @Param			cursor	query		string	false	"Opaque cursor from a previous page's next_cursor; switches the response to a paginated envelope"
+// @Param			include_total	query		boolean	false	"Include the total item count"
+// @Param			fields	query		string	false	"Comma-separated response fields to return, e.g. id,slug,title,reading_time_minutes"
+// @Success		200		{array}		view.ContactSubmissionResponse	"List of submissions (view.PageResponse envelope when cursor is given)"
+// @Failure		400		{object}	map[string]string				"Invalid cursor"
 // @Failure		403		{object}	map[string]string				"Forbidden - admin role required"
 // @Router			/api/contact [get]
 // @Security		BearerAuth
@@ -109,15 +113,19 @@ func (h *ContactHandler) ListContactSubmissions(c *gin.Context) {
 	}
 
 	status := c.Query("status")
-	limit, offset := parsePaginationGin(c)
+	page, err := parsePageGin(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
 
 	var submissions []model.ContactSubmission
-	var err error
+	var next *model.Cursor
 
 	if status != "" {
-		submissions, err = h.contactRepo.ListByStatus(c, model.ContactStatus(status), limit, offset)
+		submissions, next, err = h.contactRepo.ListByStatus(c, model.ContactStatus(status), page)
 	} else {
-		submissions, err = h.contactRepo.ListActive(c, limit, offset)
+		submissions, next, err = h.contactRepo.ListActive(c, page)
 	}
 
 	if err != nil {
@@ -125,7 +133,23 @@ func (h *ContactHandler) ListContactSubmissions(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, view.ToContactSubmissionResponses(submissions))
+	var total *int
+	if wantsTotal(c) {
+		var count int
+		if status != "" {
+			count, err = h.contactRepo.CountByStatus(c, model.ContactStatus(status))
+		} else {
+			count, err = h.contactRepo.CountActive(c)
+		}
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count submissions"})
+			return
+		}
+		total = &count
+	}
+
+	writePageGin(c, view.ToContactSubmissionResponses(submissions), next, total)
 }
 
 // UpdateContactStatusRequest represents the request body for updating contact status
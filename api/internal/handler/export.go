@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/content"
+)
+
+// ExportHandler handles content export HTTP requests
+type ExportHandler struct {
+	exporter *content.Exporter
+}
+
+// NewExportHandler creates a new export handler
+func NewExportHandler(exporter *content.Exporter) *ExportHandler {
+	return &ExportHandler{
+		exporter: exporter,
+	}
+}
+
+// ExportContent handles GET /api/admin/export (admin only)
+// @Summary		Export all content
+// @Description	Download a zip of every post as markdown with front matter, approved guestbook entries as JSON, and the media posts reference (admin only). Exports of identical data are byte-identical
+// @Tags			Admin
+// @Produce		application/zip
+// @Success		200	{file}		binary				"Zip archive"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Router			/api/admin/export [get]
+// @Security		BearerAuth
+func (h *ExportHandler) ExportContent(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	// Build the archive in memory so a failure can still be reported as JSON
+	var buf bytes.Buffer
+	if err := h.exporter.Export(c, &buf); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export content"})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="export.zip"`)
+	c.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
@@ -101,18 +101,40 @@ func (h *GuestbookHandler) SubmitGuestbookEntry(c *gin.Context) {
 // @Produce		json
 // @Param			limit	query		integer	false	"Number of entries per page (default: 10)"
 // @Param			offset	query		integer	false	"Number of entries to skip (default: 0)"
-// @Success		200		{array}		view.GuestbookEntryResponse	"List of approved entries"
+// @Param			cursor	query		string	false	"Opaque cursor from a previous page's next_cursor; switches the response to a paginated envelope"
+// @Param			include_total	query		boolean	false	"Include the total item count"
+// @Param			fields	query		string	false	"Comma-separated response fields to return, e.g. id,slug,title,reading_time_minutes"
+// @Param			before	query		string	false	"Alias for cursor"
+// @Param			If-None-Match	header	string	false	"ETag from an earlier response; unchanged content returns 304"
+// @Success		200		{array}		view.GuestbookEntryResponse	"List of approved entries (view.PageResponse envelope when cursor is given)"
+// @Success		304		"Not modified"
+// @Failure		400		{object}	map[string]string			"Invalid cursor"
 // @Router			/api/guestbook [get]
 func (h *GuestbookHandler) ListApprovedGuestbookEntries(c *gin.Context) {
-	limit, offset := parsePaginationGin(c)
+	page, err := parsePageGin(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
 
-	entries, err := h.guestbookRepo.ListApproved(c, limit, offset)
+	entries, next, err := h.guestbookRepo.ListApproved(c, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list guestbook entries"})
 		return
 	}
 
-	c.JSON(http.StatusOK, view.ToGuestbookEntryResponses(entries))
+	var total *int
+	if wantsTotal(c) {
+		count, err := h.guestbookRepo.CountApproved(c)
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count guestbook entries"})
+			return
+		}
+		total = &count
+	}
+
+	writePageGin(c, view.ToGuestbookEntryResponses(entries), next, total)
 }
 
 // ListPendingGuestbookEntries handles GET /api/guestbook/pending (admin only)
@@ -122,7 +144,11 @@ func (h *GuestbookHandler) ListApprovedGuestbookEntries(c *gin.Context) {
 // @Produce		json
 // @Param			limit	query		integer	false	"Number of entries per page (default: 10)"
 // @Param			offset	query		integer	false	"Number of entries to skip (default: 0)"
-// @Success		200		{array}		view.GuestbookEntryResponse	"List of pending entries"
+// @Param			cursor	query		string	false	"Opaque cursor from a previous page's next_cursor; switches the response to a paginated envelope"
+// @Param			include_total	query		boolean	false	"Include the total item count"
+// @Param			fields	query		string	false	"Comma-separated response fields to return, e.g. id,slug,title,reading_time_minutes"
+// @Success		200		{array}		view.GuestbookEntryResponse	"List of pending entries (view.PageResponse envelope when cursor is given)"
+// @Failure		400		{object}	map[string]string			"Invalid cursor"
 // @Failure		403		{object}	map[string]string			"Forbidden - admin role required"
 // @Router			/api/guestbook/pending [get]
 // @Security		BearerAuth
@@ -133,15 +159,30 @@ func (h *GuestbookHandler) ListPendingGuestbookEntries(c *gin.Context) {
 		return
 	}
 
-	limit, offset := parsePaginationGin(c)
+	page, err := parsePageGin(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
 
-	entries, err := h.guestbookRepo.ListPending(c, limit, offset)
+	entries, next, err := h.guestbookRepo.ListPending(c, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list pending entries"})
 		return
 	}
 
-	c.JSON(http.StatusOK, view.ToGuestbookEntryResponses(entries))
+	var total *int
+	if wantsTotal(c) {
+		count, err := h.guestbookRepo.CountPending(c)
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count pending entries"})
+			return
+		}
+		total = &count
+	}
+
+	writePageGin(c, view.ToGuestbookEntryResponses(entries), next, total)
 }
 
 // ApproveGuestbookEntryRequest represents the request body for approving an entry
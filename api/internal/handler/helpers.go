@@ -1,12 +1,18 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
 )
 
 // statusCodeFromError converts domain errors to HTTP status codes
@@ -15,6 +21,8 @@ func statusCodeFromError(err error) (int, string) {
 	var notFoundErr apierrors.NotFoundError
 	var conflictErr apierrors.ConflictError
 	var forbiddenErr apierrors.ForbiddenError
+	var preconditionErr apierrors.PreconditionFailedError
+	var rateLimitErr apierrors.RateLimitError
 
 	if errors.As(err, &validationErr) {
 		return http.StatusBadRequest, validationErr.Message
@@ -28,6 +36,12 @@ func statusCodeFromError(err error) (int, string) {
 	if errors.As(err, &forbiddenErr) {
 		return http.StatusForbidden, forbiddenErr.Message
 	}
+	if errors.As(err, &preconditionErr) {
+		return http.StatusPreconditionFailed, preconditionErr.Message
+	}
+	if errors.As(err, &rateLimitErr) {
+		return http.StatusTooManyRequests, rateLimitErr.Message
+	}
 
 	return http.StatusInternalServerError, "internal server error"
 }
@@ -51,3 +65,155 @@ func parsePaginationGin(c *gin.Context) (limit, offset int) {
 
 	return
 }
+
+// parsePageGin extracts limit/offset or cursor pagination parameters from Gin context
+// A cursor (or its alias, before) takes precedence over offset
+func parsePageGin(c *gin.Context) (model.PageRequest, error) {
+	limit, offset := parsePaginationGin(c)
+	page := model.PageRequest{Limit: limit, Offset: offset}
+
+	cursor := c.Query("cursor")
+	if cursor == "" {
+		cursor = c.Query("before")
+	}
+
+	if cursor != "" {
+		after, err := model.DecodeCursor(cursor)
+		if err != nil {
+			return page, err
+		}
+		page.After = after
+	}
+
+	return page, nil
+}
+
+// isCursorPagination reports whether the client opted into cursor pagination
+// Such requests get an envelope response; plain limit/offset requests keep the bare array
+func isCursorPagination(c *gin.Context) bool {
+	_, cursor := c.GetQuery("cursor")
+	_, before := c.GetQuery("before")
+	return cursor || before
+}
+
+// wantsTotal reports whether the client asked for the total item count
+func wantsTotal(c *gin.Context) bool {
+	include, _ := strconv.ParseBool(c.Query("include_total"))
+	return include
+}
+
+// writePageGin writes a page of list results
+// Every response carries RFC 8288 Link headers and, when counted, an X-Total-Count header
+func writePageGin(c *gin.Context, items interface{}, next *model.Cursor, total *int) {
+	items, err := selectFields(items, c.Query("fields"))
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	links := []string{fmt.Sprintf(`<%s>; rel="first"`, pageURL(c, ""))}
+	var nextCursor *string
+	if next != nil {
+		encoded := next.Encode()
+		nextCursor = &encoded
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(c, encoded)))
+	}
+	c.Header("Link", strings.Join(links, ", "))
+
+	if total != nil {
+		c.Header("X-Total-Count", strconv.Itoa(*total))
+	}
+
+	if !isCursorPagination(c) {
+		c.JSON(http.StatusOK, items)
+		return
+	}
+
+	c.JSON(http.StatusOK, view.PageResponse{
+		Items:      items,
+		NextCursor: nextCursor,
+		Total:      total,
+	})
+}
+
+// selectFields trims each item of a list to the comma-separated JSON fields requested with fields=
+// Without a selection the items are returned unchanged
+func selectFields(items interface{}, fields string) (interface{}, error) {
+	if strings.TrimSpace(fields) == "" {
+		return items, nil
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(items).Elem())
+	wanted := make(map[string]bool)
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !known[field] {
+			return nil, apierrors.ValidationError{Message: fmt.Sprintf("unknown field '%s'", field)}
+		}
+		wanted[field] = true
+	}
+
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &selected); err != nil {
+		return nil, err
+	}
+
+	for _, item := range selected {
+		for field := range item {
+			if !wanted[field] {
+				delete(item, field)
+			}
+		}
+	}
+
+	return selected, nil
+}
+
+// jsonFieldNames returns the JSON names of a struct type's fields
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	names := make(map[string]bool)
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// pageURL returns the request URL repositioned at the given cursor, or at the first page when cursor is empty
+// The first page of a limit/offset request carries no cursor, so following it keeps the bare array response
+func pageURL(c *gin.Context, cursor string) string {
+	query := c.Request.URL.Query()
+	query.Del("offset")
+	query.Del("before")
+	if cursor != "" || isCursorPagination(c) {
+		query.Set("cursor", cursor)
+	} else {
+		query.Del("cursor")
+	}
+
+	u := *c.Request.URL
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
@@ -1,18 +1,40 @@
 package handler
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/content"
 	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+	"github.com/sochoa/sochoa.dev/api/internal/events"
+	"github.com/sochoa/sochoa.dev/api/internal/mail"
+	"github.com/sochoa/sochoa.dev/api/internal/media"
 	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/scheduler"
+	"github.com/sochoa/sochoa.dev/api/internal/storage"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+	"github.com/sochoa/sochoa.dev/api/internal/webmention"
 )
 
 // mockTokenVerifier for testing auth
@@ -29,6 +51,21 @@ func (m *testTokenVerifier) VerifyToken(ctx context.Context, authHeader string)
 
 // setupTestRouter creates a router with real repositories backed by SQLite
 func setupTestRouter(t *testing.T, verifier auth.TokenVerifier) (*gin.Engine, interface{ Close() error }, *model.PostRepository, *model.GuestbookRepository, *model.ContactRepository, *model.StatsRepository) {
+	return setupTestRouterWithEvents(t, verifier, events.NewBus(createTestLogger()))
+}
+
+// setupTestRouterWithEvents creates a test router that emits events on bus
+func setupTestRouterWithEvents(t *testing.T, verifier auth.TokenVerifier, bus *events.Bus) (*gin.Engine, interface{ Close() error }, *model.PostRepository, *model.GuestbookRepository, *model.ContactRepository, *model.StatsRepository) {
+	return setupTestRouterWith(t, verifier, bus, mail.NewMemory())
+}
+
+// setupTestRouterWithMailer creates a test router that sends email through mailer
+func setupTestRouterWithMailer(t *testing.T, verifier auth.TokenVerifier, mailer mail.Mailer) (*gin.Engine, interface{ Close() error }, *model.PostRepository, *model.GuestbookRepository, *model.ContactRepository, *model.StatsRepository) {
+	return setupTestRouterWith(t, verifier, events.NewBus(createTestLogger()), mailer)
+}
+
+// setupTestRouterWith creates a test router with the given event bus and mailer
+func setupTestRouterWith(t *testing.T, verifier auth.TokenVerifier, bus *events.Bus, mailer mail.Mailer) (*gin.Engine, interface{ Close() error }, *model.PostRepository, *model.GuestbookRepository, *model.ContactRepository, *model.StatsRepository) {
 	gin.SetMode(gin.TestMode)
 
 	// Set up test database connection (with query conversion for SQLite)
@@ -39,12 +76,31 @@ func setupTestRouter(t *testing.T, verifier auth.TokenVerifier) (*gin.Engine, in
 	guestbookRepo := model.NewGuestbookRepository(adapter)
 	contactRepo := model.NewContactRepository(adapter)
 	statsRepo := model.NewStatsRepository(adapter)
+	tagRepo := model.NewTagRepository(adapter)
+	seriesRepo := model.NewSeriesRepository(adapter)
+	relatedRepo := model.NewRelatedPostRepository(adapter)
+	previewRepo := model.NewPreviewTokenRepository(adapter)
+	reactionRepo := model.NewReactionRepository(adapter)
+	webmentionRepo := model.NewWebmentionRepository(adapter)
+	resumeRepo := model.NewResumeRepository(adapter)
+	projectRepo := model.NewProjectRepository(adapter)
+	talkRepo := model.NewTalkRepository(adapter)
+	newsletterRepo := model.NewNewsletterRepository(adapter)
+	sitePageRepo := model.NewSitePageRepository(adapter)
+
+	// Store media in a per-test directory
+	mediaStore, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create media storage: %v", err)
+	}
+	mediaLibrary := media.NewLibrary(model.NewMediaRepository(adapter), mediaStore, media.Options{})
 
 	// Create logger
 	logger := createTestLogger()
 
 	// Create and register routes
-	router := NewRouter(logger, verifier, postRepo, guestbookRepo, contactRepo, statsRepo)
+	previewSigner := auth.NewPreviewSigner([]byte("test-preview-secret"))
+	router := NewRouter(logger, verifier, postRepo, guestbookRepo, contactRepo, statsRepo, tagRepo, seriesRepo, relatedRepo, model.RelatedOptions{}, previewRepo, previewSigner, mediaLibrary, reactionRepo, webmentionRepo, resumeRepo, projectRepo, talkRepo, newsletterRepo, sitePageRepo, mailer, "", "https://sochoa.dev", false, CachePolicies{}, bus)
 
 	return router.Register(), adapter, postRepo, guestbookRepo, contactRepo, statsRepo
 }
@@ -133,27 +189,3304 @@ func TestPostListPublished(t *testing.T) {
 	}
 }
 
-// Test Guestbook Handler Integration
-
-func TestGuestbookListApproved(t *testing.T) {
+func TestPostListCursorPagination(t *testing.T) {
 	verifier := &testTokenVerifier{}
-	router, closer, _, _, _, _ := setupTestRouter(t, verifier)
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
 	defer closer.Close()
 
+	// Posts sharing a published_at must still page stably via the ID tiebreaker
+	publishedAt := time.Now().UTC().Add(-time.Hour)
+	for _, slug := range []string{"post-a", "post-b", "post-c", "post-d", "post-e"} {
+		post := &model.Post{
+			Slug:        slug,
+			Title:       slug,
+			Body:        "Body",
+			Status:      model.PostStatusPublished,
+			PublishedAt: &publishedAt,
+		}
+		if err := postRepo.Create(context.Background(), post); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+	}
+
+	// A published row missing published_at is not listed, so it cannot break the cursor
+	legacy := &model.Post{Slug: "legacy", Title: "Legacy", Body: "Body", Status: model.PostStatusPublished}
+	if err := postRepo.Create(context.Background(), legacy); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	if _, err := closer.(*sqliteAdapter).ExecContext(context.Background(), `UPDATE posts SET published_at = NULL WHERE slug = 'legacy'`); err != nil {
+		t.Fatalf("failed to clear published_at: %v", err)
+	}
+
+	seen := map[string]bool{}
+	path := "/api/posts?limit=2&include_total=true&cursor="
+	for pages := 0; path != ""; pages++ {
+		if pages > 3 {
+			t.Fatal("pagination did not terminate")
+		}
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		var page struct {
+			Items      []view.PostResponse `json:"items"`
+			NextCursor *string             `json:"next_cursor"`
+			Total      *int                `json:"total"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &page)
+
+		if page.Total == nil || *page.Total != 5 {
+			t.Errorf("expected total 5, got %v", page.Total)
+		}
+		for _, p := range page.Items {
+			if seen[p.Slug] {
+				t.Errorf("post %s returned on more than one page", p.Slug)
+			}
+			seen[p.Slug] = true
+		}
+
+		path = ""
+		if page.NextCursor != nil {
+			if !strings.Contains(w.Header().Get("Link"), `rel="next"`) {
+				t.Errorf("expected Link header with rel=next, got %q", w.Header().Get("Link"))
+			}
+			path = "/api/posts?limit=2&include_total=true&cursor=" + url.QueryEscape(*page.NextCursor)
+		}
+	}
+
+	if len(seen) != 5 {
+		t.Errorf("expected to page through 5 posts, got %d", len(seen))
+	}
+
+	// Offset paging keeps the bare array response
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("GET", "/api/guestbook", nil)
+	req, _ := http.NewRequest("GET", "/api/posts?limit=2&offset=4", nil)
+	router.ServeHTTP(w, req)
+
+	var listed []view.PostResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("expected array response for offset paging: %v", err)
+	}
+	if len(listed) != 1 {
+		t.Errorf("expected 1 post at offset 4, got %d", len(listed))
+	}
+	if link := w.Header().Get("Link"); !strings.Contains(link, `</api/posts?limit=2>; rel="first"`) {
+		t.Errorf("expected the first page link to keep offset paging, got %q", link)
+	}
+
+	// Garbage cursors are rejected
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/posts?cursor=not-a-cursor", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestPostSlugChangeRedirects(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{
+				ID:     "admin-user",
+				Email:  "admin@example.com",
+				Groups: []string{"admin"},
+			}, nil
+		},
+	}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	post := &model.Post{
+		Slug:   "original-slug",
+		Title:  "Moving Post",
+		Body:   "Body",
+		Status: model.PostStatusPublished,
+	}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	body, _ := json.Marshal(UpdatePostRequest{
+		Slug:   "renamed-slug",
+		Title:  "Moving Post",
+		Body:   "Body",
+		Status: "published",
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/api/posts/"+post.ID.String(), bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// The old slug redirects permanently to the new one
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/posts/original-slug", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusMovedPermanently, w.Code, w.Body.String())
+	}
+	if w.Header().Get("Location") != "/api/posts/renamed-slug" {
+		t.Errorf("expected Location /api/posts/renamed-slug, got %q", w.Header().Get("Location"))
+	}
+
+	var redirect view.PostRedirectResponse
+	json.Unmarshal(w.Body.Bytes(), &redirect)
+	if redirect.Slug != "renamed-slug" {
+		t.Errorf("expected canonical slug renamed-slug, got %q", redirect.Slug)
+	}
 
+	// Another post cannot take over the historical slug
+	w = httptest.NewRecorder()
+	body, _ = json.Marshal(CreatePostRequest{
+		Slug:   "original-slug",
+		Title:  "Squatter",
+		Body:   "Body",
+		Status: "draft",
+	})
+	req, _ = http.NewRequest("POST", "/api/posts", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d. Body: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+
+	// The post itself may return to its old slug; reload it first since the rename bumped its version
+	post, err := postRepo.GetByID(context.Background(), post.ID)
+	if err != nil {
+		t.Fatalf("failed to reload post: %v", err)
+	}
+	post.Slug = "original-slug"
+	if err := postRepo.Update(context.Background(), post); err != nil {
+		t.Fatalf("failed to restore original slug: %v", err)
+	}
 
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/posts/original-slug", nil)
+	router.ServeHTTP(w, req)
 	if w.Code != http.StatusOK {
 		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
 	}
 
-	// Should return empty array
-	var response map[string]interface{}
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/posts/renamed-slug", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusMovedPermanently {
+		t.Errorf("expected status %d, got %d", http.StatusMovedPermanently, w.Code)
+	}
+}
+
+func TestSeriesNavigation(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{
+				ID:     "admin-user",
+				Email:  "admin@example.com",
+				Groups: []string{"admin"},
+			}, nil
+		},
+	}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	var ids []string
+	for _, p := range []struct {
+		slug   string
+		status model.PostStatus
+	}{
+		{"part-one", model.PostStatusPublished},
+		{"part-two", model.PostStatusDraft},
+		{"part-three", model.PostStatusPublished},
+		{"standalone", model.PostStatusPublished},
+	} {
+		post := &model.Post{Slug: p.slug, Title: p.slug, Body: "Body", Status: p.status}
+		if err := postRepo.Create(context.Background(), post); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+		ids = append(ids, post.ID.String())
+	}
+
+	adminRequest := func(method, path string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer valid-token")
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := adminRequest("POST", "/api/admin/series", SeriesRequest{Slug: "building-a-blog", Title: "Building a Blog"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var series view.SeriesResponse
+	json.Unmarshal(w.Body.Bytes(), &series)
+
+	w = adminRequest("PUT", "/api/admin/series/"+series.ID.String()+"/posts", map[string][]string{"post_ids": ids[:3]})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	json.Unmarshal(w.Body.Bytes(), &series)
+	if len(series.Posts) != 3 {
+		t.Fatalf("expected admin view of 3 posts, got %d", len(series.Posts))
+	}
+
+	// Public readers skip the draft: part three follows part one directly
+	w = httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/posts/part-three", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var post view.PostResponse
+	json.Unmarshal(w.Body.Bytes(), &post)
+	if post.Series == nil {
+		t.Fatal("expected series navigation on post")
+	}
+	if post.Series.Slug != "building-a-blog" || post.Series.Position != 2 || post.Series.Total != 2 {
+		t.Errorf("expected position 2 of 2 in building-a-blog, got %+v", post.Series)
+	}
+	if post.Series.Previous == nil || post.Series.Previous.Slug != "part-one" {
+		t.Errorf("expected previous part-one, got %+v", post.Series.Previous)
+	}
+	if post.Series.Next != nil {
+		t.Errorf("expected no next post, got %+v", post.Series.Next)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/series/building-a-blog", nil)
+	router.ServeHTTP(w, req)
+	json.Unmarshal(w.Body.Bytes(), &series)
+	if len(series.Posts) != 2 || series.Posts[1].Slug != "part-three" {
+		t.Errorf("expected public series of part-one and part-three, got %+v", series.Posts)
+	}
+
+	// Posts outside a series carry no navigation
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/posts/standalone", nil)
+	router.ServeHTTP(w, req)
+	post = view.PostResponse{}
+	json.Unmarshal(w.Body.Bytes(), &post)
+	if post.Series != nil {
+		t.Errorf("expected no series navigation, got %+v", post.Series)
+	}
+
+	// A post may belong to only one series
+	w = adminRequest("POST", "/api/admin/series", SeriesRequest{Slug: "other-series", Title: "Other"})
+	var other view.SeriesResponse
+	json.Unmarshal(w.Body.Bytes(), &other)
+	w = adminRequest("PUT", "/api/admin/series/"+other.ID.String()+"/posts", map[string][]string{"post_ids": {ids[0]}})
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d. Body: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+}
+
+func TestRelatedPosts(t *testing.T) {
+	verifier := &testTokenVerifier{}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	create := func(slug, title, body string, tags []string, status model.PostStatus) *model.Post {
+		post := &model.Post{Slug: slug, Title: title, Body: body, Tags: tags, Status: status}
+		if err := postRepo.Create(context.Background(), post); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+		return post
+	}
+
+	create("gin-handlers", "Writing Gin handlers", "Routers, middleware and handlers for a JSON API in Go", []string{"go", "api"}, model.PostStatusPublished)
+	create("testing-handlers", "Testing Gin handlers", "Table-driven tests for handlers and middleware", []string{"go", "testing"}, model.PostStatusPublished)
+	draft := create("draft-handlers", "Gin handler tricks", "More handlers and middleware", []string{"go", "api"}, model.PostStatusDraft)
+	create("go-modules", "Go modules", "Versioning dependencies", []string{"go"}, model.PostStatusPublished)
+	sourdough := create("sourdough", "Sourdough starter notes", "Flour, water and patience", []string{"baking"}, model.PostStatusPublished)
+
+	// Reads serve the stored index; the scheduler job builds it
+	refresh := scheduler.RefreshRelatedPosts(createTestLogger(), model.NewRelatedPostRepository(closer.(*sqliteAdapter)))
+	if err := refresh.Run(context.Background(), time.Now().UTC()); err != nil {
+		t.Fatalf("failed to build related index: %v", err)
+	}
+
+	listRelated := func(path string) []view.RelatedPostResponse {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var related []view.RelatedPostResponse
+		json.Unmarshal(w.Body.Bytes(), &related)
+		return related
+	}
+
+	related := listRelated("/api/posts/gin-handlers/related")
+	var slugs []string
+	for _, r := range related {
+		slugs = append(slugs, r.Slug)
+	}
+	if !reflect.DeepEqual(slugs, []string{"testing-handlers", "go-modules"}) {
+		t.Errorf("expected [testing-handlers go-modules] without drafts or unrelated posts, got %v", slugs)
+	}
+	if len(related) == 2 && related[0].Score <= related[1].Score {
+		t.Errorf("expected scores in descending order, got %v and %v", related[0].Score, related[1].Score)
+	}
+
+	if related = listRelated("/api/posts/gin-handlers/related?limit=1"); len(related) != 1 {
+		t.Errorf("expected limit to cap results at 1, got %d", len(related))
+	}
+
+	// Editing a post leaves reads on the stored index until the job refreshes it
+	sourdough.Title = "Gin handlers for a bakery"
+	sourdough.Tags = []string{"go", "api"}
+	if err := postRepo.Update(context.Background(), sourdough); err != nil {
+		t.Fatalf("failed to update post: %v", err)
+	}
+	related = listRelated("/api/posts/gin-handlers/related")
+	if len(related) == 0 || related[0].Slug == "sourdough" {
+		t.Errorf("expected reads not to rebuild the index, got %+v", related)
+	}
+	if err := refresh.Run(context.Background(), time.Now().UTC()); err != nil {
+		t.Fatalf("failed to refresh related index: %v", err)
+	}
+	related = listRelated("/api/posts/gin-handlers/related")
+	if len(related) == 0 || related[0].Slug != "sourdough" {
+		t.Errorf("expected updated post to rank first, got %+v", related)
+	}
+
+	// Drafts have no public related list
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/posts/draft-handlers/related", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// A status change event refreshes the index
+	relatedRepo := model.NewRelatedPostRepository(closer.(*sqliteAdapter))
+	bus := events.NewBus(createTestLogger())
+	bus.Subscribe(events.PostPublished, events.RefreshRelatedSubscriber(relatedRepo))
+	draft.Status = model.PostStatusPublished
+	if err := postRepo.Update(context.Background(), draft); err != nil {
+		t.Fatalf("failed to publish post: %v", err)
+	}
+	if stale, err := relatedRepo.IsStale(context.Background()); err != nil || !stale {
+		t.Fatalf("expected the index to be stale after an edit, got %v %v", stale, err)
+	}
+	bus.Publish(context.Background(), events.Event{Type: events.PostPublished, PostID: draft.ID, Slug: draft.Slug, At: time.Now().UTC()})
+	if stale, err := relatedRepo.IsStale(context.Background()); err != nil || stale {
+		t.Errorf("expected the event to refresh the index, got stale %v %v", stale, err)
+	}
+}
+
+func TestPostReadingMetadataAndFields(t *testing.T) {
+	verifier := &testTokenVerifier{}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	post := &model.Post{
+		Slug:   "long-read",
+		Title:  "A Long Read",
+		Body:   "# Intro\n" + strings.Repeat("word ", 450) + "\n## Details\nMore words here",
+		Status: model.PostStatusPublished,
+	}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/posts/long-read", nil)
+	router.ServeHTTP(w, req)
+	var response view.PostResponse
 	json.Unmarshal(w.Body.Bytes(), &response)
-	if response == nil {
-		t.Logf("got empty response (expected)")
+	if response.WordCount != 455 || response.ReadingTime != 3 {
+		t.Errorf("expected 455 words and 3 minutes, got %d words and %d minutes", response.WordCount, response.ReadingTime)
+	}
+	if len(response.TOC) != 1 || response.TOC[0].Anchor != "intro" || len(response.TOC[0].Children) != 1 || response.TOC[0].Children[0].Anchor != "details" {
+		t.Errorf("expected intro > details table of contents, got %+v", response.TOC)
+	}
+
+	// fields= trims list items, leaving the body out
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/posts?fields=slug,reading_time_minutes", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var items []map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &items)
+	if len(items) != 1 || !reflect.DeepEqual(items[0], map[string]interface{}{"slug": "long-read", "reading_time_minutes": float64(3)}) {
+		t.Errorf("expected only slug and reading time, got %v", items)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/posts?fields=slug,nope", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for unknown field, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Posts written before metadata was stored are filled in by the backfill
+	before, err := postRepo.GetBySlug(context.Background(), "long-read")
+	if err != nil {
+		t.Fatalf("failed to get post: %v", err)
+	}
+	adapter := closer.(*sqliteAdapter)
+	if _, err := adapter.ExecContext(context.Background(), `UPDATE posts SET word_count = NULL, reading_time = NULL, toc = NULL`); err != nil {
+		t.Fatalf("failed to clear metadata: %v", err)
+	}
+	updated, err := postRepo.ComputeMissingReadingMetadata(context.Background())
+	if err != nil || updated != 1 {
+		t.Fatalf("expected 1 post backfilled, got %d (err: %v)", updated, err)
+	}
+	stored, err := postRepo.GetBySlug(context.Background(), "long-read")
+	if err != nil || stored.WordCount != 455 || len(stored.TOC) != 1 {
+		t.Errorf("expected backfilled metadata, got %+v (err: %v)", stored, err)
+	}
+	if stored.Version != before.Version {
+		t.Errorf("expected backfill to keep version %d, got %d", before.Version, stored.Version)
+	}
+}
+
+func TestMediaUnavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Startup keeps going when media storage fails; its routes answer 503 instead of panicking
+	mediaHandler := NewMediaHandler(nil)
+	engine := gin.New()
+	engine.GET("/media/*key", mediaHandler.requireLibrary, mediaHandler.ServeMedia)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/media/ab/abcdef.png", nil)
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
+func TestMediaUploadIntegration(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{
+				ID:     "admin-user",
+				Email:  "admin@example.com",
+				Groups: []string{"admin"},
+			}, nil
+		},
+	}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	// A 1000x500 PNG gets a thumbnail plus the 480 and 960 widths
+	img := image.NewRGBA(image.Rect(0, 0, 1000, 500))
+	for i := range img.Pix {
+		img.Pix[i] = 200
+	}
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+
+	upload := func(filename string, data []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("file", filename)
+		part.Write(data)
+		form.Close()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/admin/media", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer admin-token")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := upload("banner.png", encoded.Bytes())
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var asset view.MediaAssetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &asset); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if asset.ContentType != "image/png" || asset.Width != 1000 || asset.Height != 500 {
+		t.Errorf("unexpected asset: %+v", asset)
+	}
+	var names []string
+	for _, v := range asset.Variants {
+		names = append(names, v.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"thumb", "w480", "w960"}) {
+		t.Errorf("expected variants [thumb w480 w960], got %v", names)
+	}
+
+	// Identical content under another name is deduplicated
+	w = upload("copy.png", encoded.Bytes())
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d for duplicate upload, got %d", http.StatusOK, w.Code)
+	}
+	var duplicate view.MediaAssetResponse
+	json.Unmarshal(w.Body.Bytes(), &duplicate)
+	if duplicate.ID != asset.ID {
+		t.Errorf("expected duplicate upload to return asset %s, got %s", asset.ID, duplicate.ID)
+	}
+
+	// Content is sniffed, not trusted from the filename
+	w = upload("script.png", []byte("<html><script>alert(1)</script></html>"))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for unsupported type, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Stored files are served publicly
+	w = httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", asset.Variants[1].URL, nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d serving variant, got %d", http.StatusOK, w.Code)
+	}
+	served, err := png.Decode(w.Body)
+	if err != nil {
+		t.Fatalf("failed to decode served variant: %v", err)
+	}
+	if served.Bounds().Dx() != 480 || served.Bounds().Dy() != 240 {
+		t.Errorf("expected 480x240 variant, got %v", served.Bounds())
+	}
+
+	// A post embedding the image blocks deletion unless forced
+	post := &model.Post{
+		Slug:   "with-banner",
+		Title:  "With Banner",
+		Body:   "![banner](" + asset.Variants[2].URL + ")",
+		Status: model.PostStatusDraft,
+	}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/admin/media", nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	router.ServeHTTP(w, req)
+	var listed []view.MediaAssetResponse
+	json.Unmarshal(w.Body.Bytes(), &listed)
+	if len(listed) != 1 || len(listed[0].References) != 1 || listed[0].References[0].Slug != "with-banner" || listed[0].References[0].Type != "post" {
+		t.Fatalf("expected one asset referenced by with-banner, got %s", w.Body.String())
+	}
+
+	// Translations and pages hold references too, even when the post itself does not
+	pixel := image.NewGray(image.Rect(0, 0, 8, 8))
+	var pixelPNG bytes.Buffer
+	png.Encode(&pixelPNG, pixel)
+	w = upload("pixel.png", pixelPNG.Bytes())
+	var icon view.MediaAssetResponse
+	json.Unmarshal(w.Body.Bytes(), &icon)
+
+	plain := &model.Post{Slug: "plain", Title: "Plain", Body: "No images", Status: model.PostStatusDraft}
+	if err := postRepo.Create(context.Background(), plain); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	translation := &model.PostTranslation{PostID: plain.ID, Locale: "es", Slug: "sencillo", Title: "Sencillo", Body: "![icono](" + icon.URL + ")"}
+	if err := postRepo.SaveTranslation(context.Background(), translation); err != nil {
+		t.Fatalf("failed to save translation: %v", err)
+	}
+	page := &model.SitePage{Path: "about", Title: "About", Body: "![icon](" + icon.URL + ")"}
+	if err := model.NewSitePageRepository(closer.(*sqliteAdapter)).Create(context.Background(), page); err != nil {
+		t.Fatalf("failed to create page: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("DELETE", "/api/admin/media/"+icon.ID.String(), nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	router.ServeHTTP(w, req)
+	var conflict struct {
+		References []view.MediaReferenceResponse `json:"references"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &conflict)
+	if w.Code != http.StatusConflict || len(conflict.References) != 2 {
+		t.Fatalf("expected status %d listing the translation and page, got %d. Body: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	kinds := map[string]string{}
+	for _, ref := range conflict.References {
+		kinds[ref.Type] = ref.Slug
+	}
+	if kinds["translation"] != "sencillo" || kinds["page"] != "about" {
+		t.Errorf("expected the es translation and the about page, got %+v", conflict.References)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("DELETE", "/api/admin/media/"+asset.ID.String(), nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status %d deleting referenced asset, got %d", http.StatusConflict, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("DELETE", "/api/admin/media/"+asset.ID.String()+"?force=true", nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d on forced delete, got %d. Body: %s", http.StatusNoContent, w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", asset.URL, nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for deleted file, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestContentSyncFollowsTransitions(t *testing.T) {
+	bus := events.NewBus(createTestLogger())
+	var received []events.Event
+	for _, eventType := range []events.Type{events.PostPublished, events.PostUnpublished, events.PostArchived} {
+		bus.Subscribe(eventType, func(ctx context.Context, event events.Event) error {
+			received = append(received, event)
+			return nil
+		})
+	}
+
+	_, closer, postRepo, _, _, _ := setupTestRouter(t, &testTokenVerifier{})
+	defer closer.Close()
+
+	ctx := context.Background()
+	for _, post := range []*model.Post{
+		{Slug: "going-live", Title: "Going live", Body: "Body", Status: model.PostStatusDraft},
+		{Slug: "retired", Title: "Retired", Body: "Body", Status: model.PostStatusArchived},
+		{Slug: "removed", Title: "Removed", Body: "Body", Status: model.PostStatusPublished},
+	} {
+		if err := postRepo.Create(ctx, post); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+	}
+
+	sync := func(docs []content.Document) error {
+		existing, err := postRepo.ListAll(ctx)
+		if err != nil {
+			t.Fatalf("failed to list posts: %v", err)
+		}
+		return content.Apply(ctx, postRepo, bus, content.Plan(docs, existing, true))
+	}
+
+	// An archived post cannot go back to draft, and the rejected move stops the sync before anything is written
+	err := sync([]content.Document{
+		{Path: "going-live.md", Slug: "going-live", Title: "Going live", Body: "Body", Status: model.PostStatusPublished},
+		{Path: "retired.md", Slug: "retired", Title: "Retired", Body: "Body", Status: model.PostStatusDraft},
+	})
+	var conflict apierrors.ConflictError
+	if !errors.As(err, &conflict) || !strings.HasPrefix(err.Error(), "retired.md: ") {
+		t.Fatalf("expected a conflict for retired.md, got %v", err)
+	}
+	if post, _ := postRepo.GetBySlug(ctx, "going-live"); post.Status != model.PostStatusDraft {
+		t.Errorf("expected no post written after a rejected move, got going-live %s", post.Status)
+	}
+	if len(received) != 0 {
+		t.Errorf("expected no events after a rejected move, got %+v", received)
+	}
+
+	err = sync([]content.Document{
+		{Path: "going-live.md", Slug: "going-live", Title: "Going live", Body: "Body", Status: model.PostStatusPublished},
+		{Path: "retired.md", Slug: "retired", Title: "Retired", Body: "Body", Status: model.PostStatusArchived},
+	})
+	if err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+
+	got := map[string]events.Type{}
+	for _, event := range received {
+		got[event.Slug] = event.Type
+	}
+	want := map[string]events.Type{"going-live": events.PostPublished, "removed": events.PostArchived}
+	if !reflect.DeepEqual(got, want) || len(received) != 2 {
+		t.Errorf("expected %v, got %+v", want, received)
+	}
+}
+
+func TestContentExportIntegration(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{
+				ID:     "admin-user",
+				Email:  "admin@example.com",
+				Groups: []string{"admin"},
+			}, nil
+		},
+	}
+	router, closer, postRepo, guestbookRepo, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+	ctx := context.Background()
+
+	// One referenced and one unreferenced image
+	upload := func(shade uint8) view.MediaAssetResponse {
+		img := image.NewGray(image.Rect(0, 0, 8, 8))
+		for i := range img.Pix {
+			img.Pix[i] = shade
+		}
+		var encoded bytes.Buffer
+		png.Encode(&encoded, img)
+
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("file", "pixel.png")
+		part.Write(encoded.Bytes())
+		form.Close()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/admin/media", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer admin-token")
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("failed to upload media: %d %s", w.Code, w.Body.String())
+		}
+		var asset view.MediaAssetResponse
+		json.Unmarshal(w.Body.Bytes(), &asset)
+		return asset
+	}
+	referenced := upload(10)
+	translated := upload(100)
+	upload(200)
+
+	published := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, post := range []*model.Post{
+		{Slug: "zeta", Title: "Zeta", Body: "![pixel](" + referenced.URL + ")", Tags: []string{"go"}, Status: model.PostStatusPublished, PublishedAt: &published},
+		{Slug: "alpha", Title: "Alpha", Body: "Draft body", Status: model.PostStatusDraft},
+	} {
+		if err := postRepo.Create(ctx, post); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+	}
+
+	alpha, _ := postRepo.GetBySlug(ctx, "alpha")
+	translation := &model.PostTranslation{PostID: alpha.ID, Locale: "es", Slug: "alfa", Title: "Alfa", Body: "![pixel](" + translated.URL + ")"}
+	if err := postRepo.SaveTranslation(ctx, translation); err != nil {
+		t.Fatalf("failed to save translation: %v", err)
+	}
+
+	for i, approve := range []bool{true, false} {
+		entry := &model.GuestbookEntry{UserProvider: "google", UserID: fmt.Sprintf("user-%d", i), DisplayName: "Visitor", Message: fmt.Sprintf("Hello %d", i)}
+		if err := guestbookRepo.Create(ctx, entry); err != nil {
+			t.Fatalf("failed to create guestbook entry: %v", err)
+		}
+		if approve {
+			if err := guestbookRepo.Approve(ctx, entry.ID); err != nil {
+				t.Fatalf("failed to approve guestbook entry: %v", err)
+			}
+		}
+	}
+
+	export := func() []byte {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/admin/export", nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if w.Header().Get("Content-Type") != "application/zip" {
+			t.Errorf("expected application/zip, got %s", w.Header().Get("Content-Type"))
+		}
+		return w.Body.Bytes()
+	}
+
+	first := export()
+	if second := export(); !bytes.Equal(first, second) {
+		t.Fatalf("expected identical exports of identical data")
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(first), int64(len(first)))
+	if err != nil {
+		t.Fatalf("failed to open export: %v", err)
+	}
+	files := map[string]string{}
+	var names []string
+	for _, f := range archive.File {
+		rc, _ := f.Open()
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+		names = append(names, f.Name)
+	}
+
+	media := []string{"media/" + referenced.SHA256 + "/original.png", "media/" + translated.SHA256 + "/original.png"}
+	sort.Strings(media)
+	want := append([]string{"posts/alpha.md", "posts/zeta.md", "translations/es/alfa.md", "guestbook.json"}, append(media, "media.json")...)
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("expected entries %v, got %v", want, names)
+	}
+
+	if !strings.HasPrefix(files["translations/es/alfa.md"], "---\ntranslation_of: alpha\nlocale: es\nslug: alfa\n") {
+		t.Errorf("unexpected translation document:\n%s", files["translations/es/alfa.md"])
+	}
+
+	if !strings.Contains(files["posts/zeta.md"], "published_at: \"2024-01-02T03:04:05Z\"") || !strings.HasSuffix(files["posts/zeta.md"], referenced.URL+")\n") {
+		t.Errorf("unexpected post document:\n%s", files["posts/zeta.md"])
+	}
+
+	var guestbook []map[string]interface{}
+	json.Unmarshal([]byte(files["guestbook.json"]), &guestbook)
+	if len(guestbook) != 1 || guestbook[0]["message"] != "Hello 0" {
+		t.Errorf("expected only the approved guestbook entry, got %s", files["guestbook.json"])
+	}
+}
+
+func TestPostReactions(t *testing.T) {
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, &testTokenVerifier{})
+	defer closer.Close()
+
+	for i := 0; i < 7; i++ {
+		post := &model.Post{Slug: fmt.Sprintf("post-%d", i), Title: "Post", Body: "Body", Status: model.PostStatusPublished}
+		if err := postRepo.Create(context.Background(), post); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+	}
+	draft := &model.Post{Slug: "draft", Title: "Draft", Body: "Body", Status: model.PostStatusDraft}
+	if err := postRepo.Create(context.Background(), draft); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	react := func(slug string, payload interface{}, userAgent, remoteAddr string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/posts/"+slug+"/reactions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", userAgent)
+		req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+		req.RemoteAddr = remoteAddr
+		router.ServeHTTP(w, req)
+		return w
+	}
+	browser := "Mozilla/5.0 (X11; Linux x86_64) Firefox/131.0"
+
+	w := react("post-0", AddReactionRequest{Emoji: "👍"}, browser, "192.0.2.1:1234")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var reaction view.ReactionResponse
+	json.Unmarshal(w.Body.Bytes(), &reaction)
+	if reaction.Reactions["👍"] != 1 || len(reaction.Reactions) != len(model.ReactionEmojis) {
+		t.Errorf("expected one 👍 among all emojis, got %v", reaction.Reactions)
+	}
+
+	// The same visitor reacting again is not counted twice; another visitor is
+	w = react("post-0", AddReactionRequest{Emoji: "👍"}, browser, "192.0.2.1:4321")
+	if w.Code != http.StatusOK {
+		t.Errorf("expected duplicate to return %d, got %d", http.StatusOK, w.Code)
+	}
+	w = react("post-0", AddReactionRequest{Emoji: "👍"}, browser, "192.0.2.2:1234")
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected second visitor to return %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	// Bots and honeypot submissions look successful but are not counted
+	for _, tc := range []struct {
+		payload   AddReactionRequest
+		userAgent string
+	}{
+		{AddReactionRequest{Emoji: "👍"}, "Googlebot/2.1 (+http://www.google.com/bot.html)"},
+		{AddReactionRequest{Emoji: "👍"}, "curl/8.5.0"},
+		{AddReactionRequest{Emoji: "👍"}, ""},
+		{AddReactionRequest{Emoji: "👍", Honeypot: "https://spam.example"}, browser},
+	} {
+		w = react("post-0", tc.payload, tc.userAgent, "192.0.2.3:1234")
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected ignored reaction to return %d, got %d", http.StatusCreated, w.Code)
+		}
+	}
+
+	w = react("post-0", AddReactionRequest{Emoji: "🙂"}, browser, "192.0.2.1:1234")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected unknown emoji to return %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	w = react("draft", AddReactionRequest{Emoji: "👍"}, browser, "192.0.2.1:1234")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected draft to return %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// Counts appear on the post and in lists
+	w = httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/posts/post-0", nil)
+	router.ServeHTTP(w, req)
+	var post view.PostResponse
+	json.Unmarshal(w.Body.Bytes(), &post)
+	if post.Reactions["👍"] != 2 || post.Reactions["🎉"] != 0 {
+		t.Errorf("expected 2 👍 and 0 🎉, got %v", post.Reactions)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/posts", nil)
+	router.ServeHTTP(w, req)
+	var posts []view.PostResponse
+	json.Unmarshal(w.Body.Bytes(), &posts)
+	for _, p := range posts {
+		if p.Slug == "post-0" && p.Reactions["👍"] != 2 {
+			t.Errorf("expected listed post to carry 2 👍, got %v", p.Reactions)
+		}
+	}
+
+	// An address is limited across all posts for the day, whatever user agent it sends
+	sent := 1
+	for i := 1; i < 7 && sent < model.MaxReactionsPerDay; i++ {
+		for _, emoji := range model.ReactionEmojis {
+			if sent == model.MaxReactionsPerDay {
+				break
+			}
+			w = react(fmt.Sprintf("post-%d", i), AddReactionRequest{Emoji: emoji}, browser, "192.0.2.1:1234")
+			if w.Code != http.StatusCreated {
+				t.Fatalf("expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+			}
+			sent++
+		}
+	}
+	w = react("post-0", AddReactionRequest{Emoji: "❤️"}, browser, "192.0.2.1:1234")
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	w = react("post-0", AddReactionRequest{Emoji: "❤️"}, "Mozilla/5.0 (Macintosh) Safari/605.1.15", "192.0.2.1:1234")
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected a new user agent to stay limited with %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+
+	// Concurrent requests cannot all slip under the cap
+	var wg sync.WaitGroup
+	codes := make(chan int, model.MaxReactionsPerDay+5)
+	for i := 0; i < model.MaxReactionsPerDay+5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			slug := fmt.Sprintf("post-%d", i%7)
+			emoji := model.ReactionEmojis[(i/7)%len(model.ReactionEmojis)]
+			codes <- react(slug, AddReactionRequest{Emoji: emoji}, fmt.Sprintf("%s build/%d", browser, i), "192.0.2.3:1234").Code
+		}(i)
+	}
+	wg.Wait()
+	close(codes)
+
+	created := 0
+	for code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		case http.StatusTooManyRequests:
+		default:
+			t.Errorf("unexpected status %d", code)
+		}
+	}
+	if created != model.MaxReactionsPerDay {
+		t.Errorf("expected %d reactions recorded, got %d", model.MaxReactionsPerDay, created)
+	}
+}
+
+func TestPostTrash(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{
+				ID:     "admin-user",
+				Email:  "admin@example.com",
+				Groups: []string{"admin"},
+			}, nil
+		},
+	}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	post := &model.Post{Slug: "trash-me", Title: "Trash Me", Body: "Body", Status: model.PostStatusPublished}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	request := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer valid-token")
+		router.ServeHTTP(w, req)
+		return w
+	}
+	publicRequest := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := request("DELETE", "/api/posts/"+post.ID.String()); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusNoContent, w.Code, w.Body.String())
+	}
+
+	// Trashed posts are gone from public reads
+	if w := publicRequest("/api/posts/trash-me"); w.Code != http.StatusGone {
+		t.Errorf("expected status %d, got %d", http.StatusGone, w.Code)
+	}
+	if w := publicRequest("/api/posts/trash-me/related"); w.Code != http.StatusGone {
+		t.Errorf("expected related status %d, got %d", http.StatusGone, w.Code)
+	}
+	var posts []view.PostResponse
+	json.Unmarshal(publicRequest("/api/posts").Body.Bytes(), &posts)
+	if len(posts) != 0 {
+		t.Errorf("expected trashed post to be left out of the list, got %d posts", len(posts))
+	}
+
+	// Updating and deleting again require the post to be restored first
+	if w := request("DELETE", "/api/posts/"+post.ID.String()); w.Code != http.StatusNotFound {
+		t.Errorf("expected second delete to return %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	w := request("GET", "/api/admin/trash/posts")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var trashed []view.PostResponse
+	json.Unmarshal(w.Body.Bytes(), &trashed)
+	if len(trashed) != 1 || trashed[0].Slug != "trash-me" || trashed[0].DeletedAt == nil {
+		t.Fatalf("expected the trashed post with deleted_at, got %+v", trashed)
+	}
+
+	w = request("POST", "/api/admin/trash/posts/"+post.ID.String()+"/restore")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w := publicRequest("/api/posts/trash-me"); w.Code != http.StatusOK {
+		t.Errorf("expected restored post to be readable, got %d", w.Code)
+	}
+
+	// Purging only applies to trashed posts
+	if w := request("DELETE", "/api/admin/trash/posts/"+post.ID.String()); w.Code != http.StatusNotFound {
+		t.Errorf("expected purge of a live post to return %d, got %d", http.StatusNotFound, w.Code)
+	}
+	request("DELETE", "/api/posts/"+post.ID.String())
+	if w := request("DELETE", "/api/admin/trash/posts/"+post.ID.String()); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if w := publicRequest("/api/posts/trash-me"); w.Code != http.StatusNotFound {
+		t.Errorf("expected purged post to return %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// The retention job purges posts trashed before the cutoff
+	old := &model.Post{Slug: "old", Title: "Old", Body: "Body", Status: model.PostStatusDraft}
+	if err := postRepo.Create(context.Background(), old); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	request("DELETE", "/api/posts/"+old.ID.String())
+	if purged, err := postRepo.PurgeTrashedBefore(context.Background(), time.Now().UTC().Add(-time.Hour)); err != nil || purged != 0 {
+		t.Errorf("expected recently trashed post to be kept, got %d purged (err %v)", purged, err)
+	}
+	if purged, err := postRepo.PurgeTrashedBefore(context.Background(), time.Now().UTC().Add(time.Hour)); err != nil || purged != 1 {
+		t.Errorf("expected trashed post to be purged, got %d purged (err %v)", purged, err)
+	}
+}
+
+func TestOptimisticConcurrency(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{
+				ID:     "admin-user",
+				Email:  "admin@example.com",
+				Groups: []string{"admin"},
+			}, nil
+		},
+	}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	adminRequest := func(method, path string, payload interface{}, ifMatch string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer valid-token")
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := adminRequest("POST", "/api/posts", CreatePostRequest{Slug: "tabs", Title: "Tabs", Body: "Body", Status: "draft"}, "")
+	if w.Code != http.StatusCreated || w.Header().Get("ETag") != `"1"` {
+		t.Fatalf("expected 201 with ETag \"1\", got %d %q", w.Code, w.Header().Get("ETag"))
+	}
+	var post view.PostResponse
+	json.Unmarshal(w.Body.Bytes(), &post)
+
+	w = adminRequest("GET", "/api/posts/tabs", nil, "")
+	etag := w.Header().Get("ETag")
+	if etag != `"1"` {
+		t.Fatalf("expected admin read to carry ETag \"1\", got %q", etag)
+	}
+
+	// The first tab saves; the second, still holding the old ETag, is refused
+	update := UpdatePostRequest{Slug: "tabs", Title: "First tab", Body: "Body", Status: "draft"}
+	w = adminRequest("PUT", "/api/posts/"+post.ID.String(), update, etag)
+	if w.Code != http.StatusOK || w.Header().Get("ETag") != `"2"` {
+		t.Fatalf("expected 200 with ETag \"2\", got %d %q. Body: %s", w.Code, w.Header().Get("ETag"), w.Body.String())
+	}
+
+	update.Title = "Second tab"
+	w = adminRequest("PUT", "/api/posts/"+post.ID.String(), update, etag)
+	if w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected status %d, got %d", http.StatusPreconditionFailed, w.Code)
+	}
+	if w.Header().Get("ETag") != `"2"` {
+		t.Errorf("expected 412 to report the current ETag, got %q", w.Header().Get("ETag"))
+	}
+
+	// Weak tags never match, a wildcard always does, and without strict mode the header is optional
+	if w := adminRequest("PUT", "/api/posts/"+post.ID.String(), update, `W/"2"`); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected weak ETag to return %d, got %d", http.StatusPreconditionFailed, w.Code)
+	}
+	if w := adminRequest("PUT", "/api/posts/"+post.ID.String(), update, "*"); w.Code != http.StatusOK {
+		t.Errorf("expected wildcard to return %d, got %d", http.StatusOK, w.Code)
+	}
+	if w := adminRequest("PUT", "/api/posts/"+post.ID.String(), update, ""); w.Code != http.StatusOK {
+		t.Errorf("expected missing If-Match to return %d, got %d", http.StatusOK, w.Code)
+	}
+
+	// A write that lands between the read and the repository update is caught by the versioned UPDATE
+	stale, err := postRepo.GetByID(context.Background(), post.ID)
+	if err != nil {
+		t.Fatalf("failed to get post: %v", err)
+	}
+	fresh := *stale
+	if err := postRepo.Update(context.Background(), &fresh); err != nil {
+		t.Fatalf("failed to update post: %v", err)
+	}
+	var precondition apierrors.PreconditionFailedError
+	if err := postRepo.Update(context.Background(), stale); !errors.As(err, &precondition) {
+		t.Errorf("expected stale update to fail its precondition, got %v", err)
+	}
+
+	// Stats follow the same protocol
+	w = adminRequest("POST", "/api/stats", map[string]interface{}{"date": "2024-01-02", "page_path": "/index", "pageviews": 1}, "")
+	if w.Code != http.StatusCreated || w.Header().Get("ETag") != `"1"` {
+		t.Fatalf("expected 201 with ETag \"1\", got %d %q", w.Code, w.Header().Get("ETag"))
+	}
+	var stat view.VisitorStatResponse
+	json.Unmarshal(w.Body.Bytes(), &stat)
+
+	if w := adminRequest("PUT", "/api/stats/"+stat.ID.String(), UpdateStatsRequest{Pageviews: 2}, `"1"`); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w := adminRequest("PUT", "/api/stats/"+stat.ID.String(), UpdateStatsRequest{Pageviews: 3}, `"1"`); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected stale stats update to return %d, got %d", http.StatusPreconditionFailed, w.Code)
+	}
+	if w := adminRequest("GET", "/api/stats/"+stat.ID.String(), nil, ""); w.Header().Get("ETag") != `"2"` {
+		t.Errorf("expected stats read to carry ETag \"2\", got %q", w.Header().Get("ETag"))
+	}
+}
+
+func TestConditionalPublicReads(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{
+				ID:     "admin-user",
+				Email:  "admin@example.com",
+				Groups: []string{"admin"},
+			}, nil
+		},
+	}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	post := &model.Post{Slug: "cached", Title: "Cached", Body: "Body", Status: "published"}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	get := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, path := range []string{"/api/posts/cached", "/api/posts", "/api/guestbook"} {
+		w := get(path, nil)
+		etag := w.Header().Get("ETag")
+		if w.Code != http.StatusOK || etag == "" || w.Header().Get("Cache-Control") == "" {
+			t.Fatalf("%s: expected 200 with ETag and Cache-Control, got %d %q %q", path, w.Code, etag, w.Header().Get("Cache-Control"))
+		}
+
+		if w := get(path, map[string]string{"If-None-Match": etag}); w.Code != http.StatusNotModified {
+			t.Errorf("%s: expected status %d, got %d", path, http.StatusNotModified, w.Code)
+		}
+	}
+
+	// Posts and lists aggregate data that changes without touching updated_at, so they revalidate by ETag only
+	since := time.Now().UTC().Add(time.Hour).Format(http.TimeFormat)
+	for _, path := range []string{"/api/posts/cached", "/api/posts"} {
+		w := get(path, map[string]string{"If-Modified-Since": since})
+		if w.Header().Get("Last-Modified") != "" || w.Code != http.StatusOK {
+			t.Errorf("%s: expected 200 without Last-Modified, got %d %q", path, w.Code, w.Header().Get("Last-Modified"))
+		}
+	}
+
+	// The share image depends only on the post, so it answers If-Modified-Since
+	w := get("/api/posts/cached/og.png", nil)
+	lastModified := w.Header().Get("Last-Modified")
+	if lastModified == "" {
+		t.Fatalf("expected share images to carry Last-Modified")
+	}
+	if w := get("/api/posts/cached/og.png", map[string]string{"If-Modified-Since": lastModified}); w.Code != http.StatusNotModified {
+		t.Errorf("expected status %d for If-Modified-Since, got %d", http.StatusNotModified, w.Code)
+	}
+
+	w = get("/api/posts/cached", nil)
+	etag := w.Header().Get("ETag")
+
+	// Editing the post changes its content tag
+	post, _ = postRepo.GetByID(context.Background(), post.ID)
+	post.Title = "Cached, edited"
+	if err := postRepo.Update(context.Background(), post); err != nil {
+		t.Fatalf("failed to update post: %v", err)
+	}
+	if w := get("/api/posts/cached", map[string]string{"If-None-Match": etag}); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("expected an edited post to return 200 with a new ETag, got %d %q", w.Code, w.Header().Get("ETag"))
+	}
+
+	// Authenticated reads are never cacheable and keep the version ETag for If-Match
+	w = get("/api/posts/cached", map[string]string{"Authorization": "Bearer valid-token", "If-None-Match": etag})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := w.Header().Get("Cache-Control"); got != "private, no-store" {
+		t.Errorf("expected authenticated read to be private, got %q", got)
+	}
+	if got := w.Header().Get("ETag"); got != `"2"` {
+		t.Errorf("expected version ETag \"2\", got %q", got)
+	}
+}
+
+func TestPostShareImage(t *testing.T) {
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, &testTokenVerifier{})
+	defer closer.Close()
+
+	post := &model.Post{Slug: "share-me", Title: "Share me", Body: "Body", Tags: []string{"go"}, Status: "published"}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	draft := &model.Post{Slug: "secret-draft", Title: "Secret", Body: "Body", Status: "draft"}
+	if err := postRepo.Create(context.Background(), draft); err != nil {
+		t.Fatalf("failed to create draft: %v", err)
+	}
+
+	get := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/posts/share-me/og.png", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); got != "image/png" {
+		t.Errorf("expected image/png, got %q", got)
+	}
+	img, err := png.Decode(w.Body)
+	if err != nil {
+		t.Fatalf("failed to decode share image: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 1200 || b.Dy() != 630 {
+		t.Errorf("expected a 1200x630 image, got %dx%d", b.Dx(), b.Dy())
+	}
+	etag := w.Header().Get("ETag")
+
+	if w := get("/api/posts/share-me/og.png", etag); w.Code != http.StatusNotModified {
+		t.Errorf("expected status %d for an unchanged image, got %d", http.StatusNotModified, w.Code)
+	}
+
+	// Edits that do not change the drawing keep the image; a new title replaces it
+	post, _ = postRepo.GetByID(context.Background(), post.ID)
+	post.Body = "Edited body"
+	if err := postRepo.Update(context.Background(), post); err != nil {
+		t.Fatalf("failed to update post: %v", err)
+	}
+	if w := get("/api/posts/share-me/og.png", ""); w.Header().Get("ETag") != etag {
+		t.Errorf("expected a body edit to keep the image's ETag")
+	}
+
+	post.Title = "Share me, retitled"
+	if err := postRepo.Update(context.Background(), post); err != nil {
+		t.Fatalf("failed to update post: %v", err)
+	}
+	if w := get("/api/posts/share-me/og.png", etag); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("expected a retitled post to return a new image, got %d %q", w.Code, w.Header().Get("ETag"))
+	}
+
+	if w := get("/api/posts/secret-draft/og.png", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a draft, got %d", http.StatusNotFound, w.Code)
+	}
+
+	if err := postRepo.Delete(context.Background(), post.ID); err != nil {
+		t.Fatalf("failed to delete post: %v", err)
+	}
+	if w := get("/api/posts/share-me/og.png", ""); w.Code != http.StatusGone {
+		t.Errorf("expected status %d for a trashed post, got %d", http.StatusGone, w.Code)
+	}
+}
+
+// webmentionSources serves canned source pages to the webmention verifier
+type webmentionSources map[string]string
+
+func (s webmentionSources) Do(req *http.Request) (*http.Response, error) {
+	body, ok := s[req.URL.String()]
+	if !ok {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/html"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestWebmentions(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{
+				ID:     "admin-user",
+				Email:  "admin@example.com",
+				Groups: []string{"admin"},
+			}, nil
+		},
+	}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	post := &model.Post{Slug: "mentioned", Title: "Mentioned", Body: "Body", Status: "published"}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	draft := &model.Post{Slug: "unpublished", Title: "Unpublished", Body: "Body", Status: "draft"}
+	if err := postRepo.Create(context.Background(), draft); err != nil {
+		t.Fatalf("failed to create draft: %v", err)
+	}
+
+	send := func(source, target string) *httptest.ResponseRecorder {
+		form := url.Values{"source": {source}, "target": {target}}
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/webmention", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+		return w
+	}
+	request := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer valid-token")
+		router.ServeHTTP(w, req)
+		return w
+	}
+	listPublic := func() []view.WebmentionResponse {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/posts/mentioned/webmentions", nil)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d listing mentions, got %d", http.StatusOK, w.Code)
+		}
+		var mentions []view.WebmentionResponse
+		json.Unmarshal(w.Body.Bytes(), &mentions)
+		return mentions
+	}
+
+	target := "https://sochoa.dev/blog/mentioned"
+	invalid := []struct{ source, target string }{
+		{"", target},
+		{"ftp://example.com/a", target},
+		{target, target},
+		{"https://example.com/a", "https://elsewhere.dev/blog/mentioned"},
+		{"https://example.com/a", "https://sochoa.dev/about"},
+		{"https://example.com/a", "https://sochoa.dev/blog/unpublished"},
+		{"https://example.com/a", "https://sochoa.dev/blog/missing"},
+	}
+	for _, tt := range invalid {
+		if w := send(tt.source, tt.target); w.Code != http.StatusBadRequest {
+			t.Errorf("source %q target %q: expected status %d, got %d", tt.source, tt.target, http.StatusBadRequest, w.Code)
+		}
+	}
+
+	for _, source := range []string{"https://example.com/reply", "https://example.com/spam"} {
+		if w := send(source, target); w.Code != http.StatusAccepted {
+			t.Fatalf("expected status %d, got %d. Body: %s", http.StatusAccepted, w.Code, w.Body.String())
+		}
+	}
+
+	// The worker verifies the reply, which links to the post, and fails the page that does not
+	repo := model.NewWebmentionRepository(closer.(*sqliteAdapter))
+	sources := webmentionSources{
+		"https://example.com/reply": `<div class="h-entry"><a class="p-author" href="https://example.com">Ada</a>
+			<p class="e-content">Nice write-up of <a href="` + target + `">this</a></p></div>`,
+		"https://example.com/spam": `<p>Buy things</p>`,
+	}
+	verified, failed, err := webmention.NewWorker(repo, webmention.NewVerifier(sources)).Run(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("failed to verify webmentions: %v", err)
+	}
+	if verified != 1 || failed != 1 {
+		t.Errorf("expected 1 verified and 1 failed, got %d and %d", verified, failed)
+	}
+
+	if mentions := listPublic(); len(mentions) != 0 {
+		t.Errorf("expected no public mentions before approval, got %d", len(mentions))
+	}
+
+	w := request("GET", "/api/admin/webmentions")
+	var queue []view.WebmentionResponse
+	json.Unmarshal(w.Body.Bytes(), &queue)
+	if len(queue) != 1 || queue[0].Source != "https://example.com/reply" {
+		t.Fatalf("expected the verified reply awaiting moderation, got %+v", queue)
+	}
+	if queue[0].AuthorName != "Ada" || queue[0].Content != "Nice write-up of this" {
+		t.Errorf("expected h-entry author and content, got %q %q", queue[0].AuthorName, queue[0].Content)
+	}
+
+	if w := request("POST", "/api/admin/webmentions/"+queue[0].ID.String()+"/approve"); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d approving, got %d", http.StatusNoContent, w.Code)
+	}
+	if mentions := listPublic(); len(mentions) != 1 || mentions[0].AuthorName != "Ada" {
+		t.Errorf("expected the approved mention to be listed, got %+v", mentions)
+	}
+
+	// Resending a mention queues it for verification and moderation again
+	if w := send("https://example.com/reply", target); w.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d resending, got %d", http.StatusAccepted, w.Code)
+	}
+	if mentions := listPublic(); len(mentions) != 0 {
+		t.Errorf("expected a resent mention to be hidden until approved again, got %d", len(mentions))
+	}
+
+	if w := request("DELETE", "/api/admin/webmentions/"+queue[0].ID.String()); w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d deleting, got %d", http.StatusNoContent, w.Code)
+	}
+	if w := request("DELETE", "/api/admin/webmentions/"+queue[0].ID.String()); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d deleting twice, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestWebmentionRateLimits(t *testing.T) {
+	verifier := &testTokenVerifier{}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	post := &model.Post{Slug: "mentioned", Title: "Mentioned", Body: "Body", Status: "published"}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	send := func(remoteAddr, source string) int {
+		form := url.Values{"source": {source}, "target": {"https://sochoa.dev/blog/mentioned"}}
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/webmention", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.RemoteAddr = remoteAddr
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// One site can only have so many mentions waiting for verification
+	for i := 0; i < 10; i++ {
+		if code := send("192.0.2.1:1234", fmt.Sprintf("https://flood.example/%d", i)); code != http.StatusAccepted {
+			t.Fatalf("mention %d: expected status %d, got %d", i, http.StatusAccepted, code)
+		}
+	}
+	if code := send("192.0.2.2:1234", "https://FLOOD.example/more"); code != http.StatusTooManyRequests {
+		t.Errorf("expected status %d once a host has 10 pending mentions, got %d", http.StatusTooManyRequests, code)
+	}
+
+	// One sender can only queue so many mentions a day, whatever the source
+	for i := 0; i < 10; i++ {
+		if code := send("192.0.2.1:1234", fmt.Sprintf("https://site%d.example/post", i)); code != http.StatusAccepted {
+			t.Fatalf("mention %d: expected status %d, got %d", i, http.StatusAccepted, code)
+		}
+	}
+	if code := send("192.0.2.1:1234", "https://another.example/post"); code != http.StatusTooManyRequests {
+		t.Errorf("expected status %d after 20 mentions from one sender, got %d", http.StatusTooManyRequests, code)
+	}
+	if code := send("192.0.2.3:1234", "https://another.example/post"); code != http.StatusAccepted {
+		t.Errorf("expected other senders to be unaffected, got %d", code)
+	}
+}
+
+func TestPostTranslations(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{ID: "admin-user", Email: "admin@example.com", Groups: []string{"admin"}}, nil
+		},
+	}
+
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	post := &model.Post{Slug: "hello-world", Title: "Hello world", Body: "# Hello\n\nBody", Status: "published"}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	other := &model.Post{Slug: "english-only", Title: "English only", Body: "Body", Status: "published"}
+	if err := postRepo.Create(context.Background(), other); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	request := func(method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+	admin := map[string]string{"Authorization": "Bearer valid-token", "Content-Type": "application/json"}
+	translationPath := "/api/admin/posts/" + post.ID.String() + "/translations/"
+
+	w := request("PUT", translationPath+"es_mx", `{"slug":"hola-mundo","title":"Hola mundo","body":"# Hola\n\nCuerpo"}`, admin)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var saved view.PostTranslationResponse
+	json.Unmarshal(w.Body.Bytes(), &saved)
+	if saved.Locale != "es-MX" || len(saved.TOC) != 1 {
+		t.Errorf("expected a normalized locale and computed TOC, got %+v", saved)
+	}
+
+	conflicts := []struct {
+		name   string
+		locale string
+		body   string
+		want   int
+	}{
+		{"post slug", "fr", `{"slug":"english-only","title":"T","body":"B"}`, http.StatusConflict},
+		{"own locale", "en", `{"slug":"hello-again","title":"T","body":"B"}`, http.StatusBadRequest},
+		{"invalid locale", "spanish", `{"slug":"hola-otra","title":"T","body":"B"}`, http.StatusBadRequest},
+	}
+	for _, tt := range conflicts {
+		if w := request("PUT", translationPath+tt.locale, tt.body, admin); w.Code != tt.want {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.want, w.Code)
+		}
+	}
+
+	other.Slug = "hola-mundo"
+	if err := postRepo.Update(context.Background(), other); err == nil {
+		t.Error("expected a post to be refused a translation's slug")
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		language   string
+		wantLocale string
+		wantTitle  string
+	}{
+		{"default", "/api/posts/hello-world", "", "en", "Hello world"},
+		{"accept-language", "/api/posts/hello-world", "fr;q=1, es;q=0.8, en;q=0.5", "es-MX", "Hola mundo"},
+		{"translation slug", "/api/posts/hola-mundo", "en", "es-MX", "Hola mundo"},
+		{"lang override", "/api/posts/hola-mundo?lang=en", "", "en", "Hello world"},
+		{"unavailable", "/api/posts/hello-world", "de", "en", "Hello world"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := request("GET", tt.path, "", map[string]string{"Accept-Language": tt.language})
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			var response view.PostResponse
+			json.Unmarshal(w.Body.Bytes(), &response)
+			if response.Locale != tt.wantLocale || response.Title != tt.wantTitle {
+				t.Errorf("expected %s %q, got %s %q", tt.wantLocale, tt.wantTitle, response.Locale, response.Title)
+			}
+			if got := w.Header().Get("Content-Language"); got != tt.wantLocale {
+				t.Errorf("expected Content-Language %s, got %q", tt.wantLocale, got)
+			}
+			if !strings.Contains(strings.Join(w.Header().Values("Vary"), ","), "Accept-Language") {
+				t.Error("expected the response to vary on Accept-Language")
+			}
+			want := []view.PostAlternateResponse{{Locale: "en", Slug: "hello-world"}, {Locale: "es-MX", Slug: "hola-mundo"}}
+			if !reflect.DeepEqual(response.Alternates, want) {
+				t.Errorf("expected alternates %+v, got %+v", want, response.Alternates)
+			}
+		})
+	}
+
+	list := func(query string) []view.PostResponse {
+		w := request("GET", "/api/posts"+query, "", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var posts []view.PostResponse
+		json.Unmarshal(w.Body.Bytes(), &posts)
+		return posts
+	}
+	if posts := list(""); len(posts) != 2 {
+		t.Errorf("expected both posts without a locale filter, got %d", len(posts))
+	}
+	if posts := list("?lang=es-mx"); len(posts) != 1 || posts[0].Title != "Hola mundo" || posts[0].Slug != "hola-mundo" {
+		t.Errorf("expected only the translated post in Spanish, got %+v", posts)
+	}
+	if posts := list("?lang=en"); len(posts) != 2 {
+		t.Errorf("expected both English posts, got %d", len(posts))
+	}
+	if w := request("GET", "/api/posts?lang=not a locale", "", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid lang, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	w = request("GET", "/api/admin/posts/"+post.ID.String()+"/translations", "", admin)
+	var translations []view.PostTranslationResponse
+	json.Unmarshal(w.Body.Bytes(), &translations)
+	if w.Code != http.StatusOK || len(translations) != 1 {
+		t.Fatalf("expected one translation, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := request("DELETE", translationPath+"es-MX", "", admin); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if w := request("DELETE", translationPath+"es-MX", "", admin); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a deleted translation, got %d", http.StatusNotFound, w.Code)
+	}
+	if w := request("GET", "/api/posts/hola-mundo", "", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a deleted translation's slug, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestNegotiateLocale(t *testing.T) {
+	available := []string{"en", "es-ES", "pt-BR"}
+
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"es-ES", "es-ES"},
+		{"es", "es-ES"},
+		{"es-MX", "es-ES"},
+		{"pt-br", "pt-BR"},
+		{"de, fr;q=0.9", ""},
+		{"de, en;q=0.2, es;q=0.8", "es-ES"},
+		{"es;q=0, en", "en"},
+		{"*", ""},
+		{"es;q=oops, en;q=0.1", "en"},
+	}
+	for _, tt := range tests {
+		if got := negotiateLocale(tt.header, available); got != tt.want {
+			t.Errorf("negotiateLocale(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestPostMergePatch(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{ID: "admin-user", Email: "admin@example.com", Groups: []string{"admin"}}, nil
+		},
+	}
+
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	post := &model.Post{Slug: "patch-me", Title: "Patch me", Summary: "Old summary", Body: "Body text", Tags: []string{"go"}, Status: "draft"}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	patch := func(body, contentType, ifMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PATCH", "/api/posts/"+post.ID.String(), bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer valid-token")
+		req.Header.Set("Content-Type", contentType)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := patch(`{"summary":"Fixed summary","status":"published"}`, "application/merge-patch+json", `"1"`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response view.PostResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response.Summary != "Fixed summary" || response.Status != "published" || response.PublishedAt == nil {
+		t.Errorf("expected the summary and status patched, got %+v", response)
+	}
+	if response.Title != "Patch me" || response.Body != "Body text" || !reflect.DeepEqual(response.Tags, []string{"go"}) {
+		t.Errorf("expected fields missing from the patch to be kept, got %+v", response)
+	}
+	if got := w.Header().Get("ETag"); got != `"2"` {
+		t.Errorf("expected ETag \"2\", got %s", got)
+	}
+
+	w = patch(`{"summary":null,"tags":null}`, "application/merge-patch+json; charset=utf-8", "")
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if w.Code != http.StatusOK || response.Summary != "" || len(response.Tags) != 0 {
+		t.Errorf("expected null to clear summary and tags, got %d %+v", w.Code, response)
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		ifMatch     string
+		want        int
+	}{
+		{"stale version", `{"title":"Late"}`, "application/merge-patch+json", `"1"`, http.StatusPreconditionFailed},
+		{"plain json", `{"title":"New"}`, "application/json", "", http.StatusUnsupportedMediaType},
+		{"not an object", `["title"]`, "application/merge-patch+json", "", http.StatusBadRequest},
+		{"unknown field", `{"author":"me"}`, "application/merge-patch+json", "", http.StatusBadRequest},
+		{"remove required", `{"title":null}`, "application/merge-patch+json", "", http.StatusBadRequest},
+		{"wrong type", `{"tags":"go"}`, "application/merge-patch+json", "", http.StatusBadRequest},
+		{"fails validation", `{"slug":"Not A Slug"}`, "application/merge-patch+json", "", http.StatusBadRequest},
+		{"publish_at without scheduling", `{"publish_at":"2099-01-01T00:00:00Z"}`, "application/merge-patch+json", "", http.StatusBadRequest},
+		{"schedule published post", `{"status":"scheduled","publish_at":"2099-01-01T00:00:00Z"}`, "application/merge-patch+json", "", http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := patch(tt.body, tt.contentType, tt.ifMatch); w.Code != tt.want {
+				t.Errorf("expected status %d, got %d. Body: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	stored, err := postRepo.GetByID(context.Background(), post.ID)
+	if err != nil {
+		t.Fatalf("failed to reload post: %v", err)
+	}
+	if stored.Title != "Patch me" || stored.Version != 3 {
+		t.Errorf("expected rejected patches to leave the post alone, got %q version %d", stored.Title, stored.Version)
+	}
+}
+
+func TestPostTransitions(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{ID: "admin-user", Email: "admin@example.com", Groups: []string{"admin"}}, nil
+		},
+	}
+
+	bus := events.NewBus(createTestLogger())
+	var received []events.Event
+	for _, eventType := range []events.Type{events.PostPublished, events.PostUnpublished, events.PostArchived} {
+		bus.Subscribe(eventType, func(ctx context.Context, event events.Event) error {
+			received = append(received, event)
+			return nil
+		})
+	}
+
+	router, closer, postRepo, _, _, _ := setupTestRouterWithEvents(t, verifier, bus)
+	defer closer.Close()
+
+	post := &model.Post{Slug: "state-machine", Title: "State machine", Body: "Body", Status: "draft"}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	transition := func(action, body string) (int, view.PostResponse) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/posts/state-machine/"+action, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer valid-token")
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(w, req)
+
+		var response view.PostResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response
+	}
+
+	code, response := transition("publish", "")
+	if code != http.StatusOK || response.Status != "published" || response.PublishedAt == nil {
+		t.Fatalf("expected the draft to publish, got %d %+v", code, response)
+	}
+	original := *response.PublishedAt
+
+	if code, _ := transition("publish", ""); code != http.StatusConflict {
+		t.Errorf("expected status %d publishing a published post, got %d", http.StatusConflict, code)
+	}
+
+	code, response = transition("unpublish", "")
+	if code != http.StatusOK || response.Status != "draft" || response.PublishedAt == nil || !response.PublishedAt.Equal(original) {
+		t.Fatalf("expected the post back in draft with its published_at kept, got %d %+v", code, response)
+	}
+	if code, _ := transition("unpublish", ""); code != http.StatusConflict {
+		t.Errorf("expected status %d unpublishing a draft, got %d", http.StatusConflict, code)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	code, response = transition("publish", "")
+	if code != http.StatusOK || !response.PublishedAt.Equal(original) {
+		t.Errorf("expected a republish to keep the original published_at, got %d %+v", code, response)
+	}
+
+	code, response = transition("archive", "")
+	if code != http.StatusOK || response.Status != "archived" {
+		t.Errorf("expected the post archived, got %d %+v", code, response)
+	}
+
+	code, response = transition("publish", `{"reset_published_at":true}`)
+	if code != http.StatusOK || !response.PublishedAt.After(original) {
+		t.Errorf("expected reset_published_at to date the post now, got %d %+v", code, response)
+	}
+
+	if code, _ := transition("publish", `not json`); code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid body, got %d", http.StatusBadRequest, code)
+	}
+
+	want := []events.Type{events.PostPublished, events.PostUnpublished, events.PostPublished, events.PostArchived, events.PostPublished}
+	if len(received) != len(want) {
+		t.Fatalf("expected %d events, got %d: %+v", len(want), len(received), received)
+	}
+	for i, event := range received {
+		if event.Type != want[i] || event.PostID != post.ID || event.Slug != "state-machine" {
+			t.Errorf("event %d: expected %s for the post, got %+v", i, want[i], event)
+		}
+	}
+	if received[3].From != model.PostStatusPublished || received[3].To != model.PostStatusArchived {
+		t.Errorf("expected the archive event to go from published to archived, got %+v", received[3])
+	}
+
+	// Transitions honour If-Match like any other write
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/posts/state-machine/archive", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	req.Header.Set("If-Match", `"1"`)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected status %d for a stale If-Match, got %d", http.StatusPreconditionFailed, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/posts/missing/publish", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a missing post, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestPostStatusEditsFollowTransitions(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{ID: "admin-user", Email: "admin@example.com", Groups: []string{"admin"}}, nil
+		},
+	}
+
+	bus := events.NewBus(createTestLogger())
+	var received []events.Event
+	for _, eventType := range []events.Type{events.PostPublished, events.PostUnpublished, events.PostArchived} {
+		bus.Subscribe(eventType, func(ctx context.Context, event events.Event) error {
+			received = append(received, event)
+			return nil
+		})
+	}
+
+	router, closer, postRepo, _, _, _ := setupTestRouterWithEvents(t, verifier, bus)
+	defer closer.Close()
+
+	post := &model.Post{Slug: "status-edits", Title: "Status edits", Body: "Body", Status: "draft"}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	send := func(method, contentType, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, "/api/posts/"+post.ID.String(), bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer valid-token")
+		req.Header.Set("Content-Type", contentType)
+		router.ServeHTTP(w, req)
+		return w
+	}
+	put := func(status string) *httptest.ResponseRecorder {
+		return send("PUT", "application/json", fmt.Sprintf(`{"slug": "status-edits", "title": "Status edits", "body": "Body", "status": "%s"}`, status))
+	}
+	patch := func(body string) *httptest.ResponseRecorder {
+		return send("PATCH", mergePatchContentType, body)
+	}
+
+	if w := put("published"); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d publishing through PUT, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	future := time.Now().UTC().Add(time.Hour).Format(time.RFC3339)
+	if w := patch(`{"status": "scheduled", "publish_at": "` + future + `"}`); w.Code != http.StatusConflict {
+		t.Errorf("expected status %d scheduling a published post, got %d: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	if w := patch(`{"title": "Status edits, retitled"}`); w.Code != http.StatusOK {
+		t.Errorf("expected status %d for an edit without a status change, got %d", http.StatusOK, w.Code)
+	}
+	if w := patch(`{"status": "archived"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d archiving through PATCH, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w := put("draft"); w.Code != http.StatusConflict {
+		t.Errorf("expected status %d moving an archived post to draft, got %d: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	if w := put("published"); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d republishing through PUT, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	want := []struct {
+		eventType events.Type
+		from, to  model.PostStatus
+	}{
+		{events.PostPublished, model.PostStatusDraft, model.PostStatusPublished},
+		{events.PostArchived, model.PostStatusPublished, model.PostStatusArchived},
+		{events.PostPublished, model.PostStatusArchived, model.PostStatusPublished},
+	}
+	if len(received) != len(want) {
+		t.Fatalf("expected %d events, got %d: %+v", len(want), len(received), received)
+	}
+	for i, event := range received {
+		if event.Type != want[i].eventType || event.From != want[i].from || event.To != want[i].to || event.PostID != post.ID {
+			t.Errorf("event %d: expected %s from %s to %s, got %+v", i, want[i].eventType, want[i].from, want[i].to, event)
+		}
+	}
+
+	reloaded, err := postRepo.GetByID(context.Background(), post.ID)
+	if err != nil {
+		t.Fatalf("failed to reload post: %v", err)
+	}
+	if reloaded.Status != model.PostStatusPublished || reloaded.PublishAt != nil {
+		t.Errorf("expected the post published with no publish_at, got %s %v", reloaded.Status, reloaded.PublishAt)
+	}
+}
+
+func TestScheduledPublishingEmitsEvents(t *testing.T) {
+	verifier := &testTokenVerifier{}
+	bus := events.NewBus(createTestLogger())
+	var received []events.Event
+	for _, eventType := range []events.Type{events.PostPublished, events.PostArchived} {
+		bus.Subscribe(eventType, func(ctx context.Context, event events.Event) error {
+			received = append(received, event)
+			return nil
+		})
+	}
+
+	_, closer, postRepo, _, _, _ := setupTestRouterWithEvents(t, verifier, bus)
+	defer closer.Close()
+
+	ctx := context.Background()
+	later := time.Now().UTC().Add(time.Hour)
+	scheduled := &model.Post{Slug: "on-schedule", Title: "On schedule", Body: "Body", Status: model.PostStatusScheduled, PublishAt: &later}
+	expiring := &model.Post{Slug: "expiring", Title: "Expiring", Body: "Body", Status: model.PostStatusPublished, UnpublishAt: &later}
+	for _, post := range []*model.Post{scheduled, expiring} {
+		if err := postRepo.Create(ctx, post); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+	}
+
+	job := scheduler.PublishScheduledPosts(createTestLogger(), postRepo, bus)
+	now := later.Add(time.Minute)
+	if err := job.Run(ctx, now); err != nil {
+		t.Fatalf("failed to run job: %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 events, got %d: %+v", len(received), received)
+	}
+	if event := received[0]; event.Type != events.PostPublished || event.PostID != scheduled.ID || event.From != model.PostStatusScheduled || !event.At.Equal(now) {
+		t.Errorf("expected a published event for the scheduled post, got %+v", event)
+	}
+	if event := received[1]; event.Type != events.PostArchived || event.PostID != expiring.ID || event.To != model.PostStatusArchived {
+		t.Errorf("expected an archived event for the expiring post, got %+v", event)
+	}
+
+	// A second run finds nothing left to move
+	if err := job.Run(ctx, now); err != nil {
+		t.Fatalf("failed to run job again: %v", err)
+	}
+	if len(received) != 2 {
+		t.Errorf("expected no further events, got %+v", received[2:])
+	}
+}
+
+func TestResumes(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{ID: "admin-user", Email: "admin@example.com", Groups: []string{"admin"}}, nil
+		},
+	}
+
+	router, closer, _, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer valid-token")
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	createSection := func(body string) uuid.UUID {
+		w := send("POST", "/api/admin/resume/sections", body)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status %d creating section, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		var response view.ResumeSectionResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.ID
+	}
+
+	experience := createSection(`{"kind": "experience", "title": "Principal Consultant", "organization": "Acme", "start_date": "2019-04", "end_date": "2022-06", "highlights": ["Led a cloud migration"]}`)
+	skills := createSection(`{"kind": "skills", "title": "Languages", "summary": "Go, TypeScript, SQL"}`)
+	education := createSection(`{"kind": "education", "title": "BS Computer Science", "organization": "State University", "end_date": "2012-05"}`)
+
+	if w := send("POST", "/api/admin/resume/sections", `{"kind": "experience", "title": "Backwards", "start_date": "2020-01", "end_date": "2019-01"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an end date before the start, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := send("POST", "/api/admin/resume/sections", `{"kind": "hobbies", "title": "Climbing"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an unknown kind, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	w := send("POST", "/api/admin/resume/variants", `{"slug": "consulting", "name": "Consulting", "headline": "Cloud and platform consultant"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d creating variant, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var variant view.ResumeVariantResponse
+	json.Unmarshal(w.Body.Bytes(), &variant)
+
+	if w := send("POST", "/api/admin/resume/variants", `{"slug": "consulting", "name": "Duplicate"}`); w.Code != http.StatusConflict {
+		t.Errorf("expected status %d for a duplicate slug, got %d", http.StatusConflict, w.Code)
+	}
+
+	sectionsPath := "/api/admin/resume/variants/" + variant.ID.String() + "/sections"
+	if w := send("PUT", sectionsPath, fmt.Sprintf(`{"section_ids": ["%s"]}`, uuid.New())); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an unknown section, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := send("PUT", sectionsPath, fmt.Sprintf(`{"section_ids": ["%s", "%s"]}`, skills, skills)); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for a repeated section, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// The variant includes education then experience, and leaves skills out
+	w = send("PUT", sectionsPath, fmt.Sprintf(`{"section_ids": ["%s", "%s"]}`, education, experience))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d setting sections, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	getResume := func() view.ResumeVariantResponse {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/resumes/consulting", nil)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if w.Header().Get("Last-Modified") == "" {
+			t.Error("expected a Last-Modified header")
+		}
+		var response view.ResumeVariantResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response
+	}
+
+	resume := getResume()
+	if len(resume.Sections) != 2 || resume.Sections[0].ID != education || resume.Sections[1].ID != experience {
+		t.Fatalf("expected education then experience, got %+v", resume.Sections)
+	}
+	if resume.Sections[1].StartDate != "2019-04" || len(resume.Sections[1].Highlights) != 1 {
+		t.Errorf("expected the experience details, got %+v", resume.Sections[1])
+	}
+	if resume.PDFURL != "/api/resumes/consulting/pdf" {
+		t.Errorf("expected the PDF URL, got %q", resume.PDFURL)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", resume.PDFURL, nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d for the PDF, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/pdf" {
+		t.Errorf("expected application/pdf, got %q", contentType)
+	}
+	if disposition := w.Header().Get("Content-Disposition"); disposition != `inline; filename="consulting-resume.pdf"` {
+		t.Errorf("unexpected Content-Disposition %q", disposition)
+	}
+	if !bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")) {
+		t.Errorf("expected a PDF document, got %q", w.Body.Bytes()[:min(w.Body.Len(), 16)])
+	}
+
+	// Editing a shared section changes every variant that includes it
+	w = send("PUT", "/api/admin/resume/sections/"+experience.String(), `{"kind": "experience", "title": "Engagement Lead", "organization": "Acme", "start_date": "2019-04"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d updating section, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if resume = getResume(); resume.Sections[1].Title != "Engagement Lead" || resume.Sections[1].EndDate != "" {
+		t.Errorf("expected the updated section, got %+v", resume.Sections[1])
+	}
+
+	if w := send("DELETE", "/api/admin/resume/sections/"+education.String(), ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d deleting section, got %d", http.StatusNoContent, w.Code)
+	}
+	if resume = getResume(); len(resume.Sections) != 1 || resume.Sections[0].ID != experience {
+		t.Errorf("expected only the experience section, got %+v", resume.Sections)
+	}
+
+	w = send("GET", "/api/admin/resume/sections", "")
+	var sections []view.ResumeSectionResponse
+	json.Unmarshal(w.Body.Bytes(), &sections)
+	if w.Code != http.StatusOK || len(sections) != 2 {
+		t.Errorf("expected the two remaining sections, got %d %+v", w.Code, sections)
+	}
+
+	for _, path := range []string{"/api/resumes/missing", "/api/resumes/missing/pdf"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d for %s, got %d", http.StatusNotFound, path, w.Code)
+		}
+	}
+
+	if w := send("DELETE", "/api/admin/resume/variants/"+variant.ID.String(), ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d deleting variant, got %d", http.StatusNoContent, w.Code)
+	}
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/resumes", nil)
+	router.ServeHTTP(w, req)
+	if w.Body.String() != "[]" {
+		t.Errorf("expected no variants, got %s", w.Body.String())
+	}
+}
+
+func TestProjects(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{ID: "admin-user", Email: "admin@example.com", Groups: []string{"admin"}}, nil
+		},
+	}
+
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer valid-token")
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	get := func(path string) ([]view.ProjectResponse, int) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		var projects []view.ProjectResponse
+		json.Unmarshal(w.Body.Bytes(), &projects)
+		return projects, w.Code
+	}
+
+	slugs := func(projects []view.ProjectResponse) []string {
+		result := make([]string, len(projects))
+		for i, p := range projects {
+			result[i] = p.Slug
+		}
+		return result
+	}
+
+	create := func(body string) view.ProjectResponse {
+		w := send("POST", "/api/admin/projects", body)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		var response view.ProjectResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response
+	}
+
+	// Project tags resolve aliases just like post tags
+	if w := send("POST", "/api/admin/tag-aliases", `{"alias": "golang", "tag": "go"}`); w.Code != http.StatusCreated {
+		t.Fatalf("failed to create tag alias: %d %s", w.Code, w.Body.String())
+	}
+
+	payments := create(`{
+		"slug": "payments", "title": "Payments platform", "summary": "Rebuilt checkout.", "problem": "Checkout fell over at peak.",
+		"role": "Tech lead", "stack": ["Go", "PostgreSQL"], "outcomes": ["99.99% availability"],
+		"links": [{"label": "Write-up", "url": "https://sochoa.dev/blog/payments"}],
+		"tags": ["Golang", "AWS"], "status": "published", "is_featured": true
+	}`)
+	if payments.PublishedAt == nil || !reflect.DeepEqual(payments.Tags, []string{"go", "aws"}) {
+		t.Errorf("expected a published project with canonical tags, got %+v", payments)
+	}
+	search := create(`{"slug": "search", "title": "Search", "summary": "Faster search.", "tags": ["rust"], "status": "published"}`)
+	draft := create(`{"slug": "secret", "title": "Secret", "tags": ["go"]}`)
+	if draft.Status != "draft" || draft.PublishedAt != nil {
+		t.Errorf("expected a draft by default, got %+v", draft)
+	}
+
+	if w := send("POST", "/api/admin/projects", `{"slug": "search", "title": "Again"}`); w.Code != http.StatusConflict {
+		t.Errorf("expected status %d for a duplicate slug, got %d", http.StatusConflict, w.Code)
+	}
+	if w := send("POST", "/api/admin/projects", `{"slug": "bad-link", "title": "Bad", "links": [{"label": "x", "url": "ftp://example.com"}]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for a non-web link, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	if projects, _ := get("/api/projects"); !reflect.DeepEqual(slugs(projects), []string{"search", "payments"}) {
+		t.Errorf("expected published projects newest first, got %v", slugs(projects))
+	}
+	if projects, _ := get("/api/projects?featured=true"); !reflect.DeepEqual(slugs(projects), []string{"payments"}) {
+		t.Errorf("expected only the featured project, got %v", slugs(projects))
+	}
+	if projects, _ := get("/api/projects?tag=golang"); !reflect.DeepEqual(slugs(projects), []string{"payments"}) {
+		t.Errorf("expected the alias to find the go project, got %v", slugs(projects))
+	}
+	if _, code := get("/api/projects?featured=maybe"); code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid featured flag, got %d", http.StatusBadRequest, code)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/projects/secret", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a draft read anonymously, got %d", http.StatusNotFound, w.Code)
+	}
+	if w := send("GET", "/api/projects/secret", ""); w.Code != http.StatusOK || w.Header().Get("X-Robots-Tag") != "noindex" {
+		t.Errorf("expected admins to read drafts unindexed, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/projects/payments", nil)
+	router.ServeHTTP(w, req)
+	var detail view.ProjectResponse
+	json.Unmarshal(w.Body.Bytes(), &detail)
+	if w.Code != http.StatusOK || detail.Role != "Tech lead" || len(detail.Links) != 1 || detail.Links[0].Label != "Write-up" {
+		t.Errorf("expected the full case study, got %d %+v", w.Code, detail)
+	}
+
+	// Explicit positions come before the default ordering
+	w = send("PUT", "/api/admin/projects/order", fmt.Sprintf(`{"project_ids": ["%s", "%s", "%s"]}`, payments.ID, draft.ID, search.ID))
+	var ordered []view.ProjectResponse
+	json.Unmarshal(w.Body.Bytes(), &ordered)
+	if w.Code != http.StatusOK || !reflect.DeepEqual(slugs(ordered), []string{"payments", "secret", "search"}) {
+		t.Errorf("expected the new order, got %d %v", w.Code, slugs(ordered))
+	}
+	if w := send("PUT", "/api/admin/projects/order", fmt.Sprintf(`{"project_ids": ["%s"]}`, uuid.New())); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d reordering an unknown project, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Unpublishing keeps published_at so republishing does not reorder the project
+	w = send("PUT", "/api/admin/projects/"+search.ID.String(), `{"slug": "search", "title": "Search", "summary": "Faster search.", "tags": ["rust"], "status": "draft", "position": 1}`)
+	var updated view.ProjectResponse
+	json.Unmarshal(w.Body.Bytes(), &updated)
+	if w.Code != http.StatusOK || updated.Status != "draft" || updated.PublishedAt == nil || !updated.PublishedAt.Equal(*search.PublishedAt) {
+		t.Errorf("expected the draft to keep published_at, got %d %+v", w.Code, updated)
+	}
+
+	// Tags are shared with posts: counts include projects and renames reach them
+	post := &model.Post{Slug: "go-post", Title: "Go", Body: "Body", Tags: []string{"go"}, Status: "published"}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/tags/go", nil)
+	router.ServeHTTP(w, req)
+	var tag view.TagResponse
+	json.Unmarshal(w.Body.Bytes(), &tag)
+	if tag.PostCount != 1 || tag.ProjectCount != 1 {
+		t.Errorf("expected one post and one published project tagged go, got %+v", tag)
+	}
+
+	if w := send("POST", "/api/admin/tags/aws/rename", `{"to": "amazon-web-services"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d renaming a project-only tag, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w := send("POST", "/api/admin/tags/rust/rename", `{"to": "go"}`); w.Code != http.StatusConflict {
+		t.Errorf("expected status %d renaming onto a tag in use, got %d", http.StatusConflict, w.Code)
+	}
+	if projects, _ := get("/api/projects?tag=amazon-web-services"); !reflect.DeepEqual(slugs(projects), []string{"payments"}) {
+		t.Errorf("expected the renamed tag on the project, got %v", slugs(projects))
+	}
+
+	if w := send("DELETE", "/api/admin/projects/"+payments.ID.String(), ""); w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d deleting project, got %d", http.StatusNoContent, w.Code)
+	}
+	if projects, _ := get("/api/projects"); len(projects) != 0 {
+		t.Errorf("expected no published projects left, got %v", slugs(projects))
+	}
+}
+
+func TestTalks(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{ID: "admin-user", Email: "admin@example.com", Groups: []string{"admin"}}, nil
+		},
+	}
+
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer valid-token")
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	create := func(body string) view.TalkResponse {
+		w := send("POST", "/api/admin/talks", body)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		var response view.TalkResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response
+	}
+
+	list := func(path string) view.TalkListResponse {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var response view.TalkListResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response
+	}
+
+	titles := func(talks []view.TalkResponse) []string {
+		result := []string{}
+		for _, talk := range talks {
+			result = append(result, talk.Title)
+		}
+		return result
+	}
+
+	published := &model.Post{Slug: "boring-write-up", Title: "Boring, written up", Body: "Body", Status: model.PostStatusPublished}
+	draft := &model.Post{Slug: "draft-notes", Title: "Notes", Body: "Body", Status: model.PostStatusDraft}
+	for _, post := range []*model.Post{published, draft} {
+		if err := postRepo.Create(context.Background(), post); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	at := func(days int) string { return now.AddDate(0, 0, days).Format(time.RFC3339) }
+
+	old := create(fmt.Sprintf(`{"title": "Boring infrastructure", "event": "GopherCon", "starts_at": %q,
+		"slides_url": "https://sochoa.dev/slides/boring.pdf", "post_id": %q}`, at(-60), published.ID))
+	if old.Type != "talk" || old.Post == nil || old.Post.Slug != "boring-write-up" {
+		t.Errorf("expected a talk linked to its post, got %+v", old)
+	}
+
+	create(fmt.Sprintf(`{"title": "On call, humanely", "type": "podcast", "event": "Ship It", "starts_at": %q}`, at(-5)))
+	next := create(fmt.Sprintf(`{"title": "Hands-on Go", "type": "workshop", "event": "Go Meetup", "location": "Austin, TX",
+		"starts_at": %q, "ends_at": %q, "post_id": %q}`, at(10), now.AddDate(0, 0, 10).Add(3*time.Hour).Format(time.RFC3339), draft.ID))
+	create(fmt.Sprintf(`{"title": "Keynote", "event": "StrangeLoop", "starts_at": %q}`, at(90)))
+
+	talks := list("/api/talks")
+	if got := titles(talks.Upcoming); !reflect.DeepEqual(got, []string{"Hands-on Go", "Keynote"}) {
+		t.Errorf("expected upcoming talks soonest first, got %v", got)
+	}
+	if got := titles(talks.Past); !reflect.DeepEqual(got, []string{"On call, humanely", "Boring infrastructure"}) {
+		t.Errorf("expected past talks most recent first, got %v", got)
+	}
+	if talks.Upcoming[0].Post != nil {
+		t.Errorf("expected the draft post to be hidden from the public listing")
+	}
+	if talks.Past[1].Post == nil {
+		t.Errorf("expected the published post to be listed")
+	}
+
+	if got := titles(list("/api/talks?type=workshop").Upcoming); !reflect.DeepEqual(got, []string{"Hands-on Go"}) {
+		t.Errorf("expected only workshops, got %v", got)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/talks?type=keynote", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an unknown type, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Invalid talks are rejected
+	for _, body := range []string{
+		fmt.Sprintf(`{"title": "Talk", "event": "Conf", "starts_at": %q, "slides_url": "slides.pdf"}`, at(1)),
+		fmt.Sprintf(`{"title": "Talk", "event": "Conf", "starts_at": %q, "ends_at": %q}`, at(1), at(0)),
+		fmt.Sprintf(`{"title": "Talk", "event": "Conf", "starts_at": %q, "post_id": %q}`, at(1), uuid.New()),
+		`{"title": "Talk", "event": "Conf"}`,
+	} {
+		if w := send("POST", "/api/admin/talks", body); w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d for %s, got %d", http.StatusBadRequest, body, w.Code)
+		}
+	}
+
+	// The calendar feed includes every talk, with the workshop's end time and the default hour for the others
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/talks.ics", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/calendar") {
+		t.Fatalf("expected a calendar, got %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	feed := strings.ReplaceAll(w.Body.String(), "\r\n ", "") // Unfold long lines
+	if count := strings.Count(feed, "BEGIN:VEVENT"); count != 4 {
+		t.Errorf("expected 4 events, got %d", count)
+	}
+	start := now.AddDate(0, 0, 10)
+	for _, line := range []string{
+		"UID:" + next.ID.String() + "@sochoa.dev",
+		"DTSTART:" + start.Format("20060102T150405Z"),
+		"DTEND:" + start.Add(3*time.Hour).Format("20060102T150405Z"),
+		`LOCATION:Austin\, TX`,
+		"Post: https://sochoa.dev/blog/boring-write-up",
+	} {
+		if !strings.Contains(feed, line) {
+			t.Errorf("expected %q in the feed:\n%s", line, feed)
+		}
+	}
+	if strings.Contains(feed, "draft-notes") {
+		t.Errorf("expected the draft post to be left out of the feed")
+	}
+
+	// Updating moves a talk between upcoming and past
+	body := fmt.Sprintf(`{"title": "Keynote", "type": "feature", "event": "StrangeLoop", "starts_at": %q, "video_url": "https://youtu.be/abc"}`, at(-1))
+	keynoteID := talks.Upcoming[1].ID
+	if w := send("PUT", "/api/admin/talks/"+keynoteID.String(), body); w.Code != http.StatusOK {
+		t.Fatalf("failed to update talk: %d %s", w.Code, w.Body.String())
+	}
+	talks = list("/api/talks")
+	if got := titles(talks.Past); !reflect.DeepEqual(got, []string{"Keynote", "On call, humanely", "Boring infrastructure"}) {
+		t.Errorf("expected the keynote to be past, got %v", got)
+	}
+
+	if w := send("PUT", "/api/admin/talks/"+uuid.New().String(), body); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a missing talk, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// Admins see related posts whatever their status
+	w = send("GET", "/api/admin/talks", "")
+	var all []view.TalkResponse
+	json.Unmarshal(w.Body.Bytes(), &all)
+	if len(all) != 4 || all[3].Post == nil || all[3].Post.Slug != "draft-notes" {
+		t.Errorf("expected every talk with its post, got %+v", all)
+	}
+
+	// Trashing the related post hides it from readers but keeps the talk
+	if err := postRepo.Delete(context.Background(), published.ID); err != nil {
+		t.Fatalf("failed to delete post: %v", err)
+	}
+	talks = list("/api/talks")
+	if len(talks.Past) != 3 || talks.Past[2].Post != nil {
+		t.Errorf("expected the talk without its trashed post, got %+v", talks.Past)
+	}
+
+	if w := send("DELETE", "/api/admin/talks/"+old.ID.String(), ""); w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if w := send("DELETE", "/api/admin/talks/"+old.ID.String(), ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestNewsletter(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{ID: "admin-user", Email: "admin@example.com", Groups: []string{"admin"}}, nil
+		},
+	}
+
+	mailer := mail.NewMemory()
+	router, closer, _, _, _, _ := setupTestRouterWithMailer(t, verifier, mailer)
+	defer closer.Close()
+
+	send := func(method, path, contentType, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer valid-token")
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	subscribe := func(email string) *httptest.ResponseRecorder {
+		return send("POST", "/api/newsletter/subscribe", "application/json", fmt.Sprintf(`{"email": %q}`, email))
+	}
+
+	confirm := func(token string) *httptest.ResponseRecorder {
+		return send("POST", "/api/newsletter/confirm", "application/json", fmt.Sprintf(`{"token": %q}`, token))
+	}
+
+	tokenPattern := regexp.MustCompile(`token=([A-Za-z0-9_-]+)`)
+	lastToken := func() string {
+		sent := mailer.Sent()
+		match := tokenPattern.FindStringSubmatch(sent[len(sent)-1].Body)
+		if match == nil {
+			t.Fatalf("expected a tokenized link in %q", sent[len(sent)-1].Body)
+		}
+		return match[1]
+	}
+
+	exported := func() string {
+		w := send("GET", "/api/admin/newsletter/export", "", "")
+		if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv") {
+			t.Fatalf("expected a CSV export, got %d %s", w.Code, w.Header().Get("Content-Type"))
+		}
+		return w.Body.String()
+	}
+
+	if w := subscribe("not-an-address"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid address, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// The honeypot looks like success but sends nothing
+	if w := send("POST", "/api/newsletter/subscribe", "application/json", `{"email": "bot@example.com", "honeypot": "x"}`); w.Code != http.StatusAccepted || len(mailer.Sent()) != 0 {
+		t.Errorf("expected a silent honeypot, got %d and %d emails", w.Code, len(mailer.Sent()))
+	}
+
+	if w := subscribe(" Reader@Example.com "); w.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, w.Code, w.Body.String())
+	}
+	sent := mailer.Sent()
+	if len(sent) != 1 || sent[0].To != "reader@example.com" || sent[0].From != "newsletter@sochoa.dev" {
+		t.Fatalf("expected a confirmation email to the normalized address, got %+v", sent)
+	}
+	firstToken := lastToken()
+
+	// Resending rotates the token; the fourth confirmation email of the day is refused
+	for i := 0; i < 2; i++ {
+		if w := subscribe("reader@example.com"); w.Code != http.StatusAccepted {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, w.Code, w.Body.String())
+		}
+	}
+	if w := subscribe("reader@example.com"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if len(mailer.Sent()) != 3 {
+		t.Errorf("expected 3 confirmation emails, got %d", len(mailer.Sent()))
+	}
+
+	if w := confirm(firstToken); w.Code != http.StatusNotFound {
+		t.Errorf("expected a replaced token to be rejected, got %d", w.Code)
+	}
+
+	// Unconfirmed addresses are not exported
+	if csv := exported(); strings.Contains(csv, "reader@example.com") {
+		t.Errorf("expected no unconfirmed addresses in the export, got %q", csv)
+	}
+
+	token := lastToken()
+	if w := confirm(token); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w := confirm(token); w.Code != http.StatusNotFound {
+		t.Errorf("expected a used token to be rejected, got %d", w.Code)
+	}
+
+	// The welcome email offers one-click unsubscribe
+	sent = mailer.Sent()
+	welcome := sent[len(sent)-1]
+	unsubscribeToken := lastToken()
+	if welcome.Headers["List-Unsubscribe"] != "<https://sochoa.dev/api/newsletter/unsubscribe?token="+unsubscribeToken+">" ||
+		welcome.Headers["List-Unsubscribe-Post"] != "List-Unsubscribe=One-Click" {
+		t.Errorf("expected List-Unsubscribe headers, got %+v", welcome.Headers)
+	}
+
+	// Subscribing a confirmed address is indistinguishable but sends nothing
+	if w := subscribe("reader@example.com"); w.Code != http.StatusAccepted || len(mailer.Sent()) != len(sent) {
+		t.Errorf("expected no email for a confirmed address, got %d and %d emails", w.Code, len(mailer.Sent()))
+	}
+
+	csv := exported()
+	if lines := strings.Split(strings.TrimSpace(csv), "\n"); len(lines) != 2 || lines[0] != "email,confirmed_at,subscribed_at" || !strings.HasPrefix(lines[1], "reader@example.com,") {
+		t.Errorf("unexpected export %q", csv)
+	}
+
+	// Unconfirmed addresses are purged once their retention passes
+	if w := subscribe("pending@example.com"); w.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d", http.StatusAccepted, w.Code)
+	}
+	repo := model.NewNewsletterRepository(closer.(*sqliteAdapter))
+	purged, err := repo.PurgeExpired(context.Background(), time.Now().Add(model.UnconfirmedSubscriberRetention+time.Minute))
+	if err != nil || purged != 1 {
+		t.Errorf("expected 1 unconfirmed address to be purged, got %d (%v)", purged, err)
+	}
+
+	// Mail clients unsubscribe with a form-encoded POST to the List-Unsubscribe URL
+	path := "/api/newsletter/unsubscribe?token=" + unsubscribeToken
+	if w := send("POST", path, "application/x-www-form-urlencoded", "List-Unsubscribe=One-Click"); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w := send("POST", path, "application/x-www-form-urlencoded", "List-Unsubscribe=One-Click"); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d once unsubscribed, got %d", http.StatusNotFound, w.Code)
+	}
+	if csv := exported(); strings.Contains(csv, "reader@example.com") {
+		t.Errorf("expected the address to be gone, got %q", csv)
+	}
+
+	// The token may also be sent as JSON, and resubscribing starts over
+	if w := subscribe("reader@example.com"); w.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d", http.StatusAccepted, w.Code)
+	}
+	if w := send("POST", "/api/newsletter/unsubscribe", "application/json", `{"token": "unknown"}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for an unknown token, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestPages(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{ID: "admin-user", Email: "admin@example.com", Groups: []string{"admin"}}, nil
+		},
+	}
+
+	router, closer, _, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer valid-token")
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	create := func(body string) view.SitePageResponse {
+		w := send("POST", "/api/admin/pages", body)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		var response view.SitePageResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response
+	}
+
+	// A child page needs its parent
+	if w := send("POST", "/api/admin/pages", `{"path":"about/values","title":"Values","body":"Values"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d without a parent, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	about := create(`{"path":"/about/","title":"About","body":"## Hello\n\nI write software.","status":"published","seo_title":"About Sean"}`)
+	if about.Path != "about" || about.PublishedAt == nil || about.WordCount == 0 || len(about.TOC) != 1 {
+		t.Errorf("expected a normalized, published page with reading metadata, got %+v", about)
+	}
+	values := create(`{"path":"about/values","title":"Values","body":"Boring technology."}`)
+	if values.ParentPath != "about" || values.Status != "draft" || values.PublishedAt != nil {
+		t.Errorf("expected a draft child of about, got %+v", values)
+	}
+
+	// Validation follows the post rules
+	if w := send("POST", "/api/admin/pages", `{"path":"About","title":"About","body":"x"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid path, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := send("POST", "/api/admin/pages", `{"path":"about","title":"About again","body":"x"}`); w.Code != http.StatusConflict {
+		t.Errorf("expected status %d for a duplicate path, got %d", http.StatusConflict, w.Code)
+	}
+
+	// Drafts are hidden from readers, both directly and in sub-navigation
+	w := get("/api/pages/about")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var page view.SitePageResponse
+	json.Unmarshal(w.Body.Bytes(), &page)
+	if page.SEOTitle != "About Sean" || len(page.Children) != 0 {
+		t.Errorf("expected the page without draft children, got %+v", page)
+	}
+	if w := get("/api/pages/about/values"); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a draft read anonymously, got %d", http.StatusNotFound, w.Code)
+	}
+	if w := send("GET", "/api/pages/about/values", ""); w.Code != http.StatusOK || w.Header().Get("X-Robots-Tag") != "noindex" {
+		t.Errorf("expected admins to read drafts unindexed, got %d", w.Code)
+	}
+
+	// Publishing shows the child beneath its parent
+	if w := send("PUT", "/api/admin/pages/"+values.ID.String(), `{"path":"about/values","title":"Values","body":"Boring technology.","status":"published","noindex":true}`); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	w = get("/api/pages/about/values")
+	if w.Code != http.StatusOK || w.Header().Get("X-Robots-Tag") != "noindex" {
+		t.Errorf("expected the published page marked noindex, got %d", w.Code)
+	}
+	json.Unmarshal(get("/api/pages/about").Body.Bytes(), &page)
+	if len(page.Children) != 1 || page.Children[0].Path != "about/values" {
+		t.Errorf("expected about/values beneath about, got %+v", page.Children)
+	}
+
+	// A parent with children cannot be deleted
+	if w := send("DELETE", "/api/admin/pages/"+about.ID.String(), ""); w.Code != http.StatusConflict {
+		t.Errorf("expected status %d deleting a parent, got %d", http.StatusConflict, w.Code)
+	}
+
+	// Moving a page moves its children, but not beneath itself
+	if w := send("PUT", "/api/admin/pages/"+about.ID.String(), `{"path":"about/values/me","title":"About","body":"x"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d moving a page beneath itself, got %d", http.StatusBadRequest, w.Code)
+	}
+	w = send("PUT", "/api/admin/pages/"+about.ID.String(), `{"path":"me","title":"About","body":"## Hello","status":"published"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	json.Unmarshal(w.Body.Bytes(), &page)
+	if page.PublishedAt == nil || !page.PublishedAt.Equal(*about.PublishedAt) {
+		t.Errorf("expected the first publication time to be kept, got %v", page.PublishedAt)
+	}
+	if w := get("/api/pages/me/values"); w.Code != http.StatusOK {
+		t.Errorf("expected the child to move with its parent, got %d", w.Code)
+	}
+	if w := get("/api/pages/about"); w.Code != http.StatusNotFound {
+		t.Errorf("expected the old path to be gone, got %d", w.Code)
+	}
+
+	// A published page beneath a draft is hidden from readers along with its parent
+	if w := send("PUT", "/api/admin/pages/"+about.ID.String(), `{"path":"me","title":"About","body":"## Hello"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d returning the parent to draft, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w := get("/api/pages/me/values"); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a page beneath a draft, got %d", http.StatusNotFound, w.Code)
+	}
+	if w := send("GET", "/api/pages/me/values", ""); w.Code != http.StatusOK || w.Header().Get("Cache-Control") != "private, no-store" {
+		t.Errorf("expected admins to read it uncached, got %d %q", w.Code, w.Header().Get("Cache-Control"))
+	}
+	if w := send("PUT", "/api/admin/pages/"+about.ID.String(), `{"path":"me","title":"About","body":"## Hello","status":"published"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d republishing the parent, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w := get("/api/pages/me/values"); w.Code != http.StatusOK {
+		t.Errorf("expected the page public again with its parent, got %d", w.Code)
+	}
+
+	// Admin list is in path order
+	var all []view.SitePageResponse
+	json.Unmarshal(send("GET", "/api/admin/pages", "").Body.Bytes(), &all)
+	if len(all) != 2 || all[0].Path != "me" || all[1].Path != "me/values" {
+		t.Errorf("expected me then me/values, got %+v", all)
+	}
+
+	if w := send("DELETE", "/api/admin/pages/"+values.ID.String(), ""); w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if w := send("DELETE", "/api/admin/pages/"+about.ID.String(), ""); w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+}
+
+func TestPublishedListFilters(t *testing.T) {
+	verifier := &testTokenVerifier{}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	future := time.Now().UTC().Add(time.Hour)
+	posts := []*model.Post{
+		{Slug: "node-hyphen", Title: "Hyphen", Body: "Body", Tags: []string{"node-js"}, Status: model.PostStatusPublished},
+		{Slug: "node-underscore", Title: "Underscore", Body: "Body", Tags: []string{"node_js"}, Status: model.PostStatusPublished},
+		{Slug: "from-the-future", Title: "Future", Body: "Body", Tags: []string{"node_js"}, Status: model.PostStatusPublished, PublishedAt: &future},
+	}
+	for _, post := range posts {
+		if err := postRepo.Create(context.Background(), post); err != nil {
+			t.Fatalf("failed to create post %s: %v", post.Slug, err)
+		}
+	}
+
+	list := func(path string) []string {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		var listed []view.PostResponse
+		json.Unmarshal(w.Body.Bytes(), &listed)
+		var slugs []string
+		for _, p := range listed {
+			slugs = append(slugs, p.Slug)
+		}
+		sort.Strings(slugs)
+		return slugs
+	}
+
+	// A post dated in the future is hidden from lists just as it 404s by slug
+	if slugs := list("/api/posts"); !reflect.DeepEqual(slugs, []string{"node-hyphen", "node-underscore"}) {
+		t.Errorf("expected only posts already published, got %v", slugs)
+	}
+
+	// LIKE wildcards in a tag match literally
+	if slugs := list("/api/posts?tag=node_js"); !reflect.DeepEqual(slugs, []string{"node-underscore"}) {
+		t.Errorf("expected only the post tagged node_js, got %v", slugs)
+	}
+	if slugs := list("/api/posts?tag=node%25js"); len(slugs) != 0 {
+		t.Errorf("expected no posts tagged node%%js, got %v", slugs)
+	}
+}
+
+func TestScheduledPostHiddenUntilPublished(t *testing.T) {
+	verifier := &testTokenVerifier{}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	publishAt := time.Now().UTC().Add(100 * time.Millisecond)
+	post := &model.Post{
+		Slug:      "next-tuesday",
+		Title:     "Next Tuesday",
+		Body:      "Queued for later",
+		Status:    model.PostStatusScheduled,
+		PublishAt: &publishAt,
+	}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create scheduled post: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/posts/next-tuesday", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d before publish time, got %d", http.StatusNotFound, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/posts", nil)
+	router.ServeHTTP(w, req)
+
+	var listed []map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &listed)
+	if len(listed) != 0 {
+		t.Fatalf("expected scheduled post to be hidden from list, got %d posts", len(listed))
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	published, err := postRepo.PublishDue(context.Background(), time.Now().UTC())
+	if err != nil {
+		t.Fatalf("failed to publish due posts: %v", err)
+	}
+	if len(published) != 1 || published[0].Slug != "next-tuesday" {
+		t.Fatalf("expected next-tuesday to be published, got %+v", published)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/posts/next-tuesday", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d after publish time, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response["status"] != "published" {
+		t.Errorf("expected published status, got %v", response["status"])
+	}
+}
+
+func TestUnpublishDueArchivesPost(t *testing.T) {
+	verifier := &testTokenVerifier{}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	unpublishAt := time.Now().UTC().Add(time.Hour)
+	post := &model.Post{
+		Slug:        "limited-run",
+		Title:       "Limited Run",
+		Body:        "Only up for a while",
+		Tags:        []string{"news"},
+		Status:      model.PostStatusPublished,
+		UnpublishAt: &unpublishAt,
+	}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/posts?tag=news", nil)
+	router.ServeHTTP(w, req)
+
+	var listed []map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &listed)
+	if len(listed) != 1 {
+		t.Fatalf("expected 1 post before unpublish time, got %d. Body: %s", len(listed), w.Body.String())
+	}
+
+	unpublished, err := postRepo.UnpublishDue(context.Background(), unpublishAt.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("failed to unpublish due posts: %v", err)
+	}
+	if len(unpublished) != 1 || unpublished[0].Slug != "limited-run" {
+		t.Fatalf("expected limited-run to be unpublished, got %+v", unpublished)
+	}
+
+	archived, err := postRepo.GetBySlug(context.Background(), "limited-run")
+	if err != nil {
+		t.Fatalf("failed to reload post: %v", err)
+	}
+	if archived.Status != model.PostStatusArchived {
+		t.Errorf("expected archived status, got %s", archived.Status)
+	}
+}
+
+func TestDraftPreviewLink(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{
+				ID:     "admin-user",
+				Email:  "admin@example.com",
+				Groups: []string{"admin"},
+			}, nil
+		},
+	}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	post := &model.Post{
+		Slug:   "work-in-progress",
+		Title:  "Work In Progress",
+		Body:   "Not ready yet",
+		Status: model.PostStatusDraft,
+	}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create draft: %v", err)
+	}
+
+	// Anonymous readers cannot see the draft without a link
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/posts/work-in-progress", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d without preview token, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// Admin mints a preview link
+	body, _ := json.Marshal(CreatePreviewTokenRequest{ExpiresInHours: 2})
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/admin/posts/"+post.ID.String()+"/preview-tokens", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var created map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &created)
+	previewURL, _ := created["preview_url"].(string)
+	tokenID, _ := created["id"].(string)
+
+	// Anyone holding the link can read the draft, but it is marked noindex
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", previewURL, nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d with preview token, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w.Header().Get("X-Robots-Tag") != "noindex" {
+		t.Errorf("expected X-Robots-Tag noindex, got %q", w.Header().Get("X-Robots-Tag"))
+	}
+
+	// A tampered token is rejected
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", previewURL+"x", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d with tampered token, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// Revoking the link stops it working
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("DELETE", "/api/admin/posts/"+post.ID.String()+"/preview-tokens/"+tokenID, nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusNoContent, w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", previewURL, nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d after revocation, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestDraftVisibleToAdmin(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{
+				ID:     "admin-user",
+				Email:  "admin@example.com",
+				Groups: []string{"admin"},
+			}, nil
+		},
+	}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	post := &model.Post{
+		Slug:   "admin-only",
+		Title:  "Admin Only",
+		Body:   "Draft body",
+		Status: model.PostStatusDraft,
+	}
+	if err := postRepo.Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create draft: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/posts/admin-only", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d for admin, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+}
+
+// Test Tag Handler Integration
+
+func TestTagListCountsAndDescriptions(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{
+				ID:     "admin-user",
+				Email:  "admin@example.com",
+				Groups: []string{"admin"},
+			}, nil
+		},
+	}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	// Future-dated posts are not listed yet, so their tags are not counted either
+	future := time.Now().UTC().Add(time.Hour)
+	for _, p := range []*model.Post{
+		{Slug: "first", Title: "First", Body: "Body", Status: model.PostStatusPublished, Tags: []string{"Go", " Web  Dev "}},
+		{Slug: "second", Title: "Second", Body: "Body", Status: model.PostStatusPublished, Tags: []string{"go"}},
+		{Slug: "draft", Title: "Draft", Body: "Body", Status: model.PostStatusDraft, Tags: []string{"go", "secret"}},
+		{Slug: "future", Title: "Future", Body: "Body", Status: model.PostStatusPublished, PublishedAt: &future, Tags: []string{"go", "upcoming"}},
+	} {
+		if err := postRepo.Create(context.Background(), p); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+	}
+
+	body, _ := json.Marshal(UpdateTagRequest{Description: "Posts about the Go language"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/api/admin/tags/Go", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/tags", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var tags []view.TagResponse
+	json.Unmarshal(w.Body.Bytes(), &tags)
+	expected := []view.TagResponse{
+		{Name: "go", Description: "Posts about the Go language", PostCount: 2},
+		{Name: "web-dev", PostCount: 1},
+	}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("expected tags %+v, got %+v", expected, tags)
+	}
+}
+
+func TestTagRenameMergeAndAliases(t *testing.T) {
+	verifier := &testTokenVerifier{
+		verifyFunc: func(ctx context.Context, authHeader string) (*auth.User, error) {
+			return &auth.User{
+				ID:     "admin-user",
+				Email:  "admin@example.com",
+				Groups: []string{"admin"},
+			}, nil
+		},
+	}
+	router, closer, postRepo, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	for _, p := range []*model.Post{
+		{Slug: "one", Title: "One", Body: "Body", Status: model.PostStatusPublished, Tags: []string{"golang", "js"}},
+		{Slug: "two", Title: "Two", Body: "Body", Status: model.PostStatusPublished, Tags: []string{"javascript", "js"}},
+	} {
+		if err := postRepo.Create(context.Background(), p); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+	}
+
+	adminRequest := func(method, path string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer valid-token")
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Rename golang to go
+	w := adminRequest("POST", "/api/admin/tags/golang/rename", RenameTagRequest{To: "go"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// Renaming onto a tag in use must be a merge instead
+	w = adminRequest("POST", "/api/admin/tags/go/rename", RenameTagRequest{To: "js"})
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+
+	// Merge js into javascript
+	w = adminRequest("POST", "/api/admin/tags/merge", MergeTagsRequest{Sources: []string{"js"}, Target: "javascript"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var merged view.RetagResponse
+	json.Unmarshal(w.Body.Bytes(), &merged)
+	if merged.PostsUpdated != 2 {
+		t.Errorf("expected 2 posts updated, got %d", merged.PostsUpdated)
+	}
+
+	one, _ := postRepo.GetBySlug(context.Background(), "one")
+	if !reflect.DeepEqual(one.Tags, []string{"go", "javascript"}) {
+		t.Errorf("expected post one tags [go javascript], got %v", one.Tags)
+	}
+	two, _ := postRepo.GetBySlug(context.Background(), "two")
+	if !reflect.DeepEqual(two.Tags, []string{"javascript"}) {
+		t.Errorf("expected post two tags [javascript], got %v", two.Tags)
+	}
+
+	// Old names now resolve as aliases when saving posts and filtering lists
+	w = adminRequest("POST", "/api/posts", CreatePostRequest{
+		Slug:   "three",
+		Title:  "Three",
+		Body:   "Body",
+		Tags:   []string{"Golang", "JS"},
+		Status: "published",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var created view.PostResponse
+	json.Unmarshal(w.Body.Bytes(), &created)
+	if !reflect.DeepEqual(created.Tags, []string{"go", "javascript"}) {
+		t.Errorf("expected canonical tags [go javascript], got %v", created.Tags)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/posts?tag=js", nil)
+	router.ServeHTTP(w, req)
+
+	var listed []view.PostResponse
+	json.Unmarshal(w.Body.Bytes(), &listed)
+	if len(listed) != 3 {
+		t.Errorf("expected 3 posts tagged via alias js, got %d", len(listed))
+	}
+
+	w = adminRequest("GET", "/api/admin/tag-aliases", nil)
+	var aliases []view.TagAliasResponse
+	json.Unmarshal(w.Body.Bytes(), &aliases)
+	if len(aliases) != 2 || aliases[0].Alias != "golang" || aliases[1].Alias != "js" {
+		t.Errorf("expected aliases golang and js, got %+v", aliases)
+	}
+
+	w = adminRequest("DELETE", "/api/admin/tag-aliases/js", nil)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+}
+
+// Test Guestbook Handler Integration
+
+func TestGuestbookListApproved(t *testing.T) {
+	verifier := &testTokenVerifier{}
+	router, closer, _, _, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/guestbook", nil)
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	// Should return empty array
+	var response map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response == nil {
+		t.Logf("got empty response (expected)")
+	}
+}
+
+func TestGuestbookListBeforeCursor(t *testing.T) {
+	verifier := &testTokenVerifier{}
+	router, closer, _, guestbookRepo, _, _ := setupTestRouter(t, verifier)
+	defer closer.Close()
+
+	for _, message := range []string{"first", "second", "third"} {
+		entry := &model.GuestbookEntry{
+			UserProvider: "google",
+			UserID:       "user-" + message,
+			DisplayName:  "Visitor",
+			Message:      message,
+			IsApproved:   true,
+		}
+		if err := guestbookRepo.Create(context.Background(), entry); err != nil {
+			t.Fatalf("failed to create entry: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/guestbook?limit=2&before=", nil)
+	router.ServeHTTP(w, req)
+
+	var page view.PageResponse
+	json.Unmarshal(w.Body.Bytes(), &page)
+	if page.NextCursor == nil {
+		t.Fatalf("expected next_cursor on first page. Body: %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/guestbook?limit=2&before="+url.QueryEscape(*page.NextCursor), nil)
+	router.ServeHTTP(w, req)
+
+	var last struct {
+		Items      []view.GuestbookEntryResponse `json:"items"`
+		NextCursor *string                       `json:"next_cursor"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &last)
+	if len(last.Items) != 1 || last.Items[0].Message != "first" {
+		t.Errorf("expected oldest entry on last page, got %+v", last.Items)
+	}
+	if last.NextCursor != nil {
+		t.Errorf("expected no next_cursor on last page, got %q", *last.NextCursor)
 	}
 }
 
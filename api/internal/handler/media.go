@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+	"github.com/sochoa/sochoa.dev/api/internal/media"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/storage"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// multipartOverhead allows for the multipart boundaries and headers around an uploaded file
+const multipartOverhead = 64 << 10
+
+// MediaHandler handles media library HTTP requests
+type MediaHandler struct {
+	library *media.Library
+}
+
+// NewMediaHandler creates a new media handler
+func NewMediaHandler(library *media.Library) *MediaHandler {
+	return &MediaHandler{
+		library: library,
+	}
+}
+
+// requireLibrary answers 503 when media storage could not be set up at startup
+func (h *MediaHandler) requireLibrary(c *gin.Context) {
+	if h.library == nil {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "media storage unavailable"})
+		return
+	}
+	c.Next()
+}
+
+// UploadMedia handles POST /api/admin/media (admin only)
+// @Summary		Upload a media file
+// @Description	Upload an image as multipart form field "file" (admin only). The type is sniffed from the content; images get resized variants. Uploading a file identical to an existing asset returns that asset with 200
+// @Tags			Media
+// @Accept			multipart/form-data
+// @Produce		json
+// @Param			file	formData	file					true	"File to upload"
+// @Success		201		{object}	view.MediaAssetResponse	"Asset created"
+// @Success		200		{object}	view.MediaAssetResponse	"Identical asset already exists"
+// @Failure		400		{object}	map[string]string		"Missing, empty or unsupported file"
+// @Failure		401		{object}	map[string]string		"Unauthorized"
+// @Failure		403		{object}	map[string]string		"Forbidden - admin role required"
+// @Failure		413		{object}	map[string]string		"File too large"
+// @Router			/api/admin/media [post]
+// @Security		BearerAuth
+func (h *MediaHandler) UploadMedia(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	limit := h.library.MaxUploadSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file exceeds the " + strconv.FormatInt(limit, 10) + " byte upload limit"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "multipart form field 'file' is required"})
+		return
+	}
+
+	if fileHeader.Size > limit {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file exceeds the " + strconv.FormatInt(limit, 10) + " byte upload limit"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read upload"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read upload"})
+		return
+	}
+
+	asset, created, err := h.library.Upload(c, fileHeader.Filename, data)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	references, err := h.library.References(c, []model.MediaAsset{*asset})
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to find media references"})
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, view.ToMediaAssetResponse(asset, references[asset.ID], h.library.URL))
+}
+
+// ListMedia handles GET /api/admin/media (admin only)
+// @Summary		List media assets
+// @Description	List uploaded assets newest first, with their variants and the posts, translations and pages referencing them (admin only)
+// @Tags			Media
+// @Produce		json
+// @Param			limit	query		integer	false	"Number of assets per page (default: 10)"
+// @Param			offset	query		integer	false	"Number of assets to skip (default: 0)"
+// @Param			cursor	query		string	false	"Opaque cursor from a previous page's next_cursor; switches the response to a paginated envelope"
+// @Param			include_total	query		boolean	false	"Include the total item count"
+// @Param			fields	query		string	false	"Comma-separated response fields to return, e.g. id,filename,url"
+// @Success		200		{array}		view.MediaAssetResponse	"List of assets (view.PageResponse envelope when cursor is given)"
+// @Failure		400		{object}	map[string]string		"Invalid cursor"
+// @Failure		401		{object}	map[string]string		"Unauthorized"
+// @Failure		403		{object}	map[string]string		"Forbidden - admin role required"
+// @Router			/api/admin/media [get]
+// @Security		BearerAuth
+func (h *MediaHandler) ListMedia(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	page, err := parsePageGin(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	assets, next, err := h.library.List(c, page)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list media"})
+		return
+	}
+
+	references, err := h.library.References(c, assets)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to find media references"})
+		return
+	}
+
+	var total *int
+	if wantsTotal(c) {
+		count, err := h.library.Count(c)
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count media"})
+			return
+		}
+		total = &count
+	}
+
+	writePageGin(c, view.ToMediaAssetResponses(assets, references, h.library.URL), next, total)
+}
+
+// GetMedia handles GET /api/admin/media/:id (admin only)
+// @Summary		Get a media asset
+// @Description	Get an uploaded asset with its variants and the posts, translations and pages referencing it (admin only)
+// @Tags			Media
+// @Produce		json
+// @Param			id	path		string					true	"Asset ID (UUID)"
+// @Success		200	{object}	view.MediaAssetResponse	"Asset found"
+// @Failure		400	{object}	map[string]string		"Invalid ID format"
+// @Failure		401	{object}	map[string]string		"Unauthorized"
+// @Failure		403	{object}	map[string]string		"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string		"Asset not found"
+// @Router			/api/admin/media/{id} [get]
+// @Security		BearerAuth
+func (h *MediaHandler) GetMedia(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	asset, references, err := h.library.Get(c, id)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToMediaAssetResponse(asset, references, h.library.URL))
+}
+
+// DeleteMedia handles DELETE /api/admin/media/:id (admin only)
+// @Summary		Delete a media asset
+// @Description	Delete an asset and its stored files (admin only). An asset referenced by posts, translations or pages is only deleted with force=true; otherwise 409 lists the references
+// @Tags			Media
+// @Param			id		path	string	true	"Asset ID (UUID)"
+// @Param			force	query	boolean	false	"Delete even when content references the asset"
+// @Success		204		"Asset deleted"
+// @Failure		400		{object}	map[string]string	"Invalid ID format"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string	"Asset not found"
+// @Failure		409		{object}	map[string]interface{}	"Asset is referenced by content"
+// @Router			/api/admin/media/{id} [delete]
+// @Security		BearerAuth
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	force, _ := strconv.ParseBool(c.Query("force"))
+
+	references, err := h.library.Delete(c, id, force)
+	if err != nil {
+		var conflict apierrors.ConflictError
+		if errors.As(err, &conflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": conflict.Message, "references": view.ToMediaReferenceResponses(references)})
+			return
+		}
+
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ServeMedia handles GET /media/*key (public)
+// @Summary		Serve a media file
+// @Description	Serve an uploaded file or one of its variants. Keys contain the content hash, so responses are cached indefinitely
+// @Tags			Media
+// @Produce		octet-stream
+// @Param			key	path	string	true	"Storage key, e.g. <sha256>/w480.jpg"
+// @Success		200	{file}		binary				"File contents"
+// @Failure		404	{object}	map[string]string	"File not found"
+// @Router			/media/{key} [get]
+func (h *MediaHandler) ServeMedia(c *gin.Context) {
+	key := c.Param("key")
+	if len(key) > 0 && key[0] == '/' {
+		key = key[1:]
+	}
+
+	file, err := h.library.Open(c, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read file"})
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, -1, contentType, file, nil)
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/mail"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+)
+
+// NewsletterHandler handles newsletter subscription HTTP requests
+type NewsletterHandler struct {
+	newsletterRepo *model.NewsletterRepository
+	mailer         mail.Mailer
+	from           string
+	siteURL        string
+	host           string
+}
+
+// NewNewsletterHandler creates a new newsletter handler that sends from the given address
+// An empty from address defaults to newsletter@ the site's host
+func NewNewsletterHandler(newsletterRepo *model.NewsletterRepository, mailer mail.Mailer, from, siteURL string) *NewsletterHandler {
+	host := "localhost"
+	if u, err := url.Parse(siteURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	if from == "" {
+		from = "newsletter@" + host
+	}
+	return &NewsletterHandler{
+		newsletterRepo: newsletterRepo,
+		mailer:         mailer,
+		from:           from,
+		siteURL:        strings.TrimSuffix(siteURL, "/"),
+		host:           host,
+	}
+}
+
+// SubscribeRequest represents the request body for subscribing to the newsletter
+type SubscribeRequest struct {
+	Email    string `json:"email"`
+	Honeypot string `json:"honeypot,omitempty"` // Anti-spam field - should be empty
+}
+
+// subscribeAccepted is the response to every accepted subscription request, so it does not reveal who is subscribed
+var subscribeAccepted = gin.H{"message": "check your inbox to confirm your subscription"}
+
+// Subscribe handles POST /api/newsletter/subscribe (public)
+// @Summary		Subscribe to the newsletter
+// @Description	Start a double opt-in subscription by emailing a confirmation link. Addresses that are already subscribed get the same response and no email.
+// @Description	Unconfirmed addresses are deleted after 7 days. Rate limited to 3 confirmation emails per day per address.
+// @Tags			Newsletter
+// @Accept			json
+// @Produce		json
+// @Param			request	body		SubscribeRequest	true	"Subscription request"
+// @Success		202		{object}	map[string]string	"Confirmation email sent"
+// @Failure		400		{object}	map[string]string	"Invalid email address"
+// @Failure		429		{object}	map[string]string	"Rate limit exceeded"
+// @Failure		500		{object}	map[string]string	"Internal server error"
+// @Router			/api/newsletter/subscribe [post]
+func (h *NewsletterHandler) Subscribe(c *gin.Context) {
+	var req SubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	// Honeypot check - silently treat as success to not reveal the honeypot
+	if req.Honeypot != "" {
+		c.JSON(http.StatusAccepted, subscribeAccepted)
+		return
+	}
+
+	subscriber, token, err := h.newsletterRepo.Subscribe(c, req.Email, time.Now())
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	if token != "" {
+		msg := mail.Message{
+			From:    h.from,
+			To:      subscriber.Email,
+			Subject: fmt.Sprintf("Confirm your subscription to %s", h.host),
+			Body: fmt.Sprintf("Someone, hopefully you, asked to subscribe this address to the %s newsletter.\n\n"+
+				"Confirm your subscription:\n%s\n\n"+
+				"The link expires in 7 days. If you did not ask to subscribe, ignore this email and the address will be deleted.\n",
+				h.host, h.siteURL+"/newsletter/confirm?token="+url.QueryEscape(token)),
+		}
+		if err := h.mailer.Send(c, msg); err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send confirmation email"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusAccepted, subscribeAccepted)
+}
+
+// NewsletterTokenRequest represents a request carrying a confirmation or unsubscribe token
+type NewsletterTokenRequest struct {
+	Token string `json:"token"`
+}
+
+// ConfirmSubscription handles POST /api/newsletter/confirm (public)
+// @Summary		Confirm a newsletter subscription
+// @Description	Complete a double opt-in with the token from the confirmation email and send a welcome email. Tokens work once and expire after 7 days.
+// @Tags			Newsletter
+// @Accept			json
+// @Produce		json
+// @Param			request	body		NewsletterTokenRequest	true	"Confirmation token"
+// @Success		200		{object}	map[string]string		"Subscription confirmed"
+// @Failure		400		{object}	map[string]string		"Invalid request body"
+// @Failure		404		{object}	map[string]string		"Invalid or expired token"
+// @Router			/api/newsletter/confirm [post]
+func (h *NewsletterHandler) ConfirmSubscription(c *gin.Context) {
+	var req NewsletterTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	subscriber, err := h.newsletterRepo.Confirm(c, req.Token, time.Now())
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	// The subscription stands even if the welcome email cannot be sent
+	msg := mail.Message{
+		From:    h.from,
+		To:      subscriber.Email,
+		Subject: fmt.Sprintf("You're subscribed to %s", h.host),
+		Body: fmt.Sprintf("Thanks for confirming. New posts from %s will arrive at this address.\n\n"+
+			"Unsubscribe at any time:\n%s\n",
+			h.host, h.siteURL+"/newsletter/unsubscribe?token="+url.QueryEscape(subscriber.UnsubscribeToken)),
+		Headers: h.unsubscribeHeaders(subscriber.UnsubscribeToken),
+	}
+	if err := h.mailer.Send(c, msg); err != nil {
+		c.Error(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "subscription confirmed"})
+}
+
+// unsubscribeHeaders returns the RFC 8058 headers that let mail clients unsubscribe the reader in one click
+func (h *NewsletterHandler) unsubscribeHeaders(token string) map[string]string {
+	return map[string]string{
+		"List-Unsubscribe":      "<" + h.siteURL + "/api/newsletter/unsubscribe?token=" + url.QueryEscape(token) + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+}
+
+// Unsubscribe handles POST /api/newsletter/unsubscribe (public)
+// @Summary		Unsubscribe from the newsletter
+// @Description	Remove the address an unsubscribe token was issued to. Accepts the token in the query string, as mail clients send
+// @Description	RFC 8058 one-click requests, or as a JSON body. The address is deleted rather than kept as unsubscribed.
+// @Tags			Newsletter
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Produce		json
+// @Param			token	query		string					false	"Unsubscribe token from the List-Unsubscribe header"
+// @Param			request	body		NewsletterTokenRequest	false	"Unsubscribe token"
+// @Success		200		{object}	map[string]string		"Unsubscribed"
+// @Failure		404		{object}	map[string]string		"Unknown token"
+// @Router			/api/newsletter/unsubscribe [post]
+func (h *NewsletterHandler) Unsubscribe(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" && strings.HasPrefix(c.ContentType(), "application/json") {
+		var req NewsletterTokenRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+		token = req.Token
+	}
+
+	if err := h.newsletterRepo.Unsubscribe(c, token); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "unsubscribed"})
+}
+
+// ExportSubscribers handles GET /api/admin/newsletter/export (admin only)
+// @Summary		Export newsletter subscribers
+// @Description	Download confirmed subscribers as CSV with email, confirmed_at and subscribed_at columns (admin only). Unconfirmed addresses are left out.
+// @Tags			Newsletter
+// @Produce		text/csv
+// @Success		200	{string}	string				"CSV of confirmed subscribers"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Router			/api/admin/newsletter/export [get]
+// @Security		BearerAuth
+func (h *NewsletterHandler) ExportSubscribers(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	subscribers, err := h.newsletterRepo.ListConfirmed(c)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list subscribers"})
+		return
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"email", "confirmed_at", "subscribed_at"})
+	for _, subscriber := range subscribers {
+		confirmedAt := ""
+		if subscriber.ConfirmedAt != nil {
+			confirmedAt = subscriber.ConfirmedAt.UTC().Format(time.RFC3339)
+		}
+		w.Write([]string{subscriber.Email, confirmedAt, subscriber.CreatedAt.UTC().Format(time.RFC3339)})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export subscribers"})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="subscribers.csv"`)
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sochoa/sochoa.dev/api/internal/ogimage"
+)
+
+// ogImageCacheSize bounds how many rendered share images are kept in memory
+const ogImageCacheSize = 128
+
+// GetPostImage handles GET /api/posts/:slug/og.png (public)
+// @Summary		Get a blog post's share image
+// @Description	Render the 1200x630 Open Graph / Twitter card image for a post from its title, tags and date.
+// @Description	The ETag is a hash of the drawn content, so it changes whenever an edit changes the image.
+// @Tags			Posts
+// @Produce		png
+// @Param			slug			path		string	true	"Post slug"
+// @Param			preview			query		string	false	"Signed preview token for an unpublished post"
+// @Param			If-None-Match	header		string	false	"ETag from an earlier response; an unchanged image returns 304"
+// @Success		200		{file}		binary				"PNG image"
+// @Success		304		"Not modified"
+// @Failure		404		{object}	map[string]string	"Post not found"
+// @Failure		410		{object}	map[string]string	"Post has been deleted"
+// @Router			/api/posts/{slug}/og.png [get]
+func (h *PostHandler) GetPostImage(c *gin.Context) {
+	now := time.Now().UTC()
+
+	post, err := h.postRepo.GetBySlug(c, c.Param("slug"))
+	if err == nil && post.IsTrashed() {
+		respondPostGone(c)
+		return
+	}
+	if err != nil || !h.canView(c, post, now) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+		return
+	}
+
+	date := post.CreatedAt
+	if post.PublishedAt != nil {
+		date = *post.PublishedAt
+	} else if post.PublishAt != nil {
+		date = *post.PublishAt
+	}
+
+	hash, image, err := h.ogImages.Get(ogimage.Card{Title: post.Title, Tags: post.Tags, Date: date})
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to render share image"})
+		return
+	}
+
+	c.Header("ETag", `"`+hash+`"`)
+	setLastModified(c, post.UpdatedAt)
+	c.Data(http.StatusOK, "image/png", image)
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// mergePatchContentType is the media type of an RFC 7396 JSON merge patch
+const mergePatchContentType = "application/merge-patch+json"
+
+// PatchPost handles PATCH /api/posts/:id (admin only)
+// @Summary		Partially update a blog post
+// @Description	Apply a JSON merge patch (RFC 7396) to a post by ID (admin only). Only the fields present are changed;
+// @Description	null clears summary, tags, publish_at and unpublish_at, while slug, title, body, status and locale cannot be removed.
+// @Description	The patched post is validated as a whole, so e.g. scheduling a post needs status and publish_at together.
+// @Description	A status change follows the publish, unpublish and archive transitions and emits their event; only a draft can be scheduled.
+// @Tags			Posts
+// @Accept			application/merge-patch+json
+// @Produce		json
+// @Param			id			path		string				true	"Post ID (UUID)"
+// @Param			If-Match	header		string				false	"ETag from the last read; required when REQUIRE_IF_MATCH is set"
+// @Param			request		body		UpdatePostRequest	true	"Merge patch of post fields, sent as application/merge-patch+json"
+// @Success		200		{object}	view.PostResponse	"Post updated successfully"
+// @Failure		400		{object}	map[string]string	"Invalid patch"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string	"Post not found"
+// @Failure		409		{object}	map[string]string	"Status change not allowed from the post's current status"
+// @Failure		412		{object}	map[string]string	"Post was modified since the If-Match version"
+// @Failure		415		{object}	map[string]string	"Content-Type is not application/merge-patch+json"
+// @Failure		428		{object}	map[string]string	"If-Match header required"
+// @Router			/api/posts/{id} [patch]
+// @Security		BearerAuth
+func (h *PostHandler) PatchPost(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type")); mediaType != mergePatchContentType {
+		c.Header("Accept-Patch", mergePatchContentType)
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "content type must be " + mergePatchContentType})
+		return
+	}
+
+	// A merge patch that is not an object would replace the whole post, which is what PUT is for
+	var patch map[string]json.RawMessage
+	decoder := json.NewDecoder(c.Request.Body)
+	if err := decoder.Decode(&patch); err != nil || patch == nil || decoder.More() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	post, err := h.postRepo.GetByID(c, id)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	if !checkIfMatch(c, post.Version) {
+		return
+	}
+
+	// A status change goes through the state machine first, so timestamps in the patch still apply after it
+	now := time.Now().UTC()
+	from := post.Status
+	var transition model.PostTransition
+	if raw, ok := patch["status"]; ok {
+		var target model.PostStatus
+		if json.Unmarshal(raw, &target) == nil && target != "" {
+			if transition, err = post.ChangeStatus(target, now); err != nil {
+				status, message := statusCodeFromError(err)
+				c.JSON(status, gin.H{"error": message})
+				return
+			}
+		}
+	}
+
+	if err := applyPostPatch(post, patch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, ok := patch["tags"]; ok {
+		post.Tags, err = h.tagRepo.Canonicalize(c, post.Tags)
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve tags"})
+			return
+		}
+	}
+
+	if err := h.postRepo.Update(c, post); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	h.publishTransition(c, transition, post, from, now)
+
+	response := view.ToPostResponse(post)
+	if err := h.attachSeries(c, []*view.PostResponse{response}, true); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load series"})
+		return
+	}
+
+	setVersionETag(c, post.Version)
+	c.JSON(http.StatusOK, response)
+}
+
+// requiredPostFields are the post fields a patch may change but not remove
+var requiredPostFields = map[string]bool{"slug": true, "title": true, "body": true, "status": true, "locale": true}
+
+// applyPostPatch merges the fields of a JSON merge patch into post
+// Fields are applied in name order so the first error reported does not depend on map iteration
+func applyPostPatch(post *model.Post, patch map[string]json.RawMessage) error {
+	fields := make([]string, 0, len(patch))
+	for field := range patch {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		raw := patch[field]
+		null := bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+		if null && requiredPostFields[field] {
+			return fmt.Errorf("'%s' cannot be removed", field)
+		}
+
+		var err error
+		switch field {
+		case "slug":
+			err = json.Unmarshal(raw, &post.Slug)
+		case "title":
+			err = json.Unmarshal(raw, &post.Title)
+		case "body":
+			err = json.Unmarshal(raw, &post.Body)
+		case "locale":
+			err = json.Unmarshal(raw, &post.Locale)
+		case "status":
+			err = json.Unmarshal(raw, &post.Status)
+		case "summary":
+			post.Summary = ""
+			if !null {
+				err = json.Unmarshal(raw, &post.Summary)
+			}
+		case "tags":
+			post.Tags = nil
+			if !null {
+				err = json.Unmarshal(raw, &post.Tags)
+			}
+		case "publish_at":
+			err = patchTime(raw, null, &post.PublishAt)
+		case "unpublish_at":
+			err = patchTime(raw, null, &post.UnpublishAt)
+		default:
+			return fmt.Errorf("unknown field '%s'", field)
+		}
+
+		if err != nil {
+			return fmt.Errorf("invalid value for '%s'", field)
+		}
+	}
+
+	return nil
+}
+
+// patchTime sets or, given null, clears an optional timestamp
+func patchTime(raw json.RawMessage, null bool, dest **time.Time) error {
+	if null {
+		*dest = nil
+		return nil
+	}
+
+	var t time.Time
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return err
+	}
+	*dest = &t
+	return nil
+}
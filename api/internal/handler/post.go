@@ -2,34 +2,54 @@ package handler
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/events"
 	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/ogimage"
 	"github.com/sochoa/sochoa.dev/api/internal/view"
 )
 
 // PostHandler handles post-related HTTP requests
 type PostHandler struct {
-	postRepo *model.PostRepository
+	postRepo      *model.PostRepository
+	tagRepo       *model.TagRepository
+	seriesRepo    *model.SeriesRepository
+	previewRepo   *model.PreviewTokenRepository
+	previewSigner *auth.PreviewSigner
+	reactionRepo  *model.ReactionRepository
+	ogImages      *ogimage.Cache
+	events        *events.Bus
 }
 
 // NewPostHandler creates a new post handler
-func NewPostHandler(postRepo *model.PostRepository) *PostHandler {
+func NewPostHandler(postRepo *model.PostRepository, tagRepo *model.TagRepository, seriesRepo *model.SeriesRepository, previewRepo *model.PreviewTokenRepository, previewSigner *auth.PreviewSigner, reactionRepo *model.ReactionRepository, eventBus *events.Bus) *PostHandler {
 	return &PostHandler{
-		postRepo: postRepo,
+		postRepo:      postRepo,
+		tagRepo:       tagRepo,
+		seriesRepo:    seriesRepo,
+		previewRepo:   previewRepo,
+		previewSigner: previewSigner,
+		reactionRepo:  reactionRepo,
+		ogImages:      ogimage.NewCache(ogimage.DefaultTemplate, ogImageCacheSize),
+		events:        eventBus,
 	}
 }
 
 // CreatePostRequest represents the request body for creating a post
 type CreatePostRequest struct {
-	Slug      string   `json:"slug" binding:"required"`
-	Title     string   `json:"title" binding:"required"`
-	Summary   string   `json:"summary"`
-	Body      string   `json:"body" binding:"required"`
-	Tags      []string `json:"tags"`
-	Status    string   `json:"status" binding:"required"`
+	Slug        string     `json:"slug" binding:"required"`
+	Title       string     `json:"title" binding:"required"`
+	Summary     string     `json:"summary"`
+	Body        string     `json:"body" binding:"required"`
+	Tags        []string   `json:"tags"`
+	Status      string     `json:"status" binding:"required"`
+	Locale      string     `json:"locale,omitempty"`       // Language the post is written in
+	PublishAt   *time.Time `json:"publish_at,omitempty"`   // Required when status is scheduled
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"` // Optional automatic archive time
 }
 
 // CreatePost handles POST /api/posts (admin only)
@@ -58,13 +78,23 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 		return
 	}
 
+	tags, err := h.tagRepo.Canonicalize(c, req.Tags)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve tags"})
+		return
+	}
+
 	post := &model.Post{
-		Slug:    req.Slug,
-		Title:   req.Title,
-		Summary: req.Summary,
-		Body:    req.Body,
-		Tags:    req.Tags,
-		Status:  model.PostStatus(req.Status),
+		Slug:        req.Slug,
+		Title:       req.Title,
+		Summary:     req.Summary,
+		Body:        req.Body,
+		Tags:        tags,
+		Status:      model.PostStatus(req.Status),
+		Locale:      req.Locale,
+		PublishAt:   req.PublishAt,
+		UnpublishAt: req.UnpublishAt,
 	}
 
 	if err := h.postRepo.Create(c, post); err != nil {
@@ -77,18 +107,27 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, post.Version)
 	c.JSON(http.StatusCreated, view.ToPostResponse(post))
 }
 
 // GetPost handles GET /api/posts/:slug (public)
 // @Summary		Get a blog post by slug
-// @Description	Get a blog post by slug (public posts visible to all, drafts only to admin)
+// @Description	Get a blog post by slug (live published posts visible to all, unpublished posts only to admin or with a valid preview token).
+// @Description	Translation slugs resolve to the same post. The response is in the negotiated locale, given in Content-Language, and alternates lists every available locale for hreflang links.
 // @Tags			Posts
 // @Produce		json
 // @Param			slug	path		string	true	"Post slug"
+// @Param			preview	query		string	false	"Signed preview token for an unpublished post"
+// @Param			lang	query		string	false	"Preferred locale; overrides the slug's language and Accept-Language"
+// @Param			Accept-Language	header	string	false	"Preferred locales, used when neither lang nor a translation slug picks one"
+// @Param			If-None-Match	header	string	false	"ETag from an earlier response; unchanged content returns 304"
 // @Success		200		{object}	view.PostResponse	"Post found"
+// @Success		304		"Not modified"
+// @Success		301		{object}	view.PostRedirectResponse	"Slug has changed; Location points at the current slug"
 // @Failure		400		{object}	map[string]string	"Missing slug parameter"
 // @Failure		404		{object}	map[string]string	"Post not found"
+// @Failure		410		{object}	map[string]string	"Post has been deleted"
 // @Router			/api/posts/{slug} [get]
 func (h *PostHandler) GetPost(c *gin.Context) {
 	slug := c.Param("slug")
@@ -97,73 +136,302 @@ func (h *PostHandler) GetPost(c *gin.Context) {
 		return
 	}
 
+	now := time.Now().UTC()
+
+	// A translation's slug serves the post in that translation's language
+	var slugLocale string
 	post, err := h.postRepo.GetBySlug(c, slug)
+	if err != nil {
+		if translation, translationErr := h.postRepo.GetTranslationBySlug(c, slug); translationErr == nil {
+			post, err = h.postRepo.GetByID(c, translation.PostID)
+			slugLocale = translation.Locale
+		}
+	}
 	if err != nil {
 		status, message := statusCodeFromError(err)
+		if status == http.StatusNotFound {
+			h.redirectHistoricalSlug(c, slug, now)
+			return
+		}
 		c.JSON(status, gin.H{"error": message})
 		return
 	}
 
-	// For public access, only show published posts
-	if post.Status != model.PostStatusPublished {
-		user, exists := c.Get("user")
-		if !exists || user.(*auth.User) == nil || !user.(*auth.User).IsAdmin() {
-			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
-			return
-		}
+	if post.IsTrashed() {
+		respondPostGone(c)
+		return
+	}
+
+	if !h.canView(c, post, now) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+		return
+	}
+
+	translations, err := h.postRepo.ListTranslations(c, []uuid.UUID{post.ID})
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load translations"})
+		return
+	}
+
+	alternates := view.ToPostAlternateResponses(post, translations[post.ID])
+	if translation := findTranslation(translations[post.ID], postLocale(c, post, translations[post.ID], slugLocale)); translation != nil {
+		post.Translate(translation)
+	}
+
+	response := view.ToPostResponse(post)
+	response.Alternates = alternates
+	if err := h.attachSeries(c, []*view.PostResponse{response}, isAdminRequest(c)); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load series"})
+		return
+	}
+
+	if err := h.attachReactions(c, []*view.PostResponse{response}); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load reactions"})
+		return
 	}
 
-	c.JSON(http.StatusOK, view.ToPostResponse(post))
+	// Admins get the version to send back in If-Match when they edit the post
+	// No Last-Modified: reactions and series navigation change without touching the post, so only the body ETag is reliable
+	if isAdminRequest(c) {
+		setVersionETag(c, post.Version)
+	}
+	c.Header("Content-Language", post.Locale)
+	c.Writer.Header().Add("Vary", "Accept-Language")
+
+	c.JSON(http.StatusOK, response)
+}
+
+// redirectHistoricalSlug answers a request for a post's old slug with a 301 to its current slug
+// The body carries the canonical slug for clients that do not follow redirects
+func (h *PostHandler) redirectHistoricalSlug(c *gin.Context, slug string, now time.Time) {
+	post, err := h.postRepo.GetByHistoricalSlug(c, slug)
+	if err == nil && post.IsTrashed() {
+		respondPostGone(c)
+		return
+	}
+	if err != nil || !h.canView(c, post, now) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+		return
+	}
+
+	location := "/api/posts/" + post.Slug
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+
+	c.Header("Location", location)
+	c.JSON(http.StatusMovedPermanently, view.PostRedirectResponse{
+		Slug:     post.Slug,
+		Location: location,
+	})
+}
+
+// canView reports whether the current request may see the post
+// Live posts are public; admins and holders of a valid preview link can see the rest,
+// in which case the response is marked so it is never indexed or cached
+func (h *PostHandler) canView(c *gin.Context, post *model.Post, now time.Time) bool {
+	if post.IsPublic(now) {
+		return true
+	}
+
+	if !isAdminRequest(c) && !h.hasValidPreview(c, post, now) {
+		return false
+	}
+
+	c.Header("X-Robots-Tag", "noindex")
+	c.Header("Cache-Control", "private, no-store")
+	return true
 }
 
 // ListPublishedPosts handles GET /api/posts (public)
 // @Summary		List all published blog posts
-// @Description	List all published blog posts with optional pagination and tag filtering
+// @Description	List published blog posts newest first with offset or cursor pagination and optional tag filtering; use fields to leave out bodies while keeping reading time
 // @Tags			Posts
 // @Produce		json
 // @Param			limit	query		integer	false	"Number of posts per page (default: 10)"
 // @Param			offset	query		integer	false	"Number of posts to skip (default: 0)"
-// @Param			tag		query		string	false	"Filter posts by tag"
-// @Success		200		{array}		view.PostResponse	"List of published posts"
+// @Param			tag		query		string	false	"Filter posts by tag (aliases resolve to the canonical tag)"
+// @Param			lang	query		string	false	"Only list posts available in this locale, shown in it"
+// @Param			cursor	query		string	false	"Opaque cursor from a previous page's next_cursor; switches the response to a paginated envelope"
+// @Param			include_total	query		boolean	false	"Include the total item count"
+// @Param			fields	query		string	false	"Comma-separated response fields to return, e.g. id,slug,title,reading_time_minutes"
+// @Param			If-None-Match	header	string	false	"ETag from an earlier response; unchanged content returns 304"
+// @Success		200		{array}		view.PostResponse	"List of published posts (view.PageResponse envelope when cursor is given)"
+// @Success		304		"Not modified"
+// @Failure		400		{object}	map[string]string	"Invalid cursor"
 // @Failure		500		{object}	map[string]string	"Internal server error"
 // @Router			/api/posts [get]
 func (h *PostHandler) ListPublishedPosts(c *gin.Context) {
-	limit, offset := parsePaginationGin(c)
-	tag := c.Query("tag")
+	page, err := parsePageGin(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	tag, err := h.tagRepo.Resolve(c, c.Query("tag"))
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list posts"})
+		return
+	}
+
+	locale := model.NormalizeLocale(c.Query("lang"))
+	if locale != "" && !model.IsValidLocale(locale) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "lang must be a language code such as en or es-MX"})
+		return
+	}
 
-	posts, err := h.postRepo.ListPublished(c, limit, offset, tag)
+	posts, next, err := h.postRepo.ListPublished(c, page, tag, locale)
 	if err != nil {
 		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list posts"})
 		return
 	}
 
-	c.JSON(http.StatusOK, view.ToPostResponses(posts))
+	var total *int
+	if wantsTotal(c) {
+		count, err := h.postRepo.CountPublished(c, tag, locale)
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count posts"})
+			return
+		}
+		total = &count
+	}
+
+	ids := make([]uuid.UUID, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+	translations, err := h.postRepo.ListTranslations(c, ids)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load translations"})
+		return
+	}
+
+	// Alternates come from each post's own language; with ?lang= the post is then shown in that language
+	alternates := make([][]view.PostAlternateResponse, len(posts))
+	for i := range posts {
+		post := &posts[i]
+		alternates[i] = view.ToPostAlternateResponses(post, translations[post.ID])
+		if translation := findTranslation(translations[post.ID], locale); translation != nil {
+			post.Translate(translation)
+		}
+	}
+
+	responses := view.ToPostResponses(posts)
+	for i := range responses {
+		responses[i].Alternates = alternates[i]
+	}
+	if err := h.attachSeries(c, postResponsePointers(responses), false); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load series"})
+		return
+	}
+
+	if err := h.attachReactions(c, postResponsePointers(responses)); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load reactions"})
+		return
+	}
+
+	// No Last-Modified: posts leaving the list do not raise it, so only the body ETag is reliable
+	if locale != "" {
+		c.Header("Content-Language", locale)
+	}
+
+	writePageGin(c, responses, next, total)
+}
+
+// attachSeries adds series navigation to each response whose post belongs to a series
+// Unless includeHidden is set, navigation only counts publicly visible series members
+func (h *PostHandler) attachSeries(c *gin.Context, responses []*view.PostResponse, includeHidden bool) error {
+	ids := make([]uuid.UUID, len(responses))
+	for i, r := range responses {
+		ids[i] = r.ID
+	}
+
+	navigation, err := h.seriesRepo.Navigation(c, ids, time.Now().UTC(), includeHidden)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range responses {
+		r.Series = view.ToSeriesNavigationResponse(navigation[r.ID])
+	}
+
+	return nil
+}
+
+// attachReactions adds the reaction counts of each response's post
+func (h *PostHandler) attachReactions(c *gin.Context, responses []*view.PostResponse) error {
+	ids := make([]uuid.UUID, len(responses))
+	for i, r := range responses {
+		ids[i] = r.ID
+	}
+
+	counts, err := h.reactionRepo.Counts(c, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range responses {
+		r.Reactions = counts[r.ID]
+	}
+
+	return nil
+}
+
+// postResponsePointers returns pointers into a slice of post responses so they can be amended in place
+func postResponsePointers(responses []view.PostResponse) []*view.PostResponse {
+	pointers := make([]*view.PostResponse, len(responses))
+	for i := range responses {
+		pointers[i] = &responses[i]
+	}
+	return pointers
+}
+
+// isAdminRequest reports whether an optionally authenticated request was made by an admin
+func isAdminRequest(c *gin.Context) bool {
+	user, exists := c.Get("user")
+	return exists && user.(*auth.User) != nil && user.(*auth.User).IsAdmin()
 }
 
 // UpdatePostRequest represents the request body for updating a post
 type UpdatePostRequest struct {
-	Slug      string   `json:"slug" binding:"required"`
-	Title     string   `json:"title" binding:"required"`
-	Summary   string   `json:"summary"`
-	Body      string   `json:"body" binding:"required"`
-	Tags      []string `json:"tags"`
-	Status    string   `json:"status" binding:"required"`
+	Slug        string     `json:"slug" binding:"required"`
+	Title       string     `json:"title" binding:"required"`
+	Summary     string     `json:"summary"`
+	Body        string     `json:"body" binding:"required"`
+	Tags        []string   `json:"tags"`
+	Status      string     `json:"status" binding:"required"`
+	Locale      string     `json:"locale,omitempty"`       // Language the post is written in
+	PublishAt   *time.Time `json:"publish_at,omitempty"`   // Required when status is scheduled
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"` // Optional automatic archive time
 }
 
 // UpdatePost handles PUT /api/posts/:id (admin only)
 // @Summary		Update a blog post
-// @Description	Update a blog post by ID (admin only)
+// @Description	Update a blog post by ID (admin only). A status change follows the publish, unpublish and archive
+// @Description	transitions and emits their event; only a draft can be scheduled.
 // @Tags			Posts
 // @Accept			json
 // @Produce		json
 // @Param			id		path		string				true	"Post ID (UUID)"
+// @Param			If-Match	header	string				false	"ETag from the last read; required when REQUIRE_IF_MATCH is set"
 // @Param			request	body		UpdatePostRequest	true	"Updated post data"
 // @Success		200		{object}	view.PostResponse	"Post updated successfully"
 // @Failure		400		{object}	map[string]string	"Invalid request"
 // @Failure		401		{object}	map[string]string	"Unauthorized"
 // @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
 // @Failure		404		{object}	map[string]string	"Post not found"
+// @Failure		409		{object}	map[string]string	"Status change not allowed from the post's current status"
+// @Failure		412		{object}	map[string]string	"Post was modified since the If-Match version"
+// @Failure		428		{object}	map[string]string	"If-Match header required"
 // @Router			/api/posts/{id} [put]
 // @Security		BearerAuth
 func (h *PostHandler) UpdatePost(c *gin.Context) {
@@ -198,12 +466,35 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 		return
 	}
 
+	if !checkIfMatch(c, post.Version) {
+		return
+	}
+
 	post.Slug = req.Slug
 	post.Title = req.Title
 	post.Summary = req.Summary
 	post.Body = req.Body
-	post.Tags = req.Tags
-	post.Status = model.PostStatus(req.Status)
+	post.Tags, err = h.tagRepo.Canonicalize(c, req.Tags)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve tags"})
+		return
+	}
+	if req.Locale != "" {
+		post.Locale = req.Locale
+	}
+
+	// A status change goes through the state machine before the requested schedule is applied
+	now := time.Now().UTC()
+	from := post.Status
+	transition, err := post.ChangeStatus(model.PostStatus(req.Status), now)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+	post.PublishAt = req.PublishAt
+	post.UnpublishAt = req.UnpublishAt
 
 	if err := h.postRepo.Update(c, post); err != nil {
 		status, message := statusCodeFromError(err)
@@ -211,12 +502,22 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, view.ToPostResponse(post))
+	h.publishTransition(c, transition, post, from, now)
+
+	response := view.ToPostResponse(post)
+	if err := h.attachSeries(c, []*view.PostResponse{response}, true); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load series"})
+		return
+	}
+
+	setVersionETag(c, post.Version)
+	c.JSON(http.StatusOK, response)
 }
 
 // DeletePost handles DELETE /api/posts/:id (admin only)
 // @Summary		Delete a blog post
-// @Description	Delete a blog post by ID (admin only)
+// @Description	Move a blog post to the trash by ID; it can be restored until the retention job purges it (admin only)
 // @Tags			Posts
 // @Param			id	path	string	true	"Post ID (UUID)"
 // @Success		204			"Post deleted successfully"
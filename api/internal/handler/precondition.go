@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// versionETag formats a row version as a strong entity tag
+func versionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// setVersionETag sets the ETag header a client echoes in If-Match to update the resource
+func setVersionETag(c *gin.Context, version int) {
+	c.Header("ETag", versionETag(version))
+}
+
+// setLastModified sets the Last-Modified header that middleware.HTTPCacheGin answers If-Modified-Since from
+// The newest of the given times wins; zero times are ignored
+func setLastModified(c *gin.Context, times ...time.Time) {
+	var latest time.Time
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+
+	if !latest.IsZero() {
+		c.Header("Last-Modified", latest.UTC().Format(http.TimeFormat))
+	}
+}
+
+// checkIfMatch compares the request's If-Match header with the resource's current version
+// It writes 412 and returns false when the client's copy is stale; a missing header is allowed here,
+// as strict mode rejects it in middleware.RequireIfMatchGin before the handler runs
+func checkIfMatch(c *gin.Context, version int) bool {
+	header := c.GetHeader("If-Match")
+	if header == "" || strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	current := versionETag(version)
+	for _, tag := range strings.Split(header, ",") {
+		// If-Match uses strong comparison, so weak tags (W/"...") never match
+		if strings.TrimSpace(tag) == current {
+			return true
+		}
+	}
+
+	setVersionETag(c, version)
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "resource was modified by another request; reload it and retry"})
+	return false
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// defaultPreviewLifetime is used when a preview token request omits expires_in_hours
+const defaultPreviewLifetime = 7 * 24 * time.Hour
+
+// CreatePreviewTokenRequest represents the request body for minting a preview link
+type CreatePreviewTokenRequest struct {
+	ExpiresInHours int `json:"expires_in_hours"` // Defaults to 168 (7 days), max 720 (30 days)
+}
+
+// CreatePreviewToken handles POST /api/admin/posts/:id/preview-tokens (admin only)
+// @Summary		Create a preview link
+// @Description	Mint an expiring, revocable, signed preview link for an unpublished post (admin only)
+// @Tags			Posts
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"Post ID (UUID)"
+// @Param			request	body		CreatePreviewTokenRequest	false	"Preview link options"
+// @Success		201		{object}	view.PreviewTokenResponse	"Preview link created"
+// @Failure		400		{object}	map[string]string			"Invalid request"
+// @Failure		401		{object}	map[string]string			"Unauthorized"
+// @Failure		403		{object}	map[string]string			"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string			"Post not found"
+// @Router			/api/admin/posts/{id}/preview-tokens [post]
+// @Security		BearerAuth
+func (h *PostHandler) CreatePreviewToken(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	var req CreatePreviewTokenRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+
+	lifetime := defaultPreviewLifetime
+	if req.ExpiresInHours < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_in_hours must be positive"})
+		return
+	}
+	if req.ExpiresInHours > 0 {
+		lifetime = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	post, err := h.postRepo.GetByID(c, id)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	token := &model.PreviewToken{
+		PostID:    post.ID,
+		ExpiresAt: time.Now().UTC().Add(lifetime),
+	}
+
+	if err := h.previewRepo.Create(c, token); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.toPreviewTokenResponse(post, token))
+}
+
+// ListPreviewTokens handles GET /api/admin/posts/:id/preview-tokens (admin only)
+// @Summary		List preview links
+// @Description	List all preview links minted for a post, including expired and revoked ones (admin only)
+// @Tags			Posts
+// @Produce		json
+// @Param			id	path		string	true	"Post ID (UUID)"
+// @Success		200	{array}		view.PreviewTokenResponse	"List of preview links"
+// @Failure		400	{object}	map[string]string			"Invalid request"
+// @Failure		403	{object}	map[string]string			"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string			"Post not found"
+// @Router			/api/admin/posts/{id}/preview-tokens [get]
+// @Security		BearerAuth
+func (h *PostHandler) ListPreviewTokens(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	post, err := h.postRepo.GetByID(c, id)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	tokens, err := h.previewRepo.ListByPost(c, post.ID)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list preview tokens"})
+		return
+	}
+
+	responses := make([]view.PreviewTokenResponse, len(tokens))
+	for i := range tokens {
+		responses[i] = *h.toPreviewTokenResponse(post, &tokens[i])
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// RevokePreviewToken handles DELETE /api/admin/posts/:id/preview-tokens/:token_id (admin only)
+// @Summary		Revoke a preview link
+// @Description	Revoke a preview link so it stops granting access (admin only)
+// @Tags			Posts
+// @Param			id			path	string	true	"Post ID (UUID)"
+// @Param			token_id	path	string	true	"Preview token ID (UUID)"
+// @Success		204				"Preview link revoked"
+// @Failure		400	{object}	map[string]string	"Invalid request"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string	"Preview token not found"
+// @Router			/api/admin/posts/{id}/preview-tokens/{token_id} [delete]
+// @Security		BearerAuth
+func (h *PostHandler) RevokePreviewToken(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	tokenID, err := uuid.Parse(c.Param("token_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid token_id format"})
+		return
+	}
+
+	if err := h.previewRepo.Revoke(c, postID, tokenID); err != nil {
+		status, message := statusCodeFromError(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// hasValidPreview reports whether the request carries an active preview token for the post
+func (h *PostHandler) hasValidPreview(c *gin.Context, post *model.Post, now time.Time) bool {
+	token := c.Query("preview")
+	if token == "" {
+		return false
+	}
+
+	claims, err := h.previewSigner.Verify(token, now)
+	if err != nil || claims.PostID != post.ID {
+		return false
+	}
+
+	// The database record is authoritative for revocation
+	stored, err := h.previewRepo.GetByID(c, claims.TokenID)
+	if err != nil {
+		return false
+	}
+
+	return stored.PostID == post.ID && stored.IsActive(now)
+}
+
+// toPreviewTokenResponse signs a stored preview token and builds its shareable link
+func (h *PostHandler) toPreviewTokenResponse(post *model.Post, token *model.PreviewToken) *view.PreviewTokenResponse {
+	signed := h.previewSigner.Sign(auth.PreviewClaims{
+		TokenID:   token.ID,
+		PostID:    token.PostID,
+		ExpiresAt: token.ExpiresAt,
+	})
+	previewURL := fmt.Sprintf("/api/posts/%s?preview=%s", post.Slug, url.QueryEscape(signed))
+
+	return view.ToPreviewTokenResponse(token, signed, previewURL)
+}
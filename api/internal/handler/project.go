@@ -0,0 +1,369 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// ProjectHandler handles project-related HTTP requests
+type ProjectHandler struct {
+	projectRepo *model.ProjectRepository
+	tagRepo     *model.TagRepository
+}
+
+// NewProjectHandler creates a new project handler
+func NewProjectHandler(projectRepo *model.ProjectRepository, tagRepo *model.TagRepository) *ProjectHandler {
+	return &ProjectHandler{
+		projectRepo: projectRepo,
+		tagRepo:     tagRepo,
+	}
+}
+
+// ListProjects handles GET /api/projects (public)
+// @Summary		List published projects
+// @Description	List published projects by position, then most recently published. Use featured=true for the home page.
+// @Tags			Projects
+// @Produce		json
+// @Param			tag			query		string					false	"Only projects with this tag; aliases resolve to their canonical tag"
+// @Param			featured	query		bool					false	"Only featured projects"
+// @Success		200			{array}		view.ProjectResponse	"List of projects"
+// @Failure		400			{object}	map[string]string		"Invalid query"
+// @Failure		500			{object}	map[string]string		"Internal server error"
+// @Router			/api/projects [get]
+func (h *ProjectHandler) ListProjects(c *gin.Context) {
+	filter := model.ProjectFilter{PublishedOnly: true}
+
+	if featured := c.Query("featured"); featured != "" {
+		var err error
+		if filter.FeaturedOnly, err = strconv.ParseBool(featured); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "featured must be true or false"})
+			return
+		}
+	}
+
+	tag, err := h.tagRepo.Resolve(c, c.Query("tag"))
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve tag"})
+		return
+	}
+	filter.Tag = tag
+
+	projects, err := h.projectRepo.List(c, filter)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list projects"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToProjectResponses(projects))
+}
+
+// GetProject handles GET /api/projects/:slug (public; drafts visible to admins)
+// @Summary		Get a project by slug
+// @Description	Get a published project's case study; admins may also read drafts
+// @Tags			Projects
+// @Produce		json
+// @Param			slug	path		string					true	"Project slug"
+// @Success		200		{object}	view.ProjectResponse	"Project found"
+// @Failure		404		{object}	map[string]string		"Project not found"
+// @Router			/api/projects/{slug} [get]
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	project, err := h.projectRepo.GetBySlug(c, c.Param("slug"))
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	if !project.IsPublic() {
+		if !isAdminRequest(c) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+			return
+		}
+		c.Header("X-Robots-Tag", "noindex")
+		c.Header("Cache-Control", "private, no-store")
+	}
+
+	setLastModified(c, project.UpdatedAt)
+	c.JSON(http.StatusOK, view.ToProjectResponse(project))
+}
+
+// ListAllProjects handles GET /api/admin/projects (admin only)
+// @Summary		List all projects
+// @Description	List every project, drafts included, in display order (admin only)
+// @Tags			Projects
+// @Produce		json
+// @Success		200	{array}		view.ProjectResponse	"List of projects"
+// @Failure		401	{object}	map[string]string		"Unauthorized"
+// @Failure		403	{object}	map[string]string		"Forbidden - admin role required"
+// @Router			/api/admin/projects [get]
+// @Security		BearerAuth
+func (h *ProjectHandler) ListAllProjects(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	projects, err := h.projectRepo.List(c, model.ProjectFilter{})
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list projects"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToProjectResponses(projects))
+}
+
+// ProjectLinkRequest represents a link in a project request
+type ProjectLinkRequest struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// ProjectRequest represents the request body for creating or updating a project
+type ProjectRequest struct {
+	Slug     string               `json:"slug" binding:"required"`
+	Title    string               `json:"title" binding:"required"`
+	Summary  string               `json:"summary"` // Required to publish
+	Problem  string               `json:"problem"`
+	Role     string               `json:"role"`
+	Stack    []string             `json:"stack"`
+	Outcomes []string             `json:"outcomes"`
+	Links    []ProjectLinkRequest `json:"links"`
+	Tags     []string             `json:"tags"`
+	Status   string               `json:"status"` // draft (default) or published
+	Featured bool                 `json:"is_featured"`
+	Position int                  `json:"position"`
+}
+
+// apply copies the request's fields onto project, canonicalizing its tags
+func (req *ProjectRequest) apply(c *gin.Context, tagRepo *model.TagRepository, project *model.Project) error {
+	tags, err := tagRepo.Canonicalize(c, req.Tags)
+	if err != nil {
+		return err
+	}
+
+	links := make([]model.ProjectLink, len(req.Links))
+	for i, link := range req.Links {
+		links[i] = model.ProjectLink{Label: link.Label, URL: link.URL}
+	}
+
+	status := model.ProjectStatus(req.Status)
+	if status == "" {
+		status = model.ProjectStatusDraft
+	}
+
+	project.Slug = req.Slug
+	project.Title = req.Title
+	project.Summary = req.Summary
+	project.Problem = req.Problem
+	project.Role = req.Role
+	project.Stack = req.Stack
+	project.Outcomes = req.Outcomes
+	project.Links = links
+	project.Tags = tags
+	project.Status = status
+	project.Featured = req.Featured
+	project.Position = req.Position
+	return nil
+}
+
+// CreateProject handles POST /api/admin/projects (admin only)
+// @Summary		Create a project
+// @Description	Create a project case study (admin only). Publishing it records published_at.
+// @Tags			Projects
+// @Accept			json
+// @Produce		json
+// @Param			request	body		ProjectRequest			true	"Project request body"
+// @Success		201		{object}	view.ProjectResponse	"Project created successfully"
+// @Failure		400		{object}	map[string]string		"Invalid request body"
+// @Failure		401		{object}	map[string]string		"Unauthorized"
+// @Failure		403		{object}	map[string]string		"Forbidden - admin role required"
+// @Failure		409		{object}	map[string]string		"Slug already in use"
+// @Router			/api/admin/projects [post]
+// @Security		BearerAuth
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	var req ProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	project := &model.Project{}
+	if err := req.apply(c, h.tagRepo, project); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve tags"})
+		return
+	}
+
+	if err := h.projectRepo.Create(c, project); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, view.ToProjectResponse(project))
+}
+
+// UpdateProject handles PUT /api/admin/projects/:id (admin only)
+// @Summary		Update a project
+// @Description	Replace a project's content (admin only). published_at is kept once set, even if the project returns to draft.
+// @Tags			Projects
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string					true	"Project ID (UUID)"
+// @Param			request	body		ProjectRequest			true	"Updated project data"
+// @Success		200		{object}	view.ProjectResponse	"Project updated successfully"
+// @Failure		400		{object}	map[string]string		"Invalid request"
+// @Failure		401		{object}	map[string]string		"Unauthorized"
+// @Failure		403		{object}	map[string]string		"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string		"Project not found"
+// @Failure		409		{object}	map[string]string		"Slug already in use"
+// @Router			/api/admin/projects/{id} [put]
+// @Security		BearerAuth
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	var req ProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	project, err := h.projectRepo.GetByID(c, id)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	if err := req.apply(c, h.tagRepo, project); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve tags"})
+		return
+	}
+
+	if err := h.projectRepo.Update(c, project); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToProjectResponse(project))
+}
+
+// ReorderProjectsRequest represents the request body for reordering projects
+type ReorderProjectsRequest struct {
+	ProjectIDs []uuid.UUID `json:"project_ids"`
+}
+
+// ReorderProjects handles PUT /api/admin/projects/order (admin only)
+// @Summary		Reorder projects
+// @Description	Give the listed projects positions 1, 2, 3... in the order given; projects left out keep their positions (admin only)
+// @Tags			Projects
+// @Accept			json
+// @Produce		json
+// @Param			request	body		ReorderProjectsRequest	true	"Project IDs in display order"
+// @Success		200		{array}		view.ProjectResponse	"All projects in their new order"
+// @Failure		400		{object}	map[string]string		"Invalid request"
+// @Failure		401		{object}	map[string]string		"Unauthorized"
+// @Failure		403		{object}	map[string]string		"Forbidden - admin role required"
+// @Router			/api/admin/projects/order [put]
+// @Security		BearerAuth
+func (h *ProjectHandler) ReorderProjects(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	var req ReorderProjectsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.projectRepo.Reorder(c, req.ProjectIDs); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	h.ListAllProjects(c)
+}
+
+// DeleteProject handles DELETE /api/admin/projects/:id (admin only)
+// @Summary		Delete a project
+// @Description	Permanently delete a project (admin only)
+// @Tags			Projects
+// @Param			id	path	string	true	"Project ID (UUID)"
+// @Success		204			"Project deleted successfully"
+// @Failure		400	{object}	map[string]string	"Invalid request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string	"Project not found"
+// @Router			/api/admin/projects/{id} [delete]
+// @Security		BearerAuth
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	if err := h.projectRepo.Delete(c, id); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
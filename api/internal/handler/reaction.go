@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// botUserAgentMarkers are case-insensitive User-Agent fragments of crawlers and scripted clients
+var botUserAgentMarkers = []string{
+	"bot", "crawl", "spider", "slurp", "headless", "curl", "wget",
+	"python-", "go-http-client", "java/", "okhttp", "libwww", "httpclient",
+}
+
+// ReactionHandler handles post reaction HTTP requests
+type ReactionHandler struct {
+	postRepo     *model.PostRepository
+	reactionRepo *model.ReactionRepository
+}
+
+// NewReactionHandler creates a new reaction handler
+func NewReactionHandler(postRepo *model.PostRepository, reactionRepo *model.ReactionRepository) *ReactionHandler {
+	return &ReactionHandler{
+		postRepo:     postRepo,
+		reactionRepo: reactionRepo,
+	}
+}
+
+// AddReactionRequest represents the request body for reacting to a post
+type AddReactionRequest struct {
+	Emoji    string `json:"emoji" binding:"required"`
+	Honeypot string `json:"honeypot,omitempty"` // Anti-spam field - should be empty
+}
+
+// AddReaction handles POST /api/posts/:slug/reactions (public)
+// @Summary		React to a blog post
+// @Description	Leave an emoji reaction on a published post. Each visitor counts once per emoji per day; visitors are
+// @Description	identified by a salted hash that rotates daily, and no IP addresses are stored. Rate limited to 30 reactions
+// @Description	per address per day, whatever the user agent. Requests that look automated are accepted but not counted.
+// @Tags			Posts
+// @Accept			json
+// @Produce		json
+// @Param			slug	path		string				true	"Post slug"
+// @Param			request	body		AddReactionRequest	true	"Reaction request body"
+// @Success		201		{object}	view.ReactionResponse	"Reaction recorded"
+// @Success		200		{object}	view.ReactionResponse	"Visitor already left this reaction today"
+// @Failure		400		{object}	map[string]string	"Invalid request body or emoji"
+// @Failure		404		{object}	map[string]string	"Post not found"
+// @Failure		410		{object}	map[string]string	"Post has been deleted"
+// @Failure		429		{object}	map[string]string	"Rate limit exceeded"
+// @Router			/api/posts/{slug}/reactions [post]
+func (h *ReactionHandler) AddReaction(c *gin.Context) {
+	var req AddReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if !model.IsReactionEmoji(req.Emoji) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "emoji must be one of " + strings.Join(model.ReactionEmojis, " ")})
+		return
+	}
+
+	now := time.Now().UTC()
+
+	post, err := h.postRepo.GetBySlug(c, c.Param("slug"))
+	if err == nil && post.IsTrashed() {
+		respondPostGone(c)
+		return
+	}
+	if err != nil || !post.IsPublic(now) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+		return
+	}
+
+	// Silently treat likely bots as success without counting them, to not reveal the heuristics
+	if req.Honeypot != "" || looksAutomated(c.Request) {
+		h.respond(c, post, req.Emoji, http.StatusCreated)
+		return
+	}
+
+	salt, err := h.reactionRepo.DailySalt(c, now)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record reaction"})
+		return
+	}
+	// The user agent only separates visitors for dedup; the daily budget belongs to the address
+	visitor := model.VisitorHash(salt, c.ClientIP(), c.Request.UserAgent())
+	sender := model.SenderHash(salt, c.ClientIP())
+
+	added, err := h.reactionRepo.Add(c, post.ID, req.Emoji, visitor, sender, now)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	status := http.StatusOK
+	if added {
+		status = http.StatusCreated
+	}
+	h.respond(c, post, req.Emoji, status)
+}
+
+// respond writes the post's current reaction counts
+func (h *ReactionHandler) respond(c *gin.Context, post *model.Post, emoji string, status int) {
+	counts, err := h.reactionRepo.Counts(c, []uuid.UUID{post.ID})
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load reactions"})
+		return
+	}
+
+	c.JSON(status, view.ReactionResponse{
+		Emoji:     emoji,
+		Reactions: counts[post.ID],
+	})
+}
+
+// looksAutomated applies cheap heuristics for requests that did not come from a reader's browser:
+// no User-Agent, a crawler or HTTP library User-Agent, or no Accept-Language, which browsers always send
+func looksAutomated(r *http.Request) bool {
+	userAgent := strings.ToLower(r.UserAgent())
+	if strings.TrimSpace(userAgent) == "" {
+		return true
+	}
+
+	for _, marker := range botUserAgentMarkers {
+		if strings.Contains(userAgent, marker) {
+			return true
+		}
+	}
+
+	return r.Header.Get("Accept-Language") == ""
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// RelatedPostHandler handles related-post recommendation requests
+type RelatedPostHandler struct {
+	postRepo    *model.PostRepository
+	relatedRepo *model.RelatedPostRepository
+	options     model.RelatedOptions
+}
+
+// NewRelatedPostHandler creates a new related post handler
+func NewRelatedPostHandler(postRepo *model.PostRepository, relatedRepo *model.RelatedPostRepository, options model.RelatedOptions) *RelatedPostHandler {
+	if options.Limit <= 0 {
+		options.Limit = model.DefaultRelatedLimit
+	}
+	return &RelatedPostHandler{
+		postRepo:    postRepo,
+		relatedRepo: relatedRepo,
+		options:     options,
+	}
+}
+
+// ListRelatedPosts handles GET /api/posts/:slug/related (public)
+// @Summary		List related posts
+// @Description	List published posts related to a post, ranked by tag overlap and TF-IDF similarity of title and body, optionally weighted towards recent posts.
+// @Description	Results come from the stored index, which the scheduler and post status events rebuild.
+// @Tags			Posts
+// @Produce		json
+// @Param			slug	path		string	true	"Post slug"
+// @Param			limit	query		integer	false	"Number of related posts (default: configured count, max: 20)"
+// @Success		200		{array}		view.RelatedPostResponse	"Related posts, best match first"
+// @Failure		404		{object}	map[string]string			"Post not found"
+// @Failure		410		{object}	map[string]string			"Post has been deleted"
+// @Router			/api/posts/{slug}/related [get]
+func (h *RelatedPostHandler) ListRelatedPosts(c *gin.Context) {
+	now := time.Now().UTC()
+
+	post, err := h.postRepo.GetBySlug(c, c.Param("slug"))
+	if err == nil && post.IsTrashed() {
+		respondPostGone(c)
+		return
+	}
+	if err != nil || (!post.IsPublic(now) && !isAdminRequest(c)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+		return
+	}
+
+	options := h.options
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= model.MaxRelatedLimit {
+			options.Limit = parsed
+		}
+	}
+
+	related, err := h.relatedRepo.List(c, post.ID, now, options)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list related posts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToRelatedPostResponses(related))
+}
@@ -0,0 +1,494 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/resume"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// ResumeHandler handles resume-related HTTP requests
+type ResumeHandler struct {
+	resumeRepo *model.ResumeRepository
+}
+
+// NewResumeHandler creates a new resume handler
+func NewResumeHandler(resumeRepo *model.ResumeRepository) *ResumeHandler {
+	return &ResumeHandler{
+		resumeRepo: resumeRepo,
+	}
+}
+
+// ListResumes handles GET /api/resumes (public)
+// @Summary		List resume variants
+// @Description	List all resume variants ordered by name, without their sections
+// @Tags			Resumes
+// @Produce		json
+// @Success		200	{array}		view.ResumeVariantResponse	"List of resume variants"
+// @Failure		500	{object}	map[string]string			"Internal server error"
+// @Router			/api/resumes [get]
+func (h *ResumeHandler) ListResumes(c *gin.Context) {
+	variants, err := h.resumeRepo.ListVariants(c)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list resumes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToResumeVariantResponses(variants))
+}
+
+// GetResume handles GET /api/resumes/:slug (public)
+// @Summary		Get a resume variant
+// @Description	Get a resume variant and its included sections in display order
+// @Tags			Resumes
+// @Produce		json
+// @Param			slug	path		string						true	"Resume variant slug"
+// @Success		200		{object}	view.ResumeVariantResponse	"Resume found"
+// @Failure		404		{object}	map[string]string			"Resume not found"
+// @Router			/api/resumes/{slug} [get]
+func (h *ResumeHandler) GetResume(c *gin.Context) {
+	variant, err := h.resumeRepo.GetVariantBySlug(c, c.Param("slug"))
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	setLastModified(c, variant.UpdatedAt)
+	c.JSON(http.StatusOK, view.ToResumeVariantResponse(variant))
+}
+
+// GetResumePDF handles GET /api/resumes/:slug/pdf (public)
+// @Summary		Download a resume variant as PDF
+// @Description	Render a resume variant and its included sections as a US Letter PDF
+// @Tags			Resumes
+// @Produce		application/pdf
+// @Param			slug	path		string				true	"Resume variant slug"
+// @Success		200		{file}		file				"Resume PDF"
+// @Failure		404		{object}	map[string]string	"Resume not found"
+// @Router			/api/resumes/{slug}/pdf [get]
+func (h *ResumeHandler) GetResumePDF(c *gin.Context) {
+	variant, err := h.resumeRepo.GetVariantBySlug(c, c.Param("slug"))
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	document, err := resume.Render(variant)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to render resume"})
+		return
+	}
+
+	setLastModified(c, variant.UpdatedAt)
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s-resume.pdf"`, variant.Slug))
+	c.Data(http.StatusOK, "application/pdf", document)
+}
+
+// ResumeVariantRequest represents the request body for creating or updating a resume variant
+type ResumeVariantRequest struct {
+	Slug     string `json:"slug" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Headline string `json:"headline"`
+	Summary  string `json:"summary"`
+}
+
+// CreateResumeVariant handles POST /api/admin/resume/variants (admin only)
+// @Summary		Create a resume variant
+// @Description	Create a resume variant without sections (admin only)
+// @Tags			Resumes
+// @Accept			json
+// @Produce		json
+// @Param			request	body		ResumeVariantRequest		true	"Resume variant request body"
+// @Success		201		{object}	view.ResumeVariantResponse	"Resume variant created successfully"
+// @Failure		400		{object}	map[string]string			"Invalid request body"
+// @Failure		401		{object}	map[string]string			"Unauthorized"
+// @Failure		403		{object}	map[string]string			"Forbidden - admin role required"
+// @Failure		409		{object}	map[string]string			"Slug already in use"
+// @Router			/api/admin/resume/variants [post]
+// @Security		BearerAuth
+func (h *ResumeHandler) CreateResumeVariant(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	var req ResumeVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	variant := &model.ResumeVariant{
+		Slug:     req.Slug,
+		Name:     req.Name,
+		Headline: req.Headline,
+		Summary:  req.Summary,
+	}
+
+	if err := h.resumeRepo.CreateVariant(c, variant); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, view.ToResumeVariantResponse(variant))
+}
+
+// UpdateResumeVariant handles PUT /api/admin/resume/variants/:id (admin only)
+// @Summary		Update a resume variant
+// @Description	Update a resume variant's slug, name, headline and summary (admin only)
+// @Tags			Resumes
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"Resume variant ID (UUID)"
+// @Param			request	body		ResumeVariantRequest		true	"Updated resume variant data"
+// @Success		200		{object}	view.ResumeVariantResponse	"Resume variant updated successfully"
+// @Failure		400		{object}	map[string]string			"Invalid request"
+// @Failure		401		{object}	map[string]string			"Unauthorized"
+// @Failure		403		{object}	map[string]string			"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string			"Resume variant not found"
+// @Failure		409		{object}	map[string]string			"Slug already in use"
+// @Router			/api/admin/resume/variants/{id} [put]
+// @Security		BearerAuth
+func (h *ResumeHandler) UpdateResumeVariant(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	var req ResumeVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	variant := &model.ResumeVariant{
+		ID:       id,
+		Slug:     req.Slug,
+		Name:     req.Name,
+		Headline: req.Headline,
+		Summary:  req.Summary,
+	}
+
+	if err := h.resumeRepo.UpdateVariant(c, variant); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	h.respondWithVariant(c, id, http.StatusOK)
+}
+
+// SetResumeSectionsRequest represents the request body for choosing a resume variant's sections
+type SetResumeSectionsRequest struct {
+	SectionIDs []uuid.UUID `json:"section_ids"`
+}
+
+// SetResumeVariantSections handles PUT /api/admin/resume/variants/:id/sections (admin only)
+// @Summary		Set a resume variant's sections
+// @Description	Replace the sections a resume variant includes with the given list, in display order (admin only).
+// @Description	A heading is shown whenever the kind of section changes, so group sections of a kind together.
+// @Tags			Resumes
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"Resume variant ID (UUID)"
+// @Param			request	body		SetResumeSectionsRequest	true	"Ordered section IDs"
+// @Success		200		{object}	view.ResumeVariantResponse	"Resume variant sections updated successfully"
+// @Failure		400		{object}	map[string]string			"Invalid request"
+// @Failure		401		{object}	map[string]string			"Unauthorized"
+// @Failure		403		{object}	map[string]string			"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string			"Resume variant not found"
+// @Router			/api/admin/resume/variants/{id}/sections [put]
+// @Security		BearerAuth
+func (h *ResumeHandler) SetResumeVariantSections(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	var req SetResumeSectionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.resumeRepo.SetVariantSections(c, id, req.SectionIDs); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	h.respondWithVariant(c, id, http.StatusOK)
+}
+
+// DeleteResumeVariant handles DELETE /api/admin/resume/variants/:id (admin only)
+// @Summary		Delete a resume variant
+// @Description	Delete a resume variant; its sections are kept for other variants (admin only)
+// @Tags			Resumes
+// @Param			id	path	string	true	"Resume variant ID (UUID)"
+// @Success		204			"Resume variant deleted successfully"
+// @Failure		400	{object}	map[string]string	"Invalid request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string	"Resume variant not found"
+// @Router			/api/admin/resume/variants/{id} [delete]
+// @Security		BearerAuth
+func (h *ResumeHandler) DeleteResumeVariant(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	if err := h.resumeRepo.DeleteVariant(c, id); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResumeSectionRequest represents the request body for creating or updating a resume section
+type ResumeSectionRequest struct {
+	Kind         string   `json:"kind" binding:"required"` // experience, skills or education
+	Title        string   `json:"title" binding:"required"`
+	Organization string   `json:"organization"`
+	Location     string   `json:"location"`
+	StartDate    string   `json:"start_date"` // YYYY-MM
+	EndDate      string   `json:"end_date"`   // YYYY-MM, empty while ongoing
+	Summary      string   `json:"summary"`
+	Highlights   []string `json:"highlights"`
+}
+
+// toSection builds a section model from the request
+func (req ResumeSectionRequest) toSection(id uuid.UUID) *model.ResumeSection {
+	return &model.ResumeSection{
+		ID:           id,
+		Kind:         model.ResumeSectionKind(req.Kind),
+		Title:        req.Title,
+		Organization: req.Organization,
+		Location:     req.Location,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		Summary:      req.Summary,
+		Highlights:   req.Highlights,
+	}
+}
+
+// ListResumeSections handles GET /api/admin/resume/sections (admin only)
+// @Summary		List resume sections
+// @Description	List every resume section, whichever variants include it, grouped by kind (admin only)
+// @Tags			Resumes
+// @Produce		json
+// @Success		200	{array}		view.ResumeSectionResponse	"List of resume sections"
+// @Failure		401	{object}	map[string]string			"Unauthorized"
+// @Failure		403	{object}	map[string]string			"Forbidden - admin role required"
+// @Router			/api/admin/resume/sections [get]
+// @Security		BearerAuth
+func (h *ResumeHandler) ListResumeSections(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	sections, err := h.resumeRepo.ListSections(c)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list resume sections"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToResumeSectionResponses(sections))
+}
+
+// CreateResumeSection handles POST /api/admin/resume/sections (admin only)
+// @Summary		Create a resume section
+// @Description	Create an experience, skills or education section; add it to variants to show it (admin only)
+// @Tags			Resumes
+// @Accept			json
+// @Produce		json
+// @Param			request	body		ResumeSectionRequest		true	"Resume section request body"
+// @Success		201		{object}	view.ResumeSectionResponse	"Resume section created successfully"
+// @Failure		400		{object}	map[string]string			"Invalid request body"
+// @Failure		401		{object}	map[string]string			"Unauthorized"
+// @Failure		403		{object}	map[string]string			"Forbidden - admin role required"
+// @Router			/api/admin/resume/sections [post]
+// @Security		BearerAuth
+func (h *ResumeHandler) CreateResumeSection(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	var req ResumeSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	section := req.toSection(uuid.Nil)
+	if err := h.resumeRepo.CreateSection(c, section); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, view.ToResumeSectionResponse(section))
+}
+
+// UpdateResumeSection handles PUT /api/admin/resume/sections/:id (admin only)
+// @Summary		Update a resume section
+// @Description	Replace a resume section's content; every variant including it changes with it (admin only)
+// @Tags			Resumes
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"Resume section ID (UUID)"
+// @Param			request	body		ResumeSectionRequest		true	"Updated resume section data"
+// @Success		200		{object}	view.ResumeSectionResponse	"Resume section updated successfully"
+// @Failure		400		{object}	map[string]string			"Invalid request"
+// @Failure		401		{object}	map[string]string			"Unauthorized"
+// @Failure		403		{object}	map[string]string			"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string			"Resume section not found"
+// @Router			/api/admin/resume/sections/{id} [put]
+// @Security		BearerAuth
+func (h *ResumeHandler) UpdateResumeSection(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	var req ResumeSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	section := req.toSection(id)
+	if err := h.resumeRepo.UpdateSection(c, section); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	updated, err := h.resumeRepo.GetSection(c, id)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get resume section"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToResumeSectionResponse(updated))
+}
+
+// DeleteResumeSection handles DELETE /api/admin/resume/sections/:id (admin only)
+// @Summary		Delete a resume section
+// @Description	Delete a resume section and remove it from every variant (admin only)
+// @Tags			Resumes
+// @Param			id	path	string	true	"Resume section ID (UUID)"
+// @Success		204			"Resume section deleted successfully"
+// @Failure		400	{object}	map[string]string	"Invalid request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string	"Resume section not found"
+// @Router			/api/admin/resume/sections/{id} [delete]
+// @Security		BearerAuth
+func (h *ResumeHandler) DeleteResumeSection(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	if err := h.resumeRepo.DeleteSection(c, id); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondWithVariant writes the variant with its sections
+func (h *ResumeHandler) respondWithVariant(c *gin.Context, id uuid.UUID, status int) {
+	variant, err := h.resumeRepo.GetVariantByID(c, id)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get resume variant"})
+		return
+	}
+
+	c.JSON(status, view.ToResumeVariantResponse(variant))
+}
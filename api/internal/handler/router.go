@@ -9,19 +9,44 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/content"
+	"github.com/sochoa/sochoa.dev/api/internal/events"
+	"github.com/sochoa/sochoa.dev/api/internal/mail"
+	"github.com/sochoa/sochoa.dev/api/internal/media"
 	"github.com/sochoa/sochoa.dev/api/internal/middleware"
 	"github.com/sochoa/sochoa.dev/api/internal/model"
 )
 
 // Router sets up all HTTP routes with Gin
 type Router struct {
-	engine           *gin.Engine
-	log              *slog.Logger
-	postHandler      *PostHandler
-	guestbookHandler *GuestbookHandler
-	contactHandler   *ContactHandler
-	statsHandler     *StatsHandler
-	tokenVerifier    auth.TokenVerifier
+	engine            *gin.Engine
+	log               *slog.Logger
+	postHandler       *PostHandler
+	guestbookHandler  *GuestbookHandler
+	contactHandler    *ContactHandler
+	statsHandler      *StatsHandler
+	tagHandler        *TagHandler
+	seriesHandler     *SeriesHandler
+	resumeHandler     *ResumeHandler
+	projectHandler    *ProjectHandler
+	talkHandler       *TalkHandler
+	newsletterHandler *NewsletterHandler
+	sitePageHandler   *SitePageHandler
+	relatedHandler    *RelatedPostHandler
+	reactionHandler   *ReactionHandler
+	mentionHandler    *WebmentionHandler
+	mediaHandler      *MediaHandler
+	exportHandler     *ExportHandler
+	tokenVerifier     auth.TokenVerifier
+	requireIfMatch    bool
+	cachePolicies     CachePolicies
+}
+
+// CachePolicies sets how long shared caches may keep each public read route
+type CachePolicies struct {
+	PostList  middleware.CachePolicy
+	Post      middleware.CachePolicy
+	Guestbook middleware.CachePolicy
 }
 
 // NewRouter creates a new router with all handlers
@@ -32,18 +57,52 @@ func NewRouter(
 	guestbookRepo *model.GuestbookRepository,
 	contactRepo *model.ContactRepository,
 	statsRepo *model.StatsRepository,
+	tagRepo *model.TagRepository,
+	seriesRepo *model.SeriesRepository,
+	relatedRepo *model.RelatedPostRepository,
+	relatedOptions model.RelatedOptions,
+	previewRepo *model.PreviewTokenRepository,
+	previewSigner *auth.PreviewSigner,
+	mediaLibrary *media.Library,
+	reactionRepo *model.ReactionRepository,
+	webmentionRepo *model.WebmentionRepository,
+	resumeRepo *model.ResumeRepository,
+	projectRepo *model.ProjectRepository,
+	talkRepo *model.TalkRepository,
+	newsletterRepo *model.NewsletterRepository,
+	sitePageRepo *model.SitePageRepository,
+	mailer mail.Mailer,
+	mailFrom string,
+	siteURL string,
+	requireIfMatch bool,
+	cachePolicies CachePolicies,
+	eventBus *events.Bus,
 ) *Router {
 	// Create engine without default middleware (we'll add custom ones)
 	engine := gin.New()
 
 	return &Router{
-		engine:           engine,
-		log:              log,
-		postHandler:      NewPostHandler(postRepo),
-		guestbookHandler: NewGuestbookHandler(guestbookRepo),
-		contactHandler:   NewContactHandler(contactRepo),
-		statsHandler:     NewStatsHandler(statsRepo),
-		tokenVerifier:    tokenVerifier,
+		engine:            engine,
+		log:               log,
+		postHandler:       NewPostHandler(postRepo, tagRepo, seriesRepo, previewRepo, previewSigner, reactionRepo, eventBus),
+		guestbookHandler:  NewGuestbookHandler(guestbookRepo),
+		contactHandler:    NewContactHandler(contactRepo),
+		statsHandler:      NewStatsHandler(statsRepo),
+		tagHandler:        NewTagHandler(tagRepo),
+		seriesHandler:     NewSeriesHandler(seriesRepo),
+		resumeHandler:     NewResumeHandler(resumeRepo),
+		projectHandler:    NewProjectHandler(projectRepo, tagRepo),
+		talkHandler:       NewTalkHandler(talkRepo, siteURL),
+		newsletterHandler: NewNewsletterHandler(newsletterRepo, mailer, mailFrom, siteURL),
+		sitePageHandler:   NewSitePageHandler(sitePageRepo),
+		relatedHandler:    NewRelatedPostHandler(postRepo, relatedRepo, relatedOptions),
+		reactionHandler:   NewReactionHandler(postRepo, reactionRepo),
+		mentionHandler:    NewWebmentionHandler(postRepo, webmentionRepo, reactionRepo, siteURL),
+		mediaHandler:      NewMediaHandler(mediaLibrary),
+		exportHandler:     NewExportHandler(content.NewExporter(postRepo, guestbookRepo, mediaLibrary)),
+		tokenVerifier:     tokenVerifier,
+		requireIfMatch:    requireIfMatch,
+		cachePolicies:     cachePolicies,
 	}
 }
 
@@ -72,14 +131,116 @@ func (r *Router) Register() *gin.Engine {
 	r.engine.GET("/api/health", r.healthCheck)
 
 	// Posts endpoints
-	r.engine.GET("/api/posts", r.postHandler.ListPublishedPosts)
-	r.engine.GET("/api/posts/:slug", r.postHandler.GetPost)
+	r.engine.GET("/api/posts", middleware.HTTPCacheGin(r.cachePolicies.PostList), r.postHandler.ListPublishedPosts)
+	r.engine.GET("/api/posts/:slug", middleware.HTTPCacheGin(r.cachePolicies.Post), middleware.OptionalAuthGin(r.tokenVerifier), r.postHandler.GetPost)
+	r.engine.GET("/api/posts/:slug/og.png", middleware.HTTPCacheGin(r.cachePolicies.Post), middleware.OptionalAuthGin(r.tokenVerifier), r.postHandler.GetPostImage)
+	r.engine.GET("/api/posts/:slug/related", middleware.OptionalAuthGin(r.tokenVerifier), r.relatedHandler.ListRelatedPosts)
+	r.engine.POST("/api/posts/:slug/reactions", r.reactionHandler.AddReaction)
+	r.engine.GET("/api/posts/:slug/webmentions", r.mentionHandler.ListPostWebmentions)
 	r.engine.POST("/api/posts", middleware.RequireAuthGin(r.tokenVerifier), r.postHandler.CreatePost)
-	r.engine.PUT("/api/posts/:id", middleware.RequireAuthGin(r.tokenVerifier), r.postHandler.UpdatePost)
+	r.engine.PUT("/api/posts/:id", middleware.RequireAuthGin(r.tokenVerifier), r.ifMatch(), r.postHandler.UpdatePost)
+	r.engine.PATCH("/api/posts/:id", middleware.RequireAuthGin(r.tokenVerifier), r.ifMatch(), r.postHandler.PatchPost)
 	r.engine.DELETE("/api/posts/:id", middleware.RequireAuthGin(r.tokenVerifier), r.postHandler.DeletePost)
 
+	// Post status transition endpoints
+	r.engine.POST("/api/posts/:slug/publish", middleware.RequireAuthGin(r.tokenVerifier), r.ifMatch(), r.postHandler.PublishPost)
+	r.engine.POST("/api/posts/:slug/unpublish", middleware.RequireAuthGin(r.tokenVerifier), r.ifMatch(), r.postHandler.UnpublishPost)
+	r.engine.POST("/api/posts/:slug/archive", middleware.RequireAuthGin(r.tokenVerifier), r.ifMatch(), r.postHandler.ArchivePost)
+
+	// Post preview link endpoints
+	r.engine.POST("/api/admin/posts/:id/preview-tokens", middleware.RequireAuthGin(r.tokenVerifier), r.postHandler.CreatePreviewToken)
+	r.engine.GET("/api/admin/posts/:id/preview-tokens", middleware.RequireAuthGin(r.tokenVerifier), r.postHandler.ListPreviewTokens)
+	r.engine.DELETE("/api/admin/posts/:id/preview-tokens/:token_id", middleware.RequireAuthGin(r.tokenVerifier), r.postHandler.RevokePreviewToken)
+
+	// Post translation endpoints
+	r.engine.GET("/api/admin/posts/:id/translations", middleware.RequireAuthGin(r.tokenVerifier), r.postHandler.ListPostTranslations)
+	r.engine.PUT("/api/admin/posts/:id/translations/:locale", middleware.RequireAuthGin(r.tokenVerifier), r.postHandler.SavePostTranslation)
+	r.engine.DELETE("/api/admin/posts/:id/translations/:locale", middleware.RequireAuthGin(r.tokenVerifier), r.postHandler.DeletePostTranslation)
+
+	// Post trash endpoints
+	r.engine.GET("/api/admin/trash/posts", middleware.RequireAuthGin(r.tokenVerifier), r.postHandler.ListTrashedPosts)
+	r.engine.POST("/api/admin/trash/posts/:id/restore", middleware.RequireAuthGin(r.tokenVerifier), r.postHandler.RestorePost)
+	r.engine.DELETE("/api/admin/trash/posts/:id", middleware.RequireAuthGin(r.tokenVerifier), r.postHandler.PurgePost)
+
+	// Webmention endpoints
+	r.engine.POST("/api/webmention", r.mentionHandler.ReceiveWebmention)
+	r.engine.GET("/api/admin/webmentions", middleware.RequireAuthGin(r.tokenVerifier), r.mentionHandler.ListWebmentionsAwaitingModeration)
+	r.engine.POST("/api/admin/webmentions/:id/approve", middleware.RequireAuthGin(r.tokenVerifier), r.mentionHandler.ApproveWebmention)
+	r.engine.DELETE("/api/admin/webmentions/:id", middleware.RequireAuthGin(r.tokenVerifier), r.mentionHandler.DeleteWebmention)
+
+	// Tag endpoints
+	r.engine.GET("/api/tags", r.tagHandler.ListTags)
+	r.engine.GET("/api/tags/:tag", r.tagHandler.GetTag)
+	r.engine.PUT("/api/admin/tags/:tag", middleware.RequireAuthGin(r.tokenVerifier), r.tagHandler.UpdateTag)
+	r.engine.POST("/api/admin/tags/:tag/rename", middleware.RequireAuthGin(r.tokenVerifier), r.tagHandler.RenameTag)
+	r.engine.POST("/api/admin/tags/merge", middleware.RequireAuthGin(r.tokenVerifier), r.tagHandler.MergeTags)
+	r.engine.GET("/api/admin/tag-aliases", middleware.RequireAuthGin(r.tokenVerifier), r.tagHandler.ListTagAliases)
+	r.engine.POST("/api/admin/tag-aliases", middleware.RequireAuthGin(r.tokenVerifier), r.tagHandler.CreateTagAlias)
+	r.engine.DELETE("/api/admin/tag-aliases/:alias", middleware.RequireAuthGin(r.tokenVerifier), r.tagHandler.DeleteTagAlias)
+
+	// Series endpoints
+	r.engine.GET("/api/series", r.seriesHandler.ListSeries)
+	r.engine.GET("/api/series/:slug", r.seriesHandler.GetSeries)
+	r.engine.POST("/api/admin/series", middleware.RequireAuthGin(r.tokenVerifier), r.seriesHandler.CreateSeries)
+	r.engine.PUT("/api/admin/series/:id", middleware.RequireAuthGin(r.tokenVerifier), r.seriesHandler.UpdateSeries)
+	r.engine.DELETE("/api/admin/series/:id", middleware.RequireAuthGin(r.tokenVerifier), r.seriesHandler.DeleteSeries)
+	r.engine.PUT("/api/admin/series/:id/posts", middleware.RequireAuthGin(r.tokenVerifier), r.seriesHandler.SetSeriesPosts)
+
+	// Resume endpoints
+	r.engine.GET("/api/resumes", r.resumeHandler.ListResumes)
+	r.engine.GET("/api/resumes/:slug", r.resumeHandler.GetResume)
+	r.engine.GET("/api/resumes/:slug/pdf", r.resumeHandler.GetResumePDF)
+	r.engine.POST("/api/admin/resume/variants", middleware.RequireAuthGin(r.tokenVerifier), r.resumeHandler.CreateResumeVariant)
+	r.engine.PUT("/api/admin/resume/variants/:id", middleware.RequireAuthGin(r.tokenVerifier), r.resumeHandler.UpdateResumeVariant)
+	r.engine.DELETE("/api/admin/resume/variants/:id", middleware.RequireAuthGin(r.tokenVerifier), r.resumeHandler.DeleteResumeVariant)
+	r.engine.PUT("/api/admin/resume/variants/:id/sections", middleware.RequireAuthGin(r.tokenVerifier), r.resumeHandler.SetResumeVariantSections)
+	r.engine.GET("/api/admin/resume/sections", middleware.RequireAuthGin(r.tokenVerifier), r.resumeHandler.ListResumeSections)
+	r.engine.POST("/api/admin/resume/sections", middleware.RequireAuthGin(r.tokenVerifier), r.resumeHandler.CreateResumeSection)
+	r.engine.PUT("/api/admin/resume/sections/:id", middleware.RequireAuthGin(r.tokenVerifier), r.resumeHandler.UpdateResumeSection)
+	r.engine.DELETE("/api/admin/resume/sections/:id", middleware.RequireAuthGin(r.tokenVerifier), r.resumeHandler.DeleteResumeSection)
+
+	// Project endpoints
+	r.engine.GET("/api/projects", r.projectHandler.ListProjects)
+	r.engine.GET("/api/projects/:slug", middleware.OptionalAuthGin(r.tokenVerifier), r.projectHandler.GetProject)
+	r.engine.GET("/api/admin/projects", middleware.RequireAuthGin(r.tokenVerifier), r.projectHandler.ListAllProjects)
+	r.engine.POST("/api/admin/projects", middleware.RequireAuthGin(r.tokenVerifier), r.projectHandler.CreateProject)
+	r.engine.PUT("/api/admin/projects/order", middleware.RequireAuthGin(r.tokenVerifier), r.projectHandler.ReorderProjects)
+	r.engine.PUT("/api/admin/projects/:id", middleware.RequireAuthGin(r.tokenVerifier), r.projectHandler.UpdateProject)
+	r.engine.DELETE("/api/admin/projects/:id", middleware.RequireAuthGin(r.tokenVerifier), r.projectHandler.DeleteProject)
+
+	// Talk endpoints
+	r.engine.GET("/api/talks", r.talkHandler.ListTalks)
+	r.engine.GET("/api/talks.ics", r.talkHandler.GetCalendar)
+	r.engine.GET("/api/admin/talks", middleware.RequireAuthGin(r.tokenVerifier), r.talkHandler.ListAllTalks)
+	r.engine.POST("/api/admin/talks", middleware.RequireAuthGin(r.tokenVerifier), r.talkHandler.CreateTalk)
+	r.engine.PUT("/api/admin/talks/:id", middleware.RequireAuthGin(r.tokenVerifier), r.talkHandler.UpdateTalk)
+	r.engine.DELETE("/api/admin/talks/:id", middleware.RequireAuthGin(r.tokenVerifier), r.talkHandler.DeleteTalk)
+
+	// Newsletter endpoints
+	r.engine.POST("/api/newsletter/subscribe", r.newsletterHandler.Subscribe)
+	r.engine.POST("/api/newsletter/confirm", r.newsletterHandler.ConfirmSubscription)
+	r.engine.POST("/api/newsletter/unsubscribe", r.newsletterHandler.Unsubscribe)
+	r.engine.GET("/api/admin/newsletter/export", middleware.RequireAuthGin(r.tokenVerifier), r.newsletterHandler.ExportSubscribers)
+
+	// Page endpoints
+	r.engine.GET("/api/pages/*path", middleware.OptionalAuthGin(r.tokenVerifier), r.sitePageHandler.GetPage)
+	r.engine.GET("/api/admin/pages", middleware.RequireAuthGin(r.tokenVerifier), r.sitePageHandler.ListAllPages)
+	r.engine.POST("/api/admin/pages", middleware.RequireAuthGin(r.tokenVerifier), r.sitePageHandler.CreatePage)
+	r.engine.PUT("/api/admin/pages/:id", middleware.RequireAuthGin(r.tokenVerifier), r.sitePageHandler.UpdatePage)
+	r.engine.DELETE("/api/admin/pages/:id", middleware.RequireAuthGin(r.tokenVerifier), r.sitePageHandler.DeletePage)
+
+	// Media endpoints
+	r.engine.GET("/media/*key", r.mediaHandler.requireLibrary, r.mediaHandler.ServeMedia)
+	r.engine.POST("/api/admin/media", middleware.RequireAuthGin(r.tokenVerifier), r.mediaHandler.requireLibrary, r.mediaHandler.UploadMedia)
+	r.engine.GET("/api/admin/media", middleware.RequireAuthGin(r.tokenVerifier), r.mediaHandler.requireLibrary, r.mediaHandler.ListMedia)
+	r.engine.GET("/api/admin/media/:id", middleware.RequireAuthGin(r.tokenVerifier), r.mediaHandler.requireLibrary, r.mediaHandler.GetMedia)
+	r.engine.DELETE("/api/admin/media/:id", middleware.RequireAuthGin(r.tokenVerifier), r.mediaHandler.requireLibrary, r.mediaHandler.DeleteMedia)
+
+	// Export endpoint
+	r.engine.GET("/api/admin/export", middleware.RequireAuthGin(r.tokenVerifier), r.exportHandler.ExportContent)
+
 	// Guestbook endpoints
-	r.engine.GET("/api/guestbook", r.guestbookHandler.ListApprovedGuestbookEntries)
+	r.engine.GET("/api/guestbook", middleware.HTTPCacheGin(r.cachePolicies.Guestbook), r.guestbookHandler.ListApprovedGuestbookEntries)
 	r.engine.POST("/api/guestbook", middleware.RequireAuthGin(r.tokenVerifier), r.guestbookHandler.SubmitGuestbookEntry)
 	r.engine.GET("/api/guestbook/pending", middleware.RequireAuthGin(r.tokenVerifier), r.guestbookHandler.ListPendingGuestbookEntries)
 	r.engine.POST("/api/guestbook/:id/approve", middleware.RequireAuthGin(r.tokenVerifier), r.guestbookHandler.ApproveGuestbookEntry)
@@ -95,11 +256,19 @@ func (r *Router) Register() *gin.Engine {
 	r.engine.GET("/api/stats/:id", middleware.RequireAuthGin(r.tokenVerifier), r.statsHandler.GetStats)
 	r.engine.GET("/api/stats", middleware.RequireAuthGin(r.tokenVerifier), r.statsHandler.ListStatsByDateRange)
 	r.engine.GET("/api/stats/page/:page_path", middleware.RequireAuthGin(r.tokenVerifier), r.statsHandler.ListStatsByPage)
-	r.engine.PUT("/api/stats/:id", middleware.RequireAuthGin(r.tokenVerifier), r.statsHandler.UpdateStats)
+	r.engine.PUT("/api/stats/:id", middleware.RequireAuthGin(r.tokenVerifier), r.ifMatch(), r.statsHandler.UpdateStats)
 
 	return r.engine
 }
 
+// ifMatch guards overwriting writes: in strict mode they must carry If-Match, otherwise the handler checks it when present
+func (r *Router) ifMatch() gin.HandlerFunc {
+	if r.requireIfMatch {
+		return middleware.RequireIfMatchGin()
+	}
+	return func(c *gin.Context) { c.Next() }
+}
+
 // healthCheck handles GET /api/health
 // @Summary		Health check
 // @Description	Check API health status
@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// SeriesHandler handles series-related HTTP requests
+type SeriesHandler struct {
+	seriesRepo *model.SeriesRepository
+}
+
+// NewSeriesHandler creates a new series handler
+func NewSeriesHandler(seriesRepo *model.SeriesRepository) *SeriesHandler {
+	return &SeriesHandler{
+		seriesRepo: seriesRepo,
+	}
+}
+
+// ListSeries handles GET /api/series (public)
+// @Summary		List series
+// @Description	List all post series ordered by title, without their posts
+// @Tags			Series
+// @Produce		json
+// @Success		200	{array}		view.SeriesResponse	"List of series"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Router			/api/series [get]
+func (h *SeriesHandler) ListSeries(c *gin.Context) {
+	list, err := h.seriesRepo.List(c)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToSeriesResponses(list))
+}
+
+// GetSeries handles GET /api/series/:slug (public)
+// @Summary		Get a series by slug
+// @Description	Get a series and its published posts in reading order
+// @Tags			Series
+// @Produce		json
+// @Param			slug	path		string				true	"Series slug"
+// @Success		200		{object}	view.SeriesResponse	"Series found"
+// @Failure		404		{object}	map[string]string	"Series not found"
+// @Router			/api/series/{slug} [get]
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	series, err := h.seriesRepo.GetBySlug(c, c.Param("slug"), time.Now().UTC())
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToSeriesResponse(series))
+}
+
+// SeriesRequest represents the request body for creating or updating a series
+type SeriesRequest struct {
+	Slug        string `json:"slug" binding:"required"`
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+}
+
+// CreateSeries handles POST /api/admin/series (admin only)
+// @Summary		Create a series
+// @Description	Create an empty post series (admin only)
+// @Tags			Series
+// @Accept			json
+// @Produce		json
+// @Param			request	body		SeriesRequest		true	"Series request body"
+// @Success		201		{object}	view.SeriesResponse	"Series created successfully"
+// @Failure		400		{object}	map[string]string	"Invalid request body"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		409		{object}	map[string]string	"Slug already in use"
+// @Router			/api/admin/series [post]
+// @Security		BearerAuth
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	var req SeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	series := &model.Series{
+		Slug:        req.Slug,
+		Title:       req.Title,
+		Description: req.Description,
+	}
+
+	if err := h.seriesRepo.Create(c, series); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, view.ToSeriesResponse(series))
+}
+
+// UpdateSeries handles PUT /api/admin/series/:id (admin only)
+// @Summary		Update a series
+// @Description	Update a series' slug, title and description (admin only)
+// @Tags			Series
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string				true	"Series ID (UUID)"
+// @Param			request	body		SeriesRequest		true	"Updated series data"
+// @Success		200		{object}	view.SeriesResponse	"Series updated successfully"
+// @Failure		400		{object}	map[string]string	"Invalid request"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string	"Series not found"
+// @Router			/api/admin/series/{id} [put]
+// @Security		BearerAuth
+func (h *SeriesHandler) UpdateSeries(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	var req SeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	series := &model.Series{
+		ID:          id,
+		Slug:        req.Slug,
+		Title:       req.Title,
+		Description: req.Description,
+	}
+
+	if err := h.seriesRepo.Update(c, series); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	h.respondWithSeries(c, id, http.StatusOK)
+}
+
+// SetSeriesPostsRequest represents the request body for setting a series' posts
+type SetSeriesPostsRequest struct {
+	PostIDs []uuid.UUID `json:"post_ids"`
+}
+
+// SetSeriesPosts handles PUT /api/admin/series/:id/posts (admin only)
+// @Summary		Set a series' posts
+// @Description	Replace a series' posts with the given list, in reading order; use it to add, remove or reorder members (admin only)
+// @Tags			Series
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string					true	"Series ID (UUID)"
+// @Param			request	body		SetSeriesPostsRequest	true	"Ordered post IDs"
+// @Success		200		{object}	view.SeriesResponse		"Series posts updated successfully"
+// @Failure		400		{object}	map[string]string		"Invalid request"
+// @Failure		401		{object}	map[string]string		"Unauthorized"
+// @Failure		403		{object}	map[string]string		"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string		"Series not found"
+// @Failure		409		{object}	map[string]string		"Post already belongs to another series"
+// @Router			/api/admin/series/{id}/posts [put]
+// @Security		BearerAuth
+func (h *SeriesHandler) SetSeriesPosts(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	var req SetSeriesPostsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.seriesRepo.SetPosts(c, id, req.PostIDs); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	h.respondWithSeries(c, id, http.StatusOK)
+}
+
+// DeleteSeries handles DELETE /api/admin/series/:id (admin only)
+// @Summary		Delete a series
+// @Description	Delete a series; its posts are kept (admin only)
+// @Tags			Series
+// @Param			id	path	string	true	"Series ID (UUID)"
+// @Success		204			"Series deleted successfully"
+// @Failure		400	{object}	map[string]string	"Invalid request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string	"Series not found"
+// @Router			/api/admin/series/{id} [delete]
+// @Security		BearerAuth
+func (h *SeriesHandler) DeleteSeries(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	if err := h.seriesRepo.Delete(c, id); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondWithSeries writes the series with all of its members, as admins see it
+func (h *SeriesHandler) respondWithSeries(c *gin.Context, id uuid.UUID, status int) {
+	series, err := h.seriesRepo.GetByID(c, id)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get series"})
+		return
+	}
+
+	c.JSON(status, view.ToSeriesResponse(series))
+}
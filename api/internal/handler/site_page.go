@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// SitePageHandler handles CMS page HTTP requests
+type SitePageHandler struct {
+	sitePageRepo *model.SitePageRepository
+}
+
+// NewSitePageHandler creates a new CMS page handler
+func NewSitePageHandler(sitePageRepo *model.SitePageRepository) *SitePageHandler {
+	return &SitePageHandler{sitePageRepo: sitePageRepo}
+}
+
+// GetPage handles GET /api/pages/*path (public; drafts visible to admins)
+// @Summary		Get a page by path
+// @Description	Get a published page, such as about or about/values, with links to the published pages directly beneath it.
+// @Description	A page beneath a draft is not public even when published itself; admins may also read drafts
+// @Tags			Pages
+// @Produce		json
+// @Param			path	path		string					true	"Page path, such as about/values"
+// @Success		200		{object}	view.SitePageResponse	"Page found"
+// @Failure		404		{object}	map[string]string		"Page not found"
+// @Router			/api/pages/{path} [get]
+func (h *SitePageHandler) GetPage(c *gin.Context) {
+	page, err := h.sitePageRepo.GetByPath(c, c.Param("path"))
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	// A published page beneath a draft is hidden along with it
+	public := page.IsPublic()
+	if public {
+		hidden, err := h.sitePageRepo.HasDraftAncestor(c, page)
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check parent pages"})
+			return
+		}
+		public = !hidden
+	}
+
+	admin := isAdminRequest(c)
+	if !public {
+		if !admin {
+			c.JSON(http.StatusNotFound, gin.H{"error": "page not found"})
+			return
+		}
+		c.Header("X-Robots-Tag", "noindex")
+		c.Header("Cache-Control", "private, no-store")
+	} else if page.NoIndex {
+		c.Header("X-Robots-Tag", "noindex")
+	}
+
+	children, err := h.sitePageRepo.ListChildren(c, page.Path, !admin)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list child pages"})
+		return
+	}
+
+	response := view.ToSitePageResponse(page)
+	response.Children = view.ToSitePageLinkResponses(children)
+
+	// No Last-Modified: the child links change without touching the page
+	c.JSON(http.StatusOK, response)
+}
+
+// ListAllPages handles GET /api/admin/pages (admin only)
+// @Summary		List all pages
+// @Description	List every page, drafts included, in path order so children follow their parents (admin only)
+// @Tags			Pages
+// @Produce		json
+// @Success		200	{array}		view.SitePageResponse	"List of pages"
+// @Failure		401	{object}	map[string]string		"Unauthorized"
+// @Failure		403	{object}	map[string]string		"Forbidden - admin role required"
+// @Router			/api/admin/pages [get]
+// @Security		BearerAuth
+func (h *SitePageHandler) ListAllPages(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	pages, err := h.sitePageRepo.List(c, false)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list pages"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToSitePageResponses(pages))
+}
+
+// SitePageRequest represents the request body for creating or updating a page
+type SitePageRequest struct {
+	Path           string `json:"path" binding:"required"` // Such as about/values; the parent page must already exist
+	Title          string `json:"title" binding:"required"`
+	Body           string `json:"body" binding:"required"`
+	Status         string `json:"status"` // draft (default) or published
+	SEOTitle       string `json:"seo_title"`
+	SEODescription string `json:"seo_description"`
+	CanonicalURL   string `json:"canonical_url"`
+	NoIndex        bool   `json:"noindex"`
+}
+
+// apply copies the request's fields onto page
+func (req *SitePageRequest) apply(page *model.SitePage) {
+	status := model.SitePageStatus(req.Status)
+	if status == "" {
+		status = model.SitePageStatusDraft
+	}
+
+	page.Path = req.Path
+	page.Title = req.Title
+	page.Body = req.Body
+	page.Status = status
+	page.SEOTitle = req.SEOTitle
+	page.SEODescription = req.SEODescription
+	page.CanonicalURL = req.CanonicalURL
+	page.NoIndex = req.NoIndex
+}
+
+// CreatePage handles POST /api/admin/pages (admin only)
+// @Summary		Create a page
+// @Description	Add a page of site copy beneath an existing parent page, or at the top level (admin only)
+// @Tags			Pages
+// @Accept			json
+// @Produce		json
+// @Param			request	body		SitePageRequest			true	"Page request body"
+// @Success		201		{object}	view.SitePageResponse	"Page created successfully"
+// @Failure		400		{object}	map[string]string		"Invalid request body"
+// @Failure		401		{object}	map[string]string		"Unauthorized"
+// @Failure		403		{object}	map[string]string		"Forbidden - admin role required"
+// @Failure		409		{object}	map[string]string		"Path already in use"
+// @Router			/api/admin/pages [post]
+// @Security		BearerAuth
+func (h *SitePageHandler) CreatePage(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	var req SitePageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	page := &model.SitePage{}
+	req.apply(page)
+
+	if err := h.sitePageRepo.Create(c, page); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, view.ToSitePageResponse(page))
+}
+
+// UpdatePage handles PUT /api/admin/pages/:id (admin only)
+// @Summary		Update a page
+// @Description	Replace a page's content. Changing its path moves the pages beneath it too (admin only)
+// @Tags			Pages
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string					true	"Page ID (UUID)"
+// @Param			request	body		SitePageRequest			true	"Updated page data"
+// @Success		200		{object}	view.SitePageResponse	"Page updated successfully"
+// @Failure		400		{object}	map[string]string		"Invalid request"
+// @Failure		401		{object}	map[string]string		"Unauthorized"
+// @Failure		403		{object}	map[string]string		"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string		"Page not found"
+// @Failure		409		{object}	map[string]string		"Path already in use"
+// @Router			/api/admin/pages/{id} [put]
+// @Security		BearerAuth
+func (h *SitePageHandler) UpdatePage(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	var req SitePageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	// Load the page so the first publication time survives the update
+	page, err := h.sitePageRepo.GetByID(c, id)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+	req.apply(page)
+
+	if err := h.sitePageRepo.Update(c, page); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToSitePageResponse(page))
+}
+
+// DeletePage handles DELETE /api/admin/pages/:id (admin only)
+// @Summary		Delete a page
+// @Description	Permanently delete a page. Pages with child pages cannot be deleted until the children are moved or deleted (admin only)
+// @Tags			Pages
+// @Param			id	path	string	true	"Page ID (UUID)"
+// @Success		204			"Page deleted successfully"
+// @Failure		400	{object}	map[string]string	"Invalid request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string	"Page not found"
+// @Failure		409	{object}	map[string]string	"Page has child pages"
+// @Router			/api/admin/pages/{id} [delete]
+// @Security		BearerAuth
+func (h *SitePageHandler) DeletePage(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	if err := h.sitePageRepo.Delete(c, id); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
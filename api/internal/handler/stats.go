@@ -92,6 +92,7 @@ func (h *StatsHandler) RecordStats(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, stat.Version)
 	c.JSON(http.StatusCreated, view.ToVisitorStatResponse(stat))
 }
 
@@ -133,6 +134,7 @@ func (h *StatsHandler) GetStats(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, stat.Version)
 	c.JSON(http.StatusOK, view.ToVisitorStatResponse(stat))
 }
 
@@ -145,7 +147,10 @@ func (h *StatsHandler) GetStats(c *gin.Context) {
 // @Param			end_date	query		string	true	"End date (YYYY-MM-DD)"
 // @Param			limit		query		integer	false	"Number of records per page (default: 10)"
 // @Param			offset		query		integer	false	"Number of records to skip (default: 0)"
-// @Success		200		{array}		view.VisitorStatResponse	"List of stats records"
+// @Param			cursor		query		string	false	"Opaque cursor from a previous page's next_cursor; switches the response to a paginated envelope"
+// @Param			include_total	query		boolean	false	"Include the total item count"
+// @Param			fields	query		string	false	"Comma-separated response fields to return, e.g. id,slug,title,reading_time_minutes"
+// @Success		200		{array}		view.VisitorStatResponse	"List of stats records (view.PageResponse envelope when cursor is given)"
 // @Failure		400		{object}	map[string]string			"Invalid request"
 // @Failure		403		{object}	map[string]string			"Forbidden - admin role required"
 // @Router			/api/stats [get]
@@ -177,15 +182,30 @@ func (h *StatsHandler) ListStatsByDateRange(c *gin.Context) {
 		return
 	}
 
-	limit, offset := parsePaginationGin(c)
+	page, err := parsePageGin(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
 
-	stats, err := h.statsRepo.ListByDateRange(c, startDate, endDate, limit, offset)
+	stats, next, err := h.statsRepo.ListByDateRange(c, startDate, endDate, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list stats"})
 		return
 	}
 
-	c.JSON(http.StatusOK, view.ToVisitorStatResponses(stats))
+	var total *int
+	if wantsTotal(c) {
+		count, err := h.statsRepo.CountByDateRange(c, startDate, endDate)
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count stats"})
+			return
+		}
+		total = &count
+	}
+
+	writePageGin(c, view.ToVisitorStatResponses(stats), next, total)
 }
 
 // ListStatsByPage handles GET /api/stats/page/:page_path (admin only)
@@ -196,7 +216,10 @@ func (h *StatsHandler) ListStatsByDateRange(c *gin.Context) {
 // @Param			page_path	path		string	true	"Page path (URL path)"
 // @Param			limit		query		integer	false	"Number of records per page (default: 10)"
 // @Param			offset		query		integer	false	"Number of records to skip (default: 0)"
-// @Success		200		{array}		view.VisitorStatResponse	"List of stats for page"
+// @Param			cursor		query		string	false	"Opaque cursor from a previous page's next_cursor; switches the response to a paginated envelope"
+// @Param			include_total	query		boolean	false	"Include the total item count"
+// @Param			fields	query		string	false	"Comma-separated response fields to return, e.g. id,slug,title,reading_time_minutes"
+// @Success		200		{array}		view.VisitorStatResponse	"List of stats for page (view.PageResponse envelope when cursor is given)"
 // @Failure		400		{object}	map[string]string			"Invalid request"
 // @Failure		403		{object}	map[string]string			"Forbidden - admin role required"
 // @Router			/api/stats/page/{page_path} [get]
@@ -214,15 +237,30 @@ func (h *StatsHandler) ListStatsByPage(c *gin.Context) {
 		return
 	}
 
-	limit, offset := parsePaginationGin(c)
+	page, err := parsePageGin(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
 
-	stats, err := h.statsRepo.ListByPage(c, pagePath, limit, offset)
+	stats, next, err := h.statsRepo.ListByPage(c, pagePath, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list stats"})
 		return
 	}
 
-	c.JSON(http.StatusOK, view.ToVisitorStatResponses(stats))
+	var total *int
+	if wantsTotal(c) {
+		count, err := h.statsRepo.CountByPage(c, pagePath)
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count stats"})
+			return
+		}
+		total = &count
+	}
+
+	writePageGin(c, view.ToVisitorStatResponses(stats), next, total)
 }
 
 // UpdateStatsRequest represents the request body for updating stats
@@ -243,11 +281,14 @@ type UpdateStatsRequest struct {
 // @Accept			json
 // @Produce		json
 // @Param			id		path		string				true	"Stats ID (UUID)"
+// @Param			If-Match	header	string				false	"ETag from the last read; required when REQUIRE_IF_MATCH is set"
 // @Param			request	body		UpdateStatsRequest	true	"Updated stats data"
 // @Success		200		{object}	view.VisitorStatResponse	"Stats updated successfully"
 // @Failure		400		{object}	map[string]string			"Invalid request"
 // @Failure		403		{object}	map[string]string			"Forbidden - admin role required"
 // @Failure		404		{object}	map[string]string			"Stats not found"
+// @Failure		412		{object}	map[string]string			"Stats were modified since the If-Match version"
+// @Failure		428		{object}	map[string]string			"If-Match header required"
 // @Router			/api/stats/{id} [put]
 // @Security		BearerAuth
 func (h *StatsHandler) UpdateStats(c *gin.Context) {
@@ -276,6 +317,10 @@ func (h *StatsHandler) UpdateStats(c *gin.Context) {
 		return
 	}
 
+	if !checkIfMatch(c, stat.Version) {
+		return
+	}
+
 	var req UpdateStatsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
@@ -296,5 +341,6 @@ func (h *StatsHandler) UpdateStats(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, stat.Version)
 	c.JSON(http.StatusOK, view.ToVisitorStatResponse(stat))
 }
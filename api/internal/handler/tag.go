@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// TagHandler handles tag-related HTTP requests
+type TagHandler struct {
+	tagRepo *model.TagRepository
+}
+
+// NewTagHandler creates a new tag handler
+func NewTagHandler(tagRepo *model.TagRepository) *TagHandler {
+	return &TagHandler{
+		tagRepo: tagRepo,
+	}
+}
+
+// ListTags handles GET /api/tags (public)
+// @Summary		List tags
+// @Description	List every tag used by a published post or project with its counts, most used on posts first
+// @Tags			Tags
+// @Produce		json
+// @Success		200	{array}		view.TagResponse	"List of tags"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Router			/api/tags [get]
+func (h *TagHandler) ListTags(c *gin.Context) {
+	tags, err := h.tagRepo.ListWithCounts(c, time.Now().UTC())
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list tags"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToTagResponses(tags))
+}
+
+// GetTag handles GET /api/tags/:tag (public)
+// @Summary		Get a tag
+// @Description	Get a tag's description and published post and project counts for its landing page (aliases resolve to the canonical tag)
+// @Tags			Tags
+// @Produce		json
+// @Param			tag	path		string				true	"Tag name or alias"
+// @Success		200	{object}	view.TagResponse	"Tag found"
+// @Failure		404	{object}	map[string]string	"Tag not found"
+// @Router			/api/tags/{tag} [get]
+func (h *TagHandler) GetTag(c *gin.Context) {
+	tag, err := h.tagRepo.Get(c, c.Param("tag"), time.Now().UTC())
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToTagResponse(tag))
+}
+
+// UpdateTagRequest represents the request body for describing a tag
+type UpdateTagRequest struct {
+	Description string `json:"description"`
+}
+
+// UpdateTag handles PUT /api/admin/tags/:tag (admin only)
+// @Summary		Describe a tag
+// @Description	Set the description shown on a tag's landing page (admin only)
+// @Tags			Tags
+// @Accept			json
+// @Produce		json
+// @Param			tag		path		string				true	"Tag name"
+// @Param			request	body		UpdateTagRequest	true	"Tag description"
+// @Success		200		{object}	view.TagResponse	"Tag updated successfully"
+// @Failure		400		{object}	map[string]string	"Invalid request"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Router			/api/admin/tags/{tag} [put]
+// @Security		BearerAuth
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	var req UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	tag := &model.Tag{Name: c.Param("tag"), Description: req.Description}
+	if err := h.tagRepo.SetDescription(c, tag); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	updated, err := h.tagRepo.Get(c, tag.Name, time.Now().UTC())
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get tag"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToTagResponse(updated))
+}
+
+// RenameTagRequest represents the request body for renaming a tag
+type RenameTagRequest struct {
+	To string `json:"to" binding:"required"`
+}
+
+// RenameTag handles POST /api/admin/tags/:tag/rename (admin only)
+// @Summary		Rename a tag
+// @Description	Rename a tag on every post and project in one transaction; the old name becomes an alias (admin only)
+// @Tags			Tags
+// @Accept			json
+// @Produce		json
+// @Param			tag		path		string				true	"Current tag name"
+// @Param			request	body		RenameTagRequest	true	"New tag name"
+// @Success		200		{object}	view.RetagResponse	"Tag renamed successfully"
+// @Failure		400		{object}	map[string]string	"Invalid request"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string	"Tag not found"
+// @Failure		409		{object}	map[string]string	"New name already in use"
+// @Router			/api/admin/tags/{tag}/rename [post]
+// @Security		BearerAuth
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	var req RenameTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	from, to := model.NormalizeTag(c.Param("tag")), model.NormalizeTag(req.To)
+	updated, err := h.tagRepo.Rename(c, from, to)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.RetagResponse{
+		Tag:          to,
+		Replaced:     []string{from},
+		PostsUpdated: updated,
+	})
+}
+
+// MergeTagsRequest represents the request body for merging tags
+type MergeTagsRequest struct {
+	Sources []string `json:"sources" binding:"required"`
+	Target  string   `json:"target" binding:"required"`
+}
+
+// MergeTags handles POST /api/admin/tags/merge (admin only)
+// @Summary		Merge tags
+// @Description	Fold source tags into a target tag on every post and project in one transaction; sources become aliases (admin only)
+// @Tags			Tags
+// @Accept			json
+// @Produce		json
+// @Param			request	body		MergeTagsRequest	true	"Source tags and target tag"
+// @Success		200		{object}	view.RetagResponse	"Tags merged successfully"
+// @Failure		400		{object}	map[string]string	"Invalid request"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string	"Tags not found"
+// @Router			/api/admin/tags/merge [post]
+// @Security		BearerAuth
+func (h *TagHandler) MergeTags(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	var req MergeTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	target := model.NormalizeTag(req.Target)
+	updated, err := h.tagRepo.Merge(c, req.Sources, target)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	var replaced []string
+	for _, source := range model.NormalizeTags(req.Sources) {
+		if source != target {
+			replaced = append(replaced, source)
+		}
+	}
+
+	c.JSON(http.StatusOK, view.RetagResponse{
+		Tag:          target,
+		Replaced:     replaced,
+		PostsUpdated: updated,
+	})
+}
+
+// ListTagAliases handles GET /api/admin/tag-aliases (admin only)
+// @Summary		List tag aliases
+// @Description	List every alias and the canonical tag it resolves to (admin only)
+// @Tags			Tags
+// @Produce		json
+// @Success		200	{array}		view.TagAliasResponse	"List of tag aliases"
+// @Failure		401	{object}	map[string]string		"Unauthorized"
+// @Failure		403	{object}	map[string]string		"Forbidden - admin role required"
+// @Router			/api/admin/tag-aliases [get]
+// @Security		BearerAuth
+func (h *TagHandler) ListTagAliases(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	aliases, err := h.tagRepo.ListAliases(c)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list tag aliases"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToTagAliasResponses(aliases))
+}
+
+// CreateTagAliasRequest represents the request body for creating a tag alias
+type CreateTagAliasRequest struct {
+	Alias string `json:"alias" binding:"required"`
+	Tag   string `json:"tag" binding:"required"`
+}
+
+// CreateTagAlias handles POST /api/admin/tag-aliases (admin only)
+// @Summary		Create a tag alias
+// @Description	Make an alternative spelling resolve to a canonical tag when posts and projects are saved or filtered (admin only)
+// @Tags			Tags
+// @Accept			json
+// @Produce		json
+// @Param			request	body		CreateTagAliasRequest	true	"Alias and canonical tag"
+// @Success		201		{object}	view.TagAliasResponse	"Alias created successfully"
+// @Failure		400		{object}	map[string]string		"Invalid request"
+// @Failure		401		{object}	map[string]string		"Unauthorized"
+// @Failure		403		{object}	map[string]string		"Forbidden - admin role required"
+// @Failure		409		{object}	map[string]string		"Alias exists or tag is in use"
+// @Router			/api/admin/tag-aliases [post]
+// @Security		BearerAuth
+func (h *TagHandler) CreateTagAlias(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	var req CreateTagAliasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	alias := &model.TagAlias{Alias: req.Alias, Tag: req.Tag}
+	if err := h.tagRepo.CreateAlias(c, alias); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, view.ToTagAliasResponse(alias))
+}
+
+// DeleteTagAlias handles DELETE /api/admin/tag-aliases/:alias (admin only)
+// @Summary		Delete a tag alias
+// @Description	Stop an alias resolving to its canonical tag (admin only)
+// @Tags			Tags
+// @Param			alias	path	string	true	"Alias"
+// @Success		204				"Alias deleted successfully"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string	"Alias not found"
+// @Router			/api/admin/tag-aliases/{alias} [delete]
+// @Security		BearerAuth
+func (h *TagHandler) DeleteTagAlias(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	if err := h.tagRepo.DeleteAlias(c, c.Param("alias")); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
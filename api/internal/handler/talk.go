@@ -0,0 +1,353 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/ical"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// talkDefaultDuration is how long a talk without an end time lasts in the calendar feed
+const talkDefaultDuration = time.Hour
+
+// TalkHandler handles speaking and media HTTP requests
+type TalkHandler struct {
+	talkRepo *model.TalkRepository
+	siteURL  string
+	host     string // Qualifies calendar event UIDs so they are unique across calendars
+}
+
+// NewTalkHandler creates a new talk handler for the site at siteURL
+func NewTalkHandler(talkRepo *model.TalkRepository, siteURL string) *TalkHandler {
+	host := "localhost"
+	if u, err := url.Parse(siteURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	return &TalkHandler{
+		talkRepo: talkRepo,
+		siteURL:  strings.TrimSuffix(siteURL, "/"),
+		host:     host,
+	}
+}
+
+// ListTalks handles GET /api/talks (public)
+// @Summary		List talks and appearances
+// @Description	List talks, workshops, podcasts and features, split into upcoming (soonest first) and past (most recent first). An appearance is upcoming until it ends.
+// @Tags			Talks
+// @Produce		json
+// @Param			type	query		string					false	"Only appearances of this type: talk, workshop, podcast or feature"
+// @Success		200		{object}	view.TalkListResponse	"Upcoming and past appearances"
+// @Failure		400		{object}	map[string]string		"Invalid type"
+// @Failure		500		{object}	map[string]string		"Internal server error"
+// @Router			/api/talks [get]
+func (h *TalkHandler) ListTalks(c *gin.Context) {
+	talkType := model.TalkType(c.Query("type"))
+	if talkType != "" && !talkType.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be one of: talk, workshop, podcast, feature"})
+		return
+	}
+
+	talks, err := h.talkRepo.List(c, talkType)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list talks"})
+		return
+	}
+
+	now := time.Now()
+	hidePrivatePosts(talks, now)
+	upcoming, past := model.SplitTalks(talks, now)
+
+	c.JSON(http.StatusOK, view.TalkListResponse{
+		Upcoming: view.ToTalkResponses(upcoming),
+		Past:     view.ToTalkResponses(past),
+	})
+}
+
+// GetCalendar handles GET /api/talks.ics (public)
+// @Summary		Talks calendar feed
+// @Description	Every appearance as an iCalendar feed that calendar apps can subscribe to. Past appearances stay in the feed so they do not disappear from subscribers' calendars.
+// @Tags			Talks
+// @Produce		text/calendar
+// @Success		200	{string}	string				"iCalendar feed"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Router			/api/talks.ics [get]
+func (h *TalkHandler) GetCalendar(c *gin.Context) {
+	talks, err := h.talkRepo.List(c, "")
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list talks"})
+		return
+	}
+
+	hidePrivatePosts(talks, time.Now())
+
+	cal := ical.Calendar{
+		ProductID: fmt.Sprintf("-//%s//Talks//EN", h.host),
+		Name:      fmt.Sprintf("Talks (%s)", h.host),
+	}
+
+	// No Last-Modified: a deleted talk or a post changing visibility would not raise it
+	for _, talk := range talks {
+		cal.Events = append(cal.Events, h.calendarEvent(&talk))
+	}
+
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", cal.Bytes())
+}
+
+// calendarEvent describes a talk as a calendar event, listing its links in the description
+func (h *TalkHandler) calendarEvent(talk *model.Talk) ical.Event {
+	end := talk.StartsAt.Add(talkDefaultDuration)
+	if talk.EndsAt != nil && talk.EndsAt.After(talk.StartsAt) {
+		end = *talk.EndsAt
+	}
+
+	var description []string
+	if talk.Abstract != "" {
+		description = append(description, talk.Abstract)
+	}
+	links := []struct{ label, url string }{
+		{"Slides", talk.SlidesURL},
+		{"Video", talk.VideoURL},
+		{"Recording", talk.RecordingURL},
+	}
+	if talk.Post != nil {
+		links = append(links, struct{ label, url string }{"Post", h.siteURL + "/blog/" + talk.Post.Slug})
+	}
+	for _, link := range links {
+		if link.url != "" {
+			description = append(description, link.label+": "+link.url)
+		}
+	}
+
+	return ical.Event{
+		UID:         talk.ID.String() + "@" + h.host,
+		Stamp:       talk.UpdatedAt,
+		Start:       talk.StartsAt,
+		End:         end,
+		Summary:     talk.Title + " (" + talk.Event + ")",
+		Description: strings.Join(description, "\n\n"),
+		Location:    talk.Location,
+		URL:         talk.EventURL,
+	}
+}
+
+// hidePrivatePosts drops related posts that anonymous readers may not see at now
+func hidePrivatePosts(talks []model.Talk, now time.Time) {
+	for i := range talks {
+		if talks[i].Post != nil && !talks[i].Post.IsPublic(now) {
+			talks[i].Post = nil
+		}
+	}
+}
+
+// ListAllTalks handles GET /api/admin/talks (admin only)
+// @Summary		List all talks
+// @Description	List every appearance by start time, with related posts whatever their status (admin only)
+// @Tags			Talks
+// @Produce		json
+// @Success		200	{array}		view.TalkResponse	"List of talks"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Router			/api/admin/talks [get]
+// @Security		BearerAuth
+func (h *TalkHandler) ListAllTalks(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	talks, err := h.talkRepo.List(c, "")
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list talks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToTalkResponses(talks))
+}
+
+// TalkRequest represents the request body for creating or updating a talk
+type TalkRequest struct {
+	Title        string     `json:"title" binding:"required"`
+	Type         string     `json:"type"` // talk (default), workshop, podcast or feature
+	Event        string     `json:"event" binding:"required"`
+	EventURL     string     `json:"event_url"`
+	Location     string     `json:"location"`
+	Abstract     string     `json:"abstract"`
+	StartsAt     time.Time  `json:"starts_at" binding:"required"`
+	EndsAt       *time.Time `json:"ends_at"`
+	SlidesURL    string     `json:"slides_url"`
+	VideoURL     string     `json:"video_url"`
+	RecordingURL string     `json:"recording_url"`
+	PostID       *uuid.UUID `json:"post_id"`
+}
+
+// apply copies the request's fields onto talk
+func (req *TalkRequest) apply(talk *model.Talk) {
+	talkType := model.TalkType(req.Type)
+	if talkType == "" {
+		talkType = model.TalkTypeTalk
+	}
+
+	talk.Title = req.Title
+	talk.Type = talkType
+	talk.Event = req.Event
+	talk.EventURL = req.EventURL
+	talk.Location = req.Location
+	talk.Abstract = req.Abstract
+	talk.StartsAt = req.StartsAt
+	talk.EndsAt = req.EndsAt
+	talk.SlidesURL = req.SlidesURL
+	talk.VideoURL = req.VideoURL
+	talk.RecordingURL = req.RecordingURL
+	talk.PostID = req.PostID
+}
+
+// CreateTalk handles POST /api/admin/talks (admin only)
+// @Summary		Create a talk
+// @Description	Add a talk, workshop, podcast or feature, optionally linked to a post (admin only)
+// @Tags			Talks
+// @Accept			json
+// @Produce		json
+// @Param			request	body		TalkRequest			true	"Talk request body"
+// @Success		201		{object}	view.TalkResponse	"Talk created successfully"
+// @Failure		400		{object}	map[string]string	"Invalid request body"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Router			/api/admin/talks [post]
+// @Security		BearerAuth
+func (h *TalkHandler) CreateTalk(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	var req TalkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	talk := &model.Talk{}
+	req.apply(talk)
+
+	if err := h.talkRepo.Create(c, talk); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	h.respondWithTalk(c, http.StatusCreated, talk.ID)
+}
+
+// UpdateTalk handles PUT /api/admin/talks/:id (admin only)
+// @Summary		Update a talk
+// @Description	Replace a talk's details (admin only)
+// @Tags			Talks
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string				true	"Talk ID (UUID)"
+// @Param			request	body		TalkRequest			true	"Updated talk data"
+// @Success		200		{object}	view.TalkResponse	"Talk updated successfully"
+// @Failure		400		{object}	map[string]string	"Invalid request"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string	"Talk not found"
+// @Router			/api/admin/talks/{id} [put]
+// @Security		BearerAuth
+func (h *TalkHandler) UpdateTalk(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	var req TalkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	talk := &model.Talk{ID: id}
+	req.apply(talk)
+
+	if err := h.talkRepo.Update(c, talk); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	h.respondWithTalk(c, http.StatusOK, id)
+}
+
+// respondWithTalk reloads a saved talk so the response includes its related post
+func (h *TalkHandler) respondWithTalk(c *gin.Context, status int, id uuid.UUID) {
+	talk, err := h.talkRepo.GetByID(c, id)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load talk"})
+		return
+	}
+
+	c.JSON(status, view.ToTalkResponse(talk))
+}
+
+// DeleteTalk handles DELETE /api/admin/talks/:id (admin only)
+// @Summary		Delete a talk
+// @Description	Permanently delete a talk (admin only)
+// @Tags			Talks
+// @Param			id	path	string	true	"Talk ID (UUID)"
+// @Success		204			"Talk deleted successfully"
+// @Failure		400	{object}	map[string]string	"Invalid request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string	"Talk not found"
+// @Router			/api/admin/talks/{id} [delete]
+// @Security		BearerAuth
+func (h *TalkHandler) DeleteTalk(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	if err := h.talkRepo.Delete(c, id); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
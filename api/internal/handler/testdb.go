@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
 )
 
 // setupTestDB creates a temporary SQLite database and runs migrations
@@ -69,12 +70,330 @@ CREATE TABLE posts (
     tags TEXT,
     status TEXT NOT NULL,
     published_at TIMESTAMP,
+    publish_at TIMESTAMP,
+    unpublish_at TIMESTAMP,
+    word_count INTEGER,
+    reading_time INTEGER,
+    toc TEXT,
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL,
-    CONSTRAINT valid_status CHECK (status IN ('draft', 'published', 'archived'))
+    deleted_at TIMESTAMP,
+    version INTEGER NOT NULL DEFAULT 1,
+    locale TEXT NOT NULL DEFAULT 'en',
+    CONSTRAINT valid_status CHECK (status IN ('draft', 'published', 'archived', 'scheduled'))
 );
 CREATE INDEX idx_posts_slug ON posts(slug);
 CREATE INDEX idx_posts_status ON posts(status);
+CREATE INDEX idx_posts_publish_at ON posts(publish_at);
+CREATE INDEX idx_posts_unpublish_at ON posts(unpublish_at);
+CREATE INDEX idx_posts_deleted_at ON posts(deleted_at);
+CREATE INDEX idx_posts_locale ON posts(locale);
+`,
+		},
+		{
+			name: "post_translations",
+			sql: `
+CREATE TABLE post_translations (
+    post_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    locale TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    summary TEXT,
+    body TEXT NOT NULL,
+    word_count INTEGER NOT NULL DEFAULT 0,
+    reading_time INTEGER NOT NULL DEFAULT 0,
+    toc TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (post_id, locale)
+);
+CREATE INDEX idx_post_translations_locale ON post_translations(locale);
+`,
+		},
+		{
+			name: "post_preview_tokens",
+			sql: `
+CREATE TABLE post_preview_tokens (
+    id TEXT PRIMARY KEY,
+    post_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_post_preview_tokens_post_id ON post_preview_tokens(post_id);
+`,
+		},
+		{
+			name: "post_slug_history",
+			sql: `
+CREATE TABLE post_slug_history (
+    slug TEXT PRIMARY KEY,
+    post_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_post_slug_history_post_id ON post_slug_history(post_id);
+`,
+		},
+		{
+			name: "series",
+			sql: `
+CREATE TABLE series (
+    id TEXT PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+CREATE TABLE series_posts (
+    series_id TEXT NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    post_id TEXT NOT NULL UNIQUE REFERENCES posts(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (series_id, post_id)
+);
+CREATE INDEX idx_series_posts_position ON series_posts(series_id, position);
+`,
+		},
+		{
+			name: "resumes",
+			sql: `
+CREATE TABLE resume_sections (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    title TEXT NOT NULL,
+    organization TEXT,
+    location TEXT,
+    start_date TEXT,
+    end_date TEXT,
+    summary TEXT,
+    highlights TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT valid_resume_section_kind CHECK (kind IN ('experience', 'skills', 'education'))
+);
+CREATE TABLE resume_variants (
+    id TEXT PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    headline TEXT,
+    summary TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+CREATE TABLE resume_variant_sections (
+    variant_id TEXT NOT NULL REFERENCES resume_variants(id) ON DELETE CASCADE,
+    section_id TEXT NOT NULL REFERENCES resume_sections(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (variant_id, section_id)
+);
+CREATE INDEX idx_resume_variant_sections_position ON resume_variant_sections(variant_id, position);
+CREATE INDEX idx_resume_variant_sections_section ON resume_variant_sections(section_id);
+`,
+		},
+		{
+			name: "projects",
+			sql: `
+CREATE TABLE projects (
+    id TEXT PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    summary TEXT,
+    problem TEXT,
+    role TEXT,
+    stack TEXT,
+    outcomes TEXT,
+    links TEXT,
+    tags TEXT,
+    status TEXT NOT NULL DEFAULT 'draft',
+    featured BOOLEAN NOT NULL DEFAULT 0,
+    position INTEGER NOT NULL DEFAULT 0,
+    published_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT valid_project_status CHECK (status IN ('draft', 'published'))
+);
+CREATE INDEX idx_projects_status_position ON projects(status, position, published_at DESC);
+CREATE INDEX idx_projects_featured ON projects(featured) WHERE status = 'published';
+`,
+		},
+		{
+			name: "talks",
+			sql: `
+CREATE TABLE talks (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    type TEXT NOT NULL,
+    event TEXT NOT NULL,
+    event_url TEXT,
+    location TEXT,
+    abstract TEXT,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP,
+    slides_url TEXT,
+    video_url TEXT,
+    recording_url TEXT,
+    post_id TEXT REFERENCES posts(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT valid_talk_type CHECK (type IN ('talk', 'workshop', 'podcast', 'feature'))
+);
+CREATE INDEX idx_talks_starts_at ON talks(starts_at);
+`,
+		},
+		{
+			name: "newsletter",
+			sql: `
+CREATE TABLE newsletter_subscribers (
+    id TEXT PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL,
+    confirm_token_hash TEXT UNIQUE,
+    unsubscribe_token TEXT NOT NULL UNIQUE,
+    confirmation_sent_at TIMESTAMP,
+    confirmations_sent INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP,
+    confirmed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT valid_subscriber_status CHECK (status IN ('pending', 'confirmed'))
+);
+CREATE INDEX idx_newsletter_subscribers_expires_at ON newsletter_subscribers(expires_at);
+`,
+		},
+		{
+			name: "pages",
+			sql: `
+CREATE TABLE pages (
+    id TEXT PRIMARY KEY,
+    path TEXT NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'draft',
+    seo_title TEXT,
+    seo_description TEXT,
+    canonical_url TEXT,
+    noindex BOOLEAN NOT NULL DEFAULT FALSE,
+    word_count INTEGER NOT NULL DEFAULT 0,
+    reading_time INTEGER NOT NULL DEFAULT 0,
+    toc TEXT,
+    published_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT valid_page_status CHECK (status IN ('draft', 'published'))
+);
+`,
+		},
+		{
+			name: "related_posts",
+			sql: `
+CREATE TABLE post_similarity (
+    post_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    related_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    score REAL NOT NULL,
+    PRIMARY KEY (post_id, related_id)
+);
+CREATE TABLE related_index_state (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    post_count INTEGER NOT NULL,
+    built_at TIMESTAMP NOT NULL
+);
+`,
+		},
+		{
+			name: "post_reactions",
+			sql: `
+CREATE TABLE post_reactions (
+    post_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    emoji TEXT NOT NULL,
+    visitor_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (post_id, emoji, visitor_hash)
+);
+CREATE TABLE reaction_salts (
+    day TEXT PRIMARY KEY,
+    salt TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE TABLE reaction_rate_limits (
+    day TEXT NOT NULL,
+    sender_hash TEXT NOT NULL,
+    reactions INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, sender_hash)
+);
+`,
+		},
+		{
+			name: "webmentions",
+			sql: `
+CREATE TABLE webmentions (
+    id TEXT PRIMARY KEY,
+    post_id TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    source TEXT NOT NULL,
+    target TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    approved BOOLEAN NOT NULL DEFAULT 0,
+    author_name TEXT NOT NULL DEFAULT '',
+    author_url TEXT NOT NULL DEFAULT '',
+    author_photo TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL DEFAULT '',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    verified_at TIMESTAMP,
+    sender_hash TEXT NOT NULL DEFAULT '',
+    source_host TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (source, target),
+    CONSTRAINT valid_webmention_status CHECK (status IN ('pending', 'verified', 'failed'))
+);
+CREATE INDEX idx_webmentions_post ON webmentions(post_id, status, approved);
+CREATE INDEX idx_webmentions_status ON webmentions(status, updated_at);
+CREATE INDEX idx_webmentions_sender ON webmentions(sender_hash, updated_at);
+CREATE INDEX idx_webmentions_source_host ON webmentions(source_host, status);
+`,
+		},
+		{
+			name: "media",
+			sql: `
+CREATE TABLE media_assets (
+    id TEXT PRIMARY KEY,
+    sha256 TEXT NOT NULL UNIQUE,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    width INTEGER,
+    height INTEGER,
+    storage_key TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE TABLE media_variants (
+    asset_id TEXT NOT NULL REFERENCES media_assets(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    storage_key TEXT NOT NULL,
+    PRIMARY KEY (asset_id, name)
+);
+CREATE INDEX idx_media_assets_created_at ON media_assets(created_at);
+`,
+		},
+		{
+			name: "tags",
+			sql: `
+CREATE TABLE tags (
+    name TEXT PRIMARY KEY,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+CREATE TABLE tag_aliases (
+    alias TEXT PRIMARY KEY,
+    tag TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_tag_aliases_tag ON tag_aliases(tag);
 `,
 		},
 		{
@@ -133,6 +452,7 @@ CREATE TABLE visitor_stats (
     errors_5xx INTEGER NOT NULL DEFAULT 0,
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
     UNIQUE (date, page_path),
     CONSTRAINT non_negative_pageviews CHECK (pageviews >= 0),
     CONSTRAINT non_negative_visitors CHECK (unique_visitors >= 0),
@@ -219,6 +539,38 @@ func (a *sqliteAdapter) ExecContext(ctx context.Context, query string, args ...i
 }
 
 // Close closes the underlying database connection
+// WithTx runs fn inside a transaction with the same placeholder conversion
+func (a *sqliteAdapter) WithTx(ctx context.Context, fn func(tx db.QueryExecutor) error) error {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(&sqliteTxAdapter{tx: tx}); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// sqliteTxAdapter wraps sql.Tx to convert PostgreSQL placeholders to SQLite
+type sqliteTxAdapter struct {
+	tx *sql.Tx
+}
+
+func (a *sqliteTxAdapter) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return a.tx.QueryRowContext(ctx, convertQuery(query), convertArgs(args...)...)
+}
+
+func (a *sqliteTxAdapter) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return a.tx.QueryContext(ctx, convertQuery(query), convertArgs(args...)...)
+}
+
+func (a *sqliteTxAdapter) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return a.tx.ExecContext(ctx, convertQuery(query), convertArgs(args...)...)
+}
+
 func (a *sqliteAdapter) Close() error {
 	return a.db.Close()
 }
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/events"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// PublishPostRequest represents the optional request body for publishing a post
+type PublishPostRequest struct {
+	ResetPublishedAt bool `json:"reset_published_at"` // Date the post now instead of keeping its original published_at
+}
+
+// PublishPost handles POST /api/posts/:slug/publish (admin only)
+// @Summary		Publish a blog post
+// @Description	Publish a draft, scheduled or archived post now (admin only). A post published before keeps its original
+// @Description	published_at unless reset_published_at is set. Emits a post.published event.
+// @Tags			Posts
+// @Accept			json
+// @Produce		json
+// @Param			slug		path		string				true	"Post slug"
+// @Param			If-Match	header		string				false	"ETag from the last read; required when REQUIRE_IF_MATCH is set"
+// @Param			request		body		PublishPostRequest	false	"Publish options"
+// @Success		200		{object}	view.PostResponse	"Post published"
+// @Failure		400		{object}	map[string]string	"Invalid request"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string	"Post not found"
+// @Failure		409		{object}	map[string]string	"Post is already published"
+// @Failure		410		{object}	map[string]string	"Post has been deleted"
+// @Failure		412		{object}	map[string]string	"Post was modified since the If-Match version"
+// @Failure		428		{object}	map[string]string	"If-Match header required"
+// @Router			/api/posts/{slug}/publish [post]
+// @Security		BearerAuth
+func (h *PostHandler) PublishPost(c *gin.Context) {
+	var req PublishPostRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+
+	h.transitionPost(c, model.PostTransitionPublish, model.TransitionOptions{ResetPublishedAt: req.ResetPublishedAt})
+}
+
+// UnpublishPost handles POST /api/posts/:slug/unpublish (admin only)
+// @Summary		Unpublish a blog post
+// @Description	Return a published or scheduled post to draft (admin only). published_at is kept for when it is published again. Emits a post.unpublished event.
+// @Tags			Posts
+// @Produce		json
+// @Param			slug		path		string	true	"Post slug"
+// @Param			If-Match	header		string	false	"ETag from the last read; required when REQUIRE_IF_MATCH is set"
+// @Success		200		{object}	view.PostResponse	"Post unpublished"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string	"Post not found"
+// @Failure		409		{object}	map[string]string	"Post is not published or scheduled"
+// @Failure		410		{object}	map[string]string	"Post has been deleted"
+// @Failure		412		{object}	map[string]string	"Post was modified since the If-Match version"
+// @Failure		428		{object}	map[string]string	"If-Match header required"
+// @Router			/api/posts/{slug}/unpublish [post]
+// @Security		BearerAuth
+func (h *PostHandler) UnpublishPost(c *gin.Context) {
+	h.transitionPost(c, model.PostTransitionUnpublish, model.TransitionOptions{})
+}
+
+// ArchivePost handles POST /api/posts/:slug/archive (admin only)
+// @Summary		Archive a blog post
+// @Description	Archive a draft, scheduled or published post (admin only). published_at is kept. Emits a post.archived event.
+// @Tags			Posts
+// @Produce		json
+// @Param			slug		path		string	true	"Post slug"
+// @Param			If-Match	header		string	false	"ETag from the last read; required when REQUIRE_IF_MATCH is set"
+// @Success		200		{object}	view.PostResponse	"Post archived"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string	"Post not found"
+// @Failure		409		{object}	map[string]string	"Post is already archived"
+// @Failure		410		{object}	map[string]string	"Post has been deleted"
+// @Failure		412		{object}	map[string]string	"Post was modified since the If-Match version"
+// @Failure		428		{object}	map[string]string	"If-Match header required"
+// @Router			/api/posts/{slug}/archive [post]
+// @Security		BearerAuth
+func (h *PostHandler) ArchivePost(c *gin.Context) {
+	h.transitionPost(c, model.PostTransitionArchive, model.TransitionOptions{})
+}
+
+// transitionPost applies a state machine transition to the post named by the slug and emits its event once saved
+func (h *PostHandler) transitionPost(c *gin.Context, transition model.PostTransition, opts model.TransitionOptions) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	post, err := h.postRepo.GetBySlug(c, c.Param("slug"))
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	if post.IsTrashed() {
+		respondPostGone(c)
+		return
+	}
+
+	if !checkIfMatch(c, post.Version) {
+		return
+	}
+
+	now := time.Now().UTC()
+	from := post.Status
+	if err := post.Transition(transition, now, opts); err != nil {
+		status, message := statusCodeFromError(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	if err := h.postRepo.Update(c, post); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	h.publishTransition(c, transition, post, from, now)
+
+	response := view.ToPostResponse(post)
+	if err := h.attachSeries(c, []*view.PostResponse{response}, true); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load series"})
+		return
+	}
+
+	setVersionETag(c, post.Version)
+	c.JSON(http.StatusOK, response)
+}
+
+// publishTransition emits the event of a transition once the post has been saved; no transition emits nothing
+func (h *PostHandler) publishTransition(c *gin.Context, transition model.PostTransition, post *model.Post, from model.PostStatus, at time.Time) {
+	if transition == "" {
+		return
+	}
+
+	h.events.Publish(c, events.Event{
+		Type:   events.ForTransition(transition),
+		PostID: post.ID,
+		Slug:   post.Slug,
+		From:   from,
+		To:     post.Status,
+		At:     at,
+	})
+}
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// postLocale picks the language to serve a post in from its own locale and those of its translations
+// An explicit ?lang= wins, then the language of the slug that was requested, then Accept-Language;
+// anything unavailable falls back to the language the post is written in
+func postLocale(c *gin.Context, post *model.Post, translations []model.PostTranslation, slugLocale string) string {
+	available := []string{post.Locale}
+	for _, t := range translations {
+		available = append(available, t.Locale)
+	}
+
+	if lang := c.Query("lang"); lang != "" {
+		if locale := negotiateLocale(lang, available); locale != "" {
+			return locale
+		}
+	}
+
+	if slugLocale != "" {
+		return slugLocale
+	}
+
+	if locale := negotiateLocale(c.GetHeader("Accept-Language"), available); locale != "" {
+		return locale
+	}
+
+	return post.Locale
+}
+
+// negotiateLocale returns the available locale that best matches an Accept-Language value, or "" if none does
+// Language ranges are tried by descending quality; each prefers an exact match, then one sharing its language,
+// so es-MX is served es and es is served es-ES when that is all there is
+func negotiateLocale(acceptLanguage string, available []string) string {
+	type languageRange struct {
+		locale  string
+		quality float64
+	}
+
+	var ranges []languageRange
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		ranges = append(ranges, languageRange{locale: model.NormalizeLocale(tag), quality: quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	for _, r := range ranges {
+		for _, locale := range available {
+			if locale == r.locale {
+				return locale
+			}
+		}
+
+		language := primaryLanguage(r.locale)
+		for _, locale := range available {
+			if primaryLanguage(locale) == language {
+				return locale
+			}
+		}
+	}
+
+	return ""
+}
+
+// primaryLanguage returns the language part of a locale, such as es for es-MX
+func primaryLanguage(locale string) string {
+	language, _, _ := strings.Cut(locale, "-")
+	return language
+}
+
+// findTranslation returns the translation into locale, or nil if there is none
+func findTranslation(translations []model.PostTranslation, locale string) *model.PostTranslation {
+	for i := range translations {
+		if translations[i].Locale == locale {
+			return &translations[i]
+		}
+	}
+	return nil
+}
+
+// SavePostTranslationRequest represents the request body for creating or replacing a post translation
+type SavePostTranslationRequest struct {
+	Slug    string `json:"slug" binding:"required"`
+	Title   string `json:"title" binding:"required"`
+	Summary string `json:"summary"`
+	Body    string `json:"body" binding:"required"`
+}
+
+// ListPostTranslations handles GET /api/admin/posts/:id/translations (admin only)
+// @Summary		List a post's translations
+// @Description	List every translation of a post, ordered by locale (admin only)
+// @Tags			Posts
+// @Produce		json
+// @Param			id	path		string	true	"Post ID (UUID)"
+// @Success		200	{array}		view.PostTranslationResponse	"List of translations"
+// @Failure		400	{object}	map[string]string	"Invalid request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string	"Post not found"
+// @Router			/api/admin/posts/{id}/translations [get]
+// @Security		BearerAuth
+func (h *PostHandler) ListPostTranslations(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	post, err := h.postRepo.GetByID(c, id)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	translations, err := h.postRepo.ListTranslations(c, []uuid.UUID{post.ID})
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list translations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToPostTranslationResponses(translations[post.ID]))
+}
+
+// SavePostTranslation handles PUT /api/admin/posts/:id/translations/:locale (admin only)
+// @Summary		Create or replace a post translation
+// @Description	Set the title, summary, body and slug of a post in another locale (admin only). The translation is
+// @Description	shown whenever the post itself is visible; its slug must not be used by any post or other translation.
+// @Tags			Posts
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"Post ID (UUID)"
+// @Param			locale	path		string						true	"Locale, e.g. es or es-MX"
+// @Param			request	body		SavePostTranslationRequest	true	"Translation"
+// @Success		200		{object}	view.PostTranslationResponse	"Translation saved"
+// @Failure		400		{object}	map[string]string	"Invalid request"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string	"Post not found"
+// @Failure		409		{object}	map[string]string	"Slug already in use"
+// @Router			/api/admin/posts/{id}/translations/{locale} [put]
+// @Security		BearerAuth
+func (h *PostHandler) SavePostTranslation(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	var req SavePostTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	translation := &model.PostTranslation{
+		PostID:  id,
+		Locale:  c.Param("locale"),
+		Slug:    req.Slug,
+		Title:   req.Title,
+		Summary: req.Summary,
+		Body:    req.Body,
+	}
+
+	if err := h.postRepo.SaveTranslation(c, translation); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(http.StatusOK, view.ToPostTranslationResponse(translation))
+}
+
+// DeletePostTranslation handles DELETE /api/admin/posts/:id/translations/:locale (admin only)
+// @Summary		Delete a post translation
+// @Description	Remove a post's translation into a locale (admin only)
+// @Tags			Posts
+// @Param			id		path	string	true	"Post ID (UUID)"
+// @Param			locale	path	string	true	"Locale, e.g. es or es-MX"
+// @Success		204				"Translation deleted"
+// @Failure		400		{object}	map[string]string	"Invalid request"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404		{object}	map[string]string	"Translation not found"
+// @Router			/api/admin/posts/{id}/translations/{locale} [delete]
+// @Security		BearerAuth
+func (h *PostHandler) DeletePostTranslation(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	if err := h.postRepo.DeleteTranslation(c, id, c.Param("locale")); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// ListTrashedPosts handles GET /api/admin/trash/posts (admin only)
+// @Summary		List trashed posts
+// @Description	List deleted posts still in the trash, most recently deleted first (admin only)
+// @Tags			Posts
+// @Produce		json
+// @Param			limit	query		integer	false	"Number of posts per page (default: 10)"
+// @Param			offset	query		integer	false	"Number of posts to skip (default: 0)"
+// @Param			cursor	query		string	false	"Opaque cursor from a previous page's next_cursor; switches the response to a paginated envelope"
+// @Param			include_total	query		boolean	false	"Include the total item count"
+// @Param			fields	query		string	false	"Comma-separated response fields to return, e.g. id,slug,title,deleted_at"
+// @Success		200		{array}		view.PostResponse	"List of trashed posts (view.PageResponse envelope when cursor is given)"
+// @Failure		400		{object}	map[string]string	"Invalid cursor"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Router			/api/admin/trash/posts [get]
+// @Security		BearerAuth
+func (h *PostHandler) ListTrashedPosts(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	page, err := parsePageGin(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	posts, next, err := h.postRepo.ListTrashed(c, page)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list trashed posts"})
+		return
+	}
+
+	var total *int
+	if wantsTotal(c) {
+		count, err := h.postRepo.CountTrashed(c)
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count trashed posts"})
+			return
+		}
+		total = &count
+	}
+
+	writePageGin(c, view.ToPostResponses(posts), next, total)
+}
+
+// RestorePost handles POST /api/admin/trash/posts/:id/restore (admin only)
+// @Summary		Restore a trashed post
+// @Description	Take a post out of the trash with the status it had when it was deleted (admin only)
+// @Tags			Posts
+// @Produce		json
+// @Param			id	path		string	true	"Post ID (UUID)"
+// @Success		200	{object}	view.PostResponse	"Post restored"
+// @Failure		400	{object}	map[string]string	"Invalid request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string	"Trashed post not found"
+// @Router			/api/admin/trash/posts/{id}/restore [post]
+// @Security		BearerAuth
+func (h *PostHandler) RestorePost(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	if err := h.postRepo.Restore(c, id); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	post, err := h.postRepo.GetByID(c, id)
+	if err != nil {
+		status, message := statusCodeFromError(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	setVersionETag(c, post.Version)
+	c.JSON(http.StatusOK, view.ToPostResponse(post))
+}
+
+// PurgePost handles DELETE /api/admin/trash/posts/:id (admin only)
+// @Summary		Permanently delete a trashed post
+// @Description	Permanently delete a post that is in the trash, with its slug history, preview links and reactions (admin only)
+// @Tags			Posts
+// @Param			id	path	string	true	"Post ID (UUID)"
+// @Success		204			"Post purged"
+// @Failure		400	{object}	map[string]string	"Invalid request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string	"Trashed post not found"
+// @Router			/api/admin/trash/posts/{id} [delete]
+// @Security		BearerAuth
+func (h *PostHandler) PurgePost(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	if err := h.postRepo.Purge(c, id); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondPostGone answers a public request for a trashed post
+// 410 tells crawlers and feed readers the post was removed on purpose, rather than never existing
+func respondPostGone(c *gin.Context) {
+	c.JSON(http.StatusGone, gin.H{"error": "post has been deleted"})
+}
@@ -0,0 +1,344 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/auth"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/view"
+)
+
+// maxWebmentionURLLength matches the webmentions source and target columns
+const maxWebmentionURLLength = 2048
+
+// Webmention rate limits, which keep any one sender or site from crowding real mentions out of the verification queue
+const (
+	maxWebmentionsPerSenderPerDay = 20
+	maxPendingWebmentionsPerHost  = 10
+)
+
+// WebmentionHandler handles Webmention HTTP requests
+type WebmentionHandler struct {
+	postRepo     *model.PostRepository
+	mentionRepo  *model.WebmentionRepository
+	reactionRepo *model.ReactionRepository // Provides the daily salt that senders are hashed under
+	postPrefix   *url.URL                  // Site URL of the blog, which post URLs are the slug under
+}
+
+// NewWebmentionHandler creates a new webmention handler for posts published under siteURL/blog/
+func NewWebmentionHandler(postRepo *model.PostRepository, mentionRepo *model.WebmentionRepository, reactionRepo *model.ReactionRepository, siteURL string) *WebmentionHandler {
+	prefix, _ := url.Parse(strings.TrimSuffix(siteURL, "/") + "/blog/")
+	return &WebmentionHandler{
+		postRepo:     postRepo,
+		mentionRepo:  mentionRepo,
+		reactionRepo: reactionRepo,
+		postPrefix:   prefix,
+	}
+}
+
+// ReceiveWebmention handles POST /api/webmention (public)
+// @Summary		Receive a Webmention
+// @Description	W3C Webmention endpoint. Accepts a form-encoded source and target, checks that target is one of our
+// @Description	published posts, and queues the mention; a background job then fetches source to confirm it links to
+// @Description	target. Verified mentions are shown once an admin approves them. Sending the same pair again re-queues it.
+// @Description	Rate limited to 20 mentions per sender per day and 10 mentions awaiting verification per source host.
+// @Tags			Webmentions
+// @Accept			x-www-form-urlencoded
+// @Produce		json
+// @Param			source	formData	string	true	"URL of the page that links to the post"
+// @Param			target	formData	string	true	"URL of the post that was linked to"
+// @Success		202		{object}	map[string]string	"Mention queued for verification"
+// @Failure		400		{object}	map[string]string	"Invalid source or target"
+// @Failure		429		{object}	map[string]string	"Rate limit exceeded"
+// @Router			/api/webmention [post]
+func (h *WebmentionHandler) ReceiveWebmention(c *gin.Context) {
+	source := strings.TrimSpace(c.PostForm("source"))
+	target := strings.TrimSpace(c.PostForm("target"))
+	if source == "" || target == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "source and target are required"})
+		return
+	}
+
+	sourceURL, ok := parseMentionURL(source)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "source must be an http or https URL"})
+		return
+	}
+	targetURL, ok := parseMentionURL(target)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "target must be an http or https URL"})
+		return
+	}
+	if sourceURL.String() == targetURL.String() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "source and target must be different"})
+		return
+	}
+
+	slug, ok := h.postSlug(targetURL)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "target is not a post on this site"})
+		return
+	}
+
+	now := time.Now().UTC()
+	post, err := h.postRepo.GetBySlug(c, slug)
+	if err != nil || !post.IsPublic(now) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "target is not a published post"})
+		return
+	}
+
+	salt, err := h.reactionRepo.DailySalt(c, now)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to queue webmention"})
+		return
+	}
+	sender := model.VisitorHash(salt, c.ClientIP(), c.Request.UserAgent())
+	sourceHost := strings.ToLower(sourceURL.Hostname())
+
+	sent, err := h.mentionRepo.CountBySenderSince(c, sender, model.GetStartOfDay())
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check rate limit"})
+		return
+	}
+	if sent >= maxWebmentionsPerSenderPerDay {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded: 20 webmentions per day"})
+		return
+	}
+
+	pending, err := h.mentionRepo.CountPendingBySourceHost(c, sourceHost)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check rate limit"})
+		return
+	}
+	if pending >= maxPendingWebmentionsPerHost {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded: 10 webmentions awaiting verification per site"})
+		return
+	}
+
+	mention := &model.Webmention{
+		PostID:     post.ID,
+		Source:     source,
+		Target:     target,
+		SenderHash: sender,
+		SourceHost: sourceHost,
+	}
+	if err := h.mentionRepo.Queue(c, mention); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to queue webmention"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"status": string(mention.Status)})
+}
+
+// postSlug returns the slug of a post URL on this site
+func (h *WebmentionHandler) postSlug(target *url.URL) (string, bool) {
+	if !strings.EqualFold(target.Host, h.postPrefix.Host) || target.Scheme != h.postPrefix.Scheme {
+		return "", false
+	}
+
+	slug, ok := strings.CutPrefix(target.Path, h.postPrefix.Path)
+	slug = strings.TrimSuffix(slug, "/")
+	if !ok || slug == "" || strings.Contains(slug, "/") {
+		return "", false
+	}
+	return slug, true
+}
+
+// parseMentionURL parses an absolute http(s) URL of acceptable length
+func parseMentionURL(raw string) (*url.URL, bool) {
+	if len(raw) > maxWebmentionURLLength {
+		return nil, false
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, false
+	}
+	return u, true
+}
+
+// ListPostWebmentions handles GET /api/posts/:slug/webmentions (public)
+// @Summary		List a post's webmentions
+// @Description	List the verified, approved webmentions of a published post, oldest first
+// @Tags			Webmentions
+// @Produce		json
+// @Param			slug	path		string	true	"Post slug"
+// @Param			limit	query		integer	false	"Number of mentions per page (default: 10)"
+// @Param			offset	query		integer	false	"Number of mentions to skip (default: 0)"
+// @Param			cursor	query		string	false	"Opaque cursor from a previous page's next_cursor; switches the response to a paginated envelope"
+// @Param			include_total	query		boolean	false	"Include the total item count"
+// @Param			fields	query		string	false	"Comma-separated response fields to return, e.g. source,author_name,content"
+// @Success		200		{array}		view.WebmentionResponse	"List of mentions (view.PageResponse envelope when cursor is given)"
+// @Failure		400		{object}	map[string]string	"Invalid cursor"
+// @Failure		404		{object}	map[string]string	"Post not found"
+// @Failure		410		{object}	map[string]string	"Post has been deleted"
+// @Router			/api/posts/{slug}/webmentions [get]
+func (h *WebmentionHandler) ListPostWebmentions(c *gin.Context) {
+	page, err := parsePageGin(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	post, err := h.postRepo.GetBySlug(c, c.Param("slug"))
+	if err == nil && post.IsTrashed() {
+		respondPostGone(c)
+		return
+	}
+	if err != nil || !post.IsPublic(time.Now().UTC()) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+		return
+	}
+
+	mentions, next, err := h.mentionRepo.ListApprovedByPost(c, post.ID, page)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list webmentions"})
+		return
+	}
+
+	var total *int
+	if wantsTotal(c) {
+		count, err := h.mentionRepo.CountApprovedByPost(c, post.ID)
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count webmentions"})
+			return
+		}
+		total = &count
+	}
+
+	writePageGin(c, view.ToWebmentionResponses(mentions), next, total)
+}
+
+// ListWebmentionsAwaitingModeration handles GET /api/admin/webmentions (admin only)
+// @Summary		List webmentions awaiting moderation
+// @Description	List verified webmentions that have not been approved yet, oldest first (admin only)
+// @Tags			Webmentions
+// @Produce		json
+// @Param			limit	query		integer	false	"Number of mentions per page (default: 10)"
+// @Param			offset	query		integer	false	"Number of mentions to skip (default: 0)"
+// @Param			cursor	query		string	false	"Opaque cursor from a previous page's next_cursor; switches the response to a paginated envelope"
+// @Param			include_total	query		boolean	false	"Include the total item count"
+// @Success		200		{array}		view.WebmentionResponse	"List of mentions (view.PageResponse envelope when cursor is given)"
+// @Failure		400		{object}	map[string]string	"Invalid cursor"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		403		{object}	map[string]string	"Forbidden - admin role required"
+// @Router			/api/admin/webmentions [get]
+// @Security		BearerAuth
+func (h *WebmentionHandler) ListWebmentionsAwaitingModeration(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	page, err := parsePageGin(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	mentions, next, err := h.mentionRepo.ListAwaitingModeration(c, page)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list webmentions"})
+		return
+	}
+
+	var total *int
+	if wantsTotal(c) {
+		count, err := h.mentionRepo.CountAwaitingModeration(c)
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count webmentions"})
+			return
+		}
+		total = &count
+	}
+
+	writePageGin(c, view.ToWebmentionResponses(mentions), next, total)
+}
+
+// ApproveWebmention handles POST /api/admin/webmentions/:id/approve (admin only)
+// @Summary		Approve a webmention
+// @Description	Show a verified webmention on its post (admin only)
+// @Tags			Webmentions
+// @Param			id	path	string	true	"Webmention ID (UUID)"
+// @Success		204			"Webmention approved"
+// @Failure		400	{object}	map[string]string	"Invalid request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string	"Verified webmention not found"
+// @Router			/api/admin/webmentions/{id}/approve [post]
+// @Security		BearerAuth
+func (h *WebmentionHandler) ApproveWebmention(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	if err := h.mentionRepo.Approve(c, id); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteWebmention handles DELETE /api/admin/webmentions/:id (admin only)
+// @Summary		Delete a webmention
+// @Description	Reject or remove a webmention (admin only). The source can send it again, which queues it for moderation anew.
+// @Tags			Webmentions
+// @Param			id	path	string	true	"Webmention ID (UUID)"
+// @Success		204			"Webmention deleted"
+// @Failure		400	{object}	map[string]string	"Invalid request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Forbidden - admin role required"
+// @Failure		404	{object}	map[string]string	"Webmention not found"
+// @Router			/api/admin/webmentions/{id} [delete]
+// @Security		BearerAuth
+func (h *WebmentionHandler) DeleteWebmention(c *gin.Context) {
+	user := c.MustGet("user").(*auth.User)
+	if !user.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+		return
+	}
+
+	if err := h.mentionRepo.Delete(c, id); err != nil {
+		status, message := statusCodeFromError(err)
+		if status == http.StatusInternalServerError {
+			c.Error(err)
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,104 @@
+// Package ical writes iCalendar (RFC 5545) feeds using only the standard library
+package ical
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest content line allowed before folding, excluding the CRLF
+const maxLineOctets = 75
+
+// utcFormat is the DATE-TIME form for UTC times
+const utcFormat = "20060102T150405Z"
+
+// Calendar is a named collection of events
+type Calendar struct {
+	ProductID string // PRODID, such as "-//sochoa.dev//Talks//EN"
+	Name      string // Shown by clients that support X-WR-CALNAME
+	Events    []Event
+}
+
+// Event is a single VEVENT
+type Event struct {
+	UID         string // Stable across feed refreshes so clients update rather than duplicate the event
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+}
+
+// Bytes renders the calendar with CRLF line endings and long lines folded
+func (c *Calendar) Bytes() []byte {
+	var b strings.Builder
+	line := func(name, value string) {
+		writeLine(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", escape(c.ProductID))
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+
+	for _, event := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(event.UID))
+		line("DTSTAMP", event.Stamp.UTC().Format(utcFormat))
+		line("DTSTART", event.Start.UTC().Format(utcFormat))
+		if !event.End.IsZero() {
+			line("DTEND", event.End.UTC().Format(utcFormat))
+		}
+		line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		if event.Location != "" {
+			line("LOCATION", escape(event.Location))
+		}
+		if event.URL != "" {
+			line("URL", event.URL)
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return []byte(b.String())
+}
+
+// escape quotes the characters that are special in TEXT values
+func escape(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		`;`, `\;`,
+		`,`, `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// writeLine writes a content line, folding it into continuation lines of at most 75 octets
+// Folds fall between characters so multi-byte UTF-8 sequences are never split
+func writeLine(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1 // The leading space counts towards the continuation line
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,95 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscape(t *testing.T) {
+	got := escape("Go, Rust; C\\C++\r\nand more\n")
+	want := `Go\, Rust\; C\\C++\nand more\n`
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWriteLineFolds(t *testing.T) {
+	var b strings.Builder
+	long := "SUMMARY:" + strings.Repeat("é", 100)
+	writeLine(&b, long)
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected a folded line, got %q", b.String())
+	}
+
+	var unfolded strings.Builder
+	for i, line := range lines {
+		if len(line) > maxLineOctets {
+			t.Errorf("line %d is %d octets", i, len(line))
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d splits a UTF-8 sequence", i)
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Fatalf("continuation line %d does not start with a space", i)
+			}
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+
+	if unfolded.String() != long {
+		t.Errorf("unfolding did not restore the original line")
+	}
+}
+
+func TestCalendarBytes(t *testing.T) {
+	start := time.Date(2026, 5, 14, 10, 0, 0, 0, time.FixedZone("CDT", -5*3600))
+	cal := Calendar{
+		ProductID: "-//sochoa.dev//Talks//EN",
+		Name:      "Talks",
+		Events: []Event{{
+			UID:      "abc@sochoa.dev",
+			Stamp:    time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			Start:    start,
+			End:      start.Add(time.Hour),
+			Summary:  "Boring infrastructure, revisited",
+			Location: "Chicago",
+			URL:      "https://gophercon.com",
+		}},
+	}
+
+	got := string(cal.Bytes())
+
+	for _, line := range []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//sochoa.dev//Talks//EN",
+		"BEGIN:VEVENT",
+		"UID:abc@sochoa.dev",
+		"DTSTAMP:20260401T000000Z",
+		"DTSTART:20260514T150000Z",
+		"DTEND:20260514T160000Z",
+		`SUMMARY:Boring infrastructure\, revisited`,
+		"LOCATION:Chicago",
+		"URL:https://gophercon.com",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	} {
+		if !strings.Contains(got, line+"\r\n") {
+			t.Errorf("expected line %q in:\n%s", line, got)
+		}
+	}
+
+	if strings.Contains(strings.ReplaceAll(got, "\r\n", ""), "\n") {
+		t.Errorf("expected CRLF line endings only")
+	}
+
+	if strings.Contains(got, "DESCRIPTION") {
+		t.Errorf("expected no DESCRIPTION for an event without one")
+	}
+}
@@ -0,0 +1,18 @@
+package lambda
+
+import (
+	"encoding/json"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// IsScheduledEvent reports whether a raw Lambda payload is an EventBridge scheduled event
+// rather than an API Gateway request
+func IsScheduledEvent(payload json.RawMessage) bool {
+	var event events.CloudWatchEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return false
+	}
+
+	return event.Source == "aws.events" && event.DetailType == "Scheduled Event"
+}
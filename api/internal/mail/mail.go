@@ -0,0 +1,129 @@
+// Package mail composes plain-text email and delivers it through pluggable mailers
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Mailer delivers email messages
+type Mailer interface {
+	// Send delivers msg to its recipient
+	Send(ctx context.Context, msg Message) error
+}
+
+// Message is a plain-text email to a single recipient
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Body    string
+	Headers map[string]string // Extra headers, such as List-Unsubscribe
+}
+
+// reservedHeaders are written by Bytes and may not be overridden through Headers
+var reservedHeaders = map[string]bool{
+	"From":                      true,
+	"To":                        true,
+	"Subject":                   true,
+	"Date":                      true,
+	"Mime-Version":              true,
+	"Content-Type":              true,
+	"Content-Transfer-Encoding": true,
+}
+
+// Validate checks the addresses and rejects header values that could inject further headers
+func (m Message) Validate() error {
+	for name, address := range map[string]string{"from": m.From, "to": m.To} {
+		parsed, err := mail.ParseAddress(address)
+		if err != nil || parsed.Address != address {
+			return fmt.Errorf("invalid %s address %q", name, address)
+		}
+	}
+
+	if strings.ContainsAny(m.Subject, "\r\n") {
+		return fmt.Errorf("subject must be a single line")
+	}
+
+	seen := make(map[string]bool, len(m.Headers))
+	for name, value := range m.Headers {
+		if seen[canonicalHeader(name)] {
+			return fmt.Errorf("header %s is given more than once", name)
+		}
+		seen[canonicalHeader(name)] = true
+		if name == "" || strings.ContainsAny(name, ":\r\n ") {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+		if reservedHeaders[canonicalHeader(name)] {
+			return fmt.Errorf("header %s is set by the mailer", name)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("header %s must be a single line", name)
+		}
+	}
+
+	return nil
+}
+
+// Bytes renders the message in RFC 5322 format with a quoted-printable UTF-8 body
+// Extra headers are written in name order so the output is deterministic for a given date
+func (m Message) Bytes(date time.Time) ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&b, "%s: %s\r\n", name, value)
+	}
+
+	header("From", m.From)
+	header("To", m.To)
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+
+	extra := make(map[string]string, len(m.Headers))
+	names := make([]string, 0, len(m.Headers))
+	for name, value := range m.Headers {
+		name = canonicalHeader(name)
+		extra[name] = value
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		header(name, extra[name])
+	}
+	b.WriteString("\r\n")
+
+	body := strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n")
+	w := quotedprintable.NewWriter(&b)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return nil, fmt.Errorf("failed to encode body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode body: %w", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// canonicalHeader capitalizes a header name the way mail headers are usually written
+func canonicalHeader(name string) string {
+	parts := strings.Split(strings.ToLower(name), "-")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "-")
+}
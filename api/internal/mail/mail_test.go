@@ -0,0 +1,199 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testMessage() Message {
+	return Message{
+		From:    "newsletter@sochoa.dev",
+		To:      "reader@example.com",
+		Subject: "Confirm your subscription — sochoa.dev",
+		Body:    "Hello,\nconfirm here: https://sochoa.dev/newsletter/confirm?token=abc\n",
+		Headers: map[string]string{
+			"list-unsubscribe":      "<https://sochoa.dev/api/newsletter/unsubscribe?token=xyz>",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}
+}
+
+func TestMessageValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(m *Message)
+		shouldErr bool
+	}{
+		{name: "valid message", modify: func(m *Message) {}, shouldErr: false},
+		{name: "invalid recipient", modify: func(m *Message) { m.To = "not an address" }, shouldErr: true},
+		{name: "display name recipient", modify: func(m *Message) { m.To = "Reader <reader@example.com>" }, shouldErr: true},
+		{name: "injected recipient", modify: func(m *Message) { m.To = "reader@example.com\r\nBcc: x@example.com" }, shouldErr: true},
+		{name: "multi-line subject", modify: func(m *Message) { m.Subject = "Hi\r\nBcc: x@example.com" }, shouldErr: true},
+		{name: "multi-line header", modify: func(m *Message) { m.Headers["X-Note"] = "a\nb" }, shouldErr: true},
+		{name: "reserved header", modify: func(m *Message) { m.Headers["content-type"] = "text/html" }, shouldErr: true},
+		{name: "invalid header name", modify: func(m *Message) { m.Headers["X Note"] = "a" }, shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := testMessage()
+			tt.modify(&msg)
+			err := msg.Validate()
+			if tt.shouldErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.shouldErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMessageBytes(t *testing.T) {
+	date := time.Date(2026, 5, 14, 15, 0, 0, 0, time.UTC)
+	data, err := testMessage().Bytes(date)
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+
+	head, body, ok := strings.Cut(string(data), "\r\n\r\n")
+	if !ok {
+		t.Fatalf("expected a blank line between headers and body")
+	}
+
+	wantHeaders := []string{
+		"From: newsletter@sochoa.dev",
+		"To: reader@example.com",
+		"Subject: =?utf-8?q?Confirm_your_subscription_=E2=80=94_sochoa.dev?=",
+		"Date: Thu, 14 May 2026 15:00:00 +0000",
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: quoted-printable",
+		"List-Unsubscribe: <https://sochoa.dev/api/newsletter/unsubscribe?token=xyz>",
+		"List-Unsubscribe-Post: List-Unsubscribe=One-Click",
+	}
+	if got := strings.Split(head, "\r\n"); strings.Join(got, "\n") != strings.Join(wantHeaders, "\n") {
+		t.Errorf("unexpected headers:\n%s", strings.Join(got, "\n"))
+	}
+
+	decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(body)))
+	if err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if want := "Hello,\r\nconfirm here: https://sochoa.dev/newsletter/confirm?token=abc\r\n"; string(decoded) != want {
+		t.Errorf("expected body %q, got %q", want, decoded)
+	}
+}
+
+func TestMemory(t *testing.T) {
+	mailer := NewMemory()
+	if err := mailer.Send(context.Background(), testMessage()); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	invalid := testMessage()
+	invalid.To = ""
+	if err := mailer.Send(context.Background(), invalid); err == nil {
+		t.Errorf("expected an invalid message to be rejected")
+	}
+
+	sent := mailer.Sent()
+	if len(sent) != 1 || sent[0].To != "reader@example.com" {
+		t.Errorf("expected one message to reader@example.com, got %+v", sent)
+	}
+}
+
+func TestSMTP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	type session struct {
+		commands []string
+		data     string
+	}
+	done := make(chan session, 1)
+
+	// A minimal SMTP server that accepts a single message without TLS or authentication
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		text := textproto.NewConn(conn)
+		var s session
+		text.PrintfLine("220 localhost ready")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				done <- s
+				return
+			}
+			command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			s.commands = append(s.commands, line)
+			switch command {
+			case "EHLO":
+				text.PrintfLine("250-localhost")
+				text.PrintfLine("250 8BITMIME")
+			case "DATA":
+				text.PrintfLine("354 go ahead")
+				lines, _ := text.ReadDotLines()
+				s.data = strings.Join(lines, "\n")
+				text.PrintfLine("250 queued")
+			case "QUIT":
+				text.PrintfLine("221 bye")
+				done <- s
+				return
+			default:
+				text.PrintfLine("250 ok")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	mailer, err := NewSMTP(SMTPConfig{Host: host, Port: portNumber})
+	if err != nil {
+		t.Fatalf("NewSMTP failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := mailer.Send(ctx, testMessage()); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	s := <-done
+	commands := strings.Join(s.commands, "\n")
+	for _, want := range []string{"MAIL FROM:<newsletter@sochoa.dev>", "RCPT TO:<reader@example.com>", "DATA", "QUIT"} {
+		if !strings.Contains(commands, want) {
+			t.Errorf("expected command %q, got:\n%s", want, commands)
+		}
+	}
+
+	headers, err := textproto.NewReader(bufio.NewReader(strings.NewReader(s.data + "\n"))).ReadMIMEHeader()
+	if err != nil && err != io.EOF {
+		t.Fatalf("failed to parse delivered message: %v", err)
+	}
+	if got := headers.Get("List-Unsubscribe-Post"); got != "List-Unsubscribe=One-Click" {
+		t.Errorf("expected the List-Unsubscribe-Post header to be delivered, got %q", got)
+	}
+}
+
+func TestNewSMTPRequiresHost(t *testing.T) {
+	if _, err := NewSMTP(SMTPConfig{}); err == nil {
+		t.Errorf("expected an error without a host")
+	}
+}
@@ -0,0 +1,36 @@
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory keeps messages instead of delivering them, for development and tests
+type Memory struct {
+	mu   sync.Mutex
+	sent []Message
+}
+
+// NewMemory creates an empty in-memory mailer
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Send records msg after validating it as a real mailer would
+func (m *Memory) Send(ctx context.Context, msg Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// Sent returns the messages sent so far, oldest first
+func (m *Memory) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.sent...)
+}
@@ -0,0 +1,182 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // Register the GIF decoder for image.Decode
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strings"
+)
+
+// maxDecodePixels bounds the images decoded for variants so a small file cannot expand into gigabytes of pixels
+const maxDecodePixels = 50_000_000
+
+// AllowedTypes maps the content types accepted for upload to the extension files are stored with
+var AllowedTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// VariantSpec describes a resized copy generated for uploaded images
+// With a Height the image is centre-cropped to exactly Width x Height; otherwise it is scaled to Width keeping its aspect ratio
+type VariantSpec struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// DefaultVariants are a square thumbnail plus the widths offered in srcset attributes
+var DefaultVariants = []VariantSpec{
+	{Name: "thumb", Width: 320, Height: 320},
+	{Name: "w480", Width: 480},
+	{Name: "w960", Width: 960},
+	{Name: "w1600", Width: 1600},
+}
+
+// Sniff detects a file's content type from its leading bytes, ignoring any name or client-supplied type
+func Sniff(data []byte) string {
+	contentType, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	return strings.TrimSpace(contentType)
+}
+
+// decodableTypes are the uploaded formats the standard library can decode for variants
+var decodableTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// decodeImage decodes data as an image, refusing images too large to process safely
+// Animated GIFs decode to their first frame
+func decodeImage(data []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image header: %w", err)
+	}
+	if config.Width*config.Height > maxDecodePixels {
+		return nil, fmt.Errorf("image is %dx%d, larger than the %d pixels that can be processed", config.Width, config.Height, maxDecodePixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	return img, nil
+}
+
+// variantSize returns the dimensions of spec applied to a w x h image, and false when the
+// variant would not be smaller than the original
+func variantSize(spec VariantSpec, w, h int) (int, int, bool) {
+	if spec.Height > 0 {
+		return spec.Width, spec.Height, w > spec.Width || h > spec.Height
+	}
+	if w <= spec.Width {
+		return 0, 0, false
+	}
+
+	height := (h*spec.Width + w/2) / w
+	if height < 1 {
+		height = 1
+	}
+	return spec.Width, height, true
+}
+
+// renderVariant produces the w x h variant of img, centre-cropping first when the aspect ratio differs
+func renderVariant(img image.Image, w, h int) image.Image {
+	bounds := img.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+
+	// Crop to the target aspect ratio, keeping the centre
+	crop := bounds
+	if sw*h > sh*w {
+		cw := max(sh*w/h, 1)
+		crop.Min.X += (sw - cw) / 2
+		crop.Max.X = crop.Min.X + cw
+	} else if sw*h < sh*w {
+		ch := max(sw*h/w, 1)
+		crop.Min.Y += (sh - ch) / 2
+		crop.Max.Y = crop.Min.Y + ch
+	}
+
+	return Resize(img, crop, w, h)
+}
+
+// Resize scales the rect region of src to w x h, averaging every source pixel each output pixel covers
+// Averaging premultiplied colours keeps downscaled images sharp and transparent edges clean
+func Resize(src image.Image, rect image.Rectangle, w, h int) *image.RGBA {
+	source := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(source, source.Bounds(), src, rect.Min, draw.Src)
+
+	horizontal := resizeAxis(source.Pix, source.Stride, rect.Dx(), rect.Dy(), w, true)
+	vertical := resizeAxis(horizontal, w*4, w, rect.Dy(), h, false)
+
+	return &image.RGBA{Pix: vertical, Stride: w * 4, Rect: image.Rect(0, 0, w, h)}
+}
+
+// resizeAxis resamples an RGBA pixel buffer of sw x sh along one axis to n pixels
+func resizeAxis(pix []byte, stride, sw, sh, n int, horizontal bool) []byte {
+	length, lines := sh, sw
+	outW, outH := sw, n
+	if horizontal {
+		length, lines = sw, sh
+		outW, outH = n, sh
+	}
+	out := make([]byte, outW*outH*4)
+	scale := float64(length) / float64(n)
+
+	for line := 0; line < lines; line++ {
+		for i := 0; i < n; i++ {
+			start, end := float64(i)*scale, float64(i+1)*scale
+			var sum [4]float64
+			total := 0.0
+
+			for s := int(start); s < length && float64(s) < end; s++ {
+				weight := min(end, float64(s+1)) - max(start, float64(s))
+				if weight <= 0 {
+					continue
+				}
+				offset := line*stride + s*4
+				if !horizontal {
+					offset = s*stride + line*4
+				}
+				for c := 0; c < 4; c++ {
+					sum[c] += float64(pix[offset+c]) * weight
+				}
+				total += weight
+			}
+
+			offset := (line*outW + i) * 4
+			if !horizontal {
+				offset = (i*outW + line) * 4
+			}
+			for c := 0; c < 4; c++ {
+				out[offset+c] = uint8(sum[c]/total + 0.5)
+			}
+		}
+	}
+
+	return out
+}
+
+// encodeVariant encodes a variant in the format suited to its source: JPEG photos stay JPEG, everything else becomes PNG
+func encodeVariant(img image.Image, sourceType string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if sourceType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", fmt.Errorf("failed to encode variant: %w", err)
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", fmt.Errorf("failed to encode variant: %w", err)
+	}
+	return buf.Bytes(), "image/png", nil
+}
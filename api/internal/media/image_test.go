@@ -0,0 +1,130 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestSniff(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"png", buf.Bytes(), "image/png"},
+		{"jpeg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"), "image/jpeg"},
+		{"gif", []byte("GIF89a\x01\x00\x01\x00"), "image/gif"},
+		{"html", []byte("<!DOCTYPE html><html></html>"), "text/html"},
+		{"text", []byte("hello"), "text/plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sniff(tt.data); got != tt.want {
+				t.Errorf("Sniff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVariantSize(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         VariantSpec
+		w, h         int
+		wantW, wantH int
+		wantOK       bool
+	}{
+		{"width scales height", VariantSpec{Name: "w480", Width: 480}, 1920, 1080, 480, 270, true},
+		{"width not smaller", VariantSpec{Name: "w960", Width: 960}, 800, 600, 0, 0, false},
+		{"width equal", VariantSpec{Name: "w480", Width: 480}, 480, 300, 0, 0, false},
+		{"height rounds", VariantSpec{Name: "w480", Width: 480}, 1000, 333, 480, 160, true},
+		{"tiny height clamps", VariantSpec{Name: "w480", Width: 480}, 10000, 1, 480, 1, true},
+		{"crop", VariantSpec{Name: "thumb", Width: 320, Height: 320}, 1920, 1080, 320, 320, true},
+		{"crop one side larger", VariantSpec{Name: "thumb", Width: 320, Height: 320}, 200, 400, 320, 320, true},
+		{"crop not smaller", VariantSpec{Name: "thumb", Width: 320, Height: 320}, 320, 200, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h, ok := variantSize(tt.spec, tt.w, tt.h)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && (w != tt.wantW || h != tt.wantH) {
+				t.Errorf("size = %dx%d, want %dx%d", w, h, tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestRenderVariant(t *testing.T) {
+	// Left half red, right half blue
+	src := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= 200 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			src.SetRGBA(x, y, c)
+		}
+	}
+
+	t.Run("scale keeps colours", func(t *testing.T) {
+		out := renderVariant(src, 100, 50)
+		if got := out.Bounds(); got.Dx() != 100 || got.Dy() != 50 {
+			t.Fatalf("bounds = %v, want 100x50", got)
+		}
+		if got := color.RGBAModel.Convert(out.At(10, 25)).(color.RGBA); got != (color.RGBA{R: 255, A: 255}) {
+			t.Errorf("left pixel = %v, want red", got)
+		}
+		if got := color.RGBAModel.Convert(out.At(90, 25)).(color.RGBA); got != (color.RGBA{B: 255, A: 255}) {
+			t.Errorf("right pixel = %v, want blue", got)
+		}
+		// The boundary column straddles both halves and averages them
+		if got := color.RGBAModel.Convert(out.At(50, 25)).(color.RGBA); got.R == 0 && got.B == 0 {
+			t.Errorf("boundary pixel = %v, want a blend", got)
+		}
+	})
+
+	t.Run("square crop keeps centre", func(t *testing.T) {
+		out := renderVariant(src, 20, 20)
+		if got := out.Bounds(); got.Dx() != 20 || got.Dy() != 20 {
+			t.Fatalf("bounds = %v, want 20x20", got)
+		}
+		// The centre 200x200 crop spans x 100..300, so both colours survive
+		if got := color.RGBAModel.Convert(out.At(2, 10)).(color.RGBA); got != (color.RGBA{R: 255, A: 255}) {
+			t.Errorf("left pixel = %v, want red", got)
+		}
+		if got := color.RGBAModel.Convert(out.At(17, 10)).(color.RGBA); got != (color.RGBA{B: 255, A: 255}) {
+			t.Errorf("right pixel = %v, want blue", got)
+		}
+	})
+}
+
+func TestDecodeImageRejectsHugeDimensions(t *testing.T) {
+	// A PNG whose header claims 100000x100000 pixels; only the header is read before refusing it
+	ihdr := []byte("IHDR\x00\x01\x86\xa0\x00\x01\x86\xa0\x08\x06\x00\x00\x00")
+	var data []byte
+	data = append(data, "\x89PNG\r\n\x1a\n\x00\x00\x00\x0d"...)
+	data = append(data, ihdr...)
+	data = binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(ihdr))
+
+	_, err := decodeImage(data)
+	if err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Fatalf("expected an oversized image error, got %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/storage"
+)
+
+// DefaultMaxUploadSize is the largest file accepted when no limit is configured
+const DefaultMaxUploadSize = 10 << 20
+
+// Options configures a media library
+type Options struct {
+	MaxUploadSize int64         // Largest accepted upload in bytes
+	BaseURL       string        // Public URL prefix files are served under, e.g. /media or a CDN origin
+	Variants      []VariantSpec // Resized copies generated for images; nil uses DefaultVariants
+}
+
+// Library stores uploaded files and their variants and records them in the media repository
+type Library struct {
+	repo    *model.MediaRepository
+	store   storage.Storage
+	options Options
+}
+
+// NewLibrary creates a media library backed by the given repository and storage
+func NewLibrary(repo *model.MediaRepository, store storage.Storage, options Options) *Library {
+	if options.MaxUploadSize <= 0 {
+		options.MaxUploadSize = DefaultMaxUploadSize
+	}
+	if options.BaseURL == "" {
+		options.BaseURL = "/media"
+	}
+	if options.Variants == nil {
+		options.Variants = DefaultVariants
+	}
+	options.BaseURL = strings.TrimSuffix(options.BaseURL, "/")
+
+	return &Library{repo: repo, store: store, options: options}
+}
+
+// MaxUploadSize returns the largest accepted upload in bytes
+func (l *Library) MaxUploadSize() int64 {
+	return l.options.MaxUploadSize
+}
+
+// URL returns the public URL of a stored file
+func (l *Library) URL(key string) string {
+	return l.options.BaseURL + "/" + key
+}
+
+// Upload stores a file and its variants and records the asset
+// A file whose content was uploaded before returns the existing asset and false
+func (l *Library) Upload(ctx context.Context, filename string, data []byte) (*model.MediaAsset, bool, error) {
+	if len(data) == 0 {
+		return nil, false, apierrors.ValidationError{Message: "file is empty"}
+	}
+	if int64(len(data)) > l.options.MaxUploadSize {
+		return nil, false, apierrors.ValidationError{Message: fmt.Sprintf("file exceeds the %d byte upload limit", l.options.MaxUploadSize)}
+	}
+
+	contentType := Sniff(data)
+	ext, allowed := AllowedTypes[contentType]
+	if !allowed {
+		return nil, false, apierrors.ValidationError{Message: fmt.Sprintf("unsupported file type '%s'", contentType)}
+	}
+
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	existing, err := l.repo.GetBySHA256(ctx, digest)
+	if err == nil {
+		return existing, false, nil
+	}
+	var notFound apierrors.NotFoundError
+	if !errors.As(err, &notFound) {
+		return nil, false, err
+	}
+
+	asset := &model.MediaAsset{
+		ID:          uuid.New(),
+		SHA256:      digest,
+		Filename:    path.Base(strings.ReplaceAll(filename, "\\", "/")),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		StorageKey:  digest + "/original" + ext,
+	}
+
+	var variants [][]byte
+	if decodableTypes[contentType] {
+		if variants, err = l.renderVariants(asset, data); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if err := asset.Validate(); err != nil {
+		return nil, false, err
+	}
+
+	if err := l.store.Put(ctx, asset.StorageKey, data, contentType); err != nil {
+		return nil, false, err
+	}
+	for i, variant := range asset.Variants {
+		if err := l.store.Put(ctx, variant.StorageKey, variants[i], variant.ContentType); err != nil {
+			l.removeFiles(ctx, asset)
+			return nil, false, err
+		}
+	}
+
+	if err := l.repo.Create(ctx, asset); err != nil {
+		// Another upload of the same file won the race; its files share these keys, so keep them
+		var conflict apierrors.ConflictError
+		if errors.As(err, &conflict) {
+			if existing, getErr := l.repo.GetBySHA256(ctx, digest); getErr == nil {
+				return existing, false, nil
+			}
+		}
+		l.removeFiles(ctx, asset)
+		return nil, false, err
+	}
+
+	return asset, true, nil
+}
+
+// renderVariants decodes an image, records its dimensions and variants on the asset, and
+// returns each variant's encoded bytes in the same order as asset.Variants
+func (l *Library) renderVariants(asset *model.MediaAsset, data []byte) ([][]byte, error) {
+	img, err := decodeImage(data)
+	if err != nil {
+		return nil, apierrors.ValidationError{Message: "file is not a valid image: " + err.Error()}
+	}
+
+	bounds := img.Bounds()
+	asset.Width, asset.Height = bounds.Dx(), bounds.Dy()
+
+	var encodedVariants [][]byte
+	for _, spec := range l.options.Variants {
+		w, h, ok := variantSize(spec, asset.Width, asset.Height)
+		if !ok {
+			continue
+		}
+
+		encoded, contentType, err := encodeVariant(renderVariant(img, w, h), asset.ContentType)
+		if err != nil {
+			return nil, err
+		}
+
+		asset.Variants = append(asset.Variants, model.MediaVariant{
+			Name:        spec.Name,
+			Width:       w,
+			Height:      h,
+			ContentType: contentType,
+			Size:        int64(len(encoded)),
+			StorageKey:  asset.SHA256 + "/" + spec.Name + AllowedTypes[contentType],
+		})
+		encodedVariants = append(encodedVariants, encoded)
+	}
+
+	return encodedVariants, nil
+}
+
+// List retrieves a page of assets, newest first
+func (l *Library) List(ctx context.Context, page model.PageRequest) ([]model.MediaAsset, *model.Cursor, error) {
+	return l.repo.List(ctx, page)
+}
+
+// Count counts all assets
+func (l *Library) Count(ctx context.Context) (int, error) {
+	return l.repo.Count(ctx)
+}
+
+// Get retrieves an asset and the posts, translations and pages referencing it
+func (l *Library) Get(ctx context.Context, id uuid.UUID) (*model.MediaAsset, []model.MediaReference, error) {
+	asset, err := l.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	references, err := l.repo.References(ctx, asset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return asset, references, nil
+}
+
+// References finds the posts, translations and pages referencing each asset, keyed by asset ID
+func (l *Library) References(ctx context.Context, assets []model.MediaAsset) (map[uuid.UUID][]model.MediaReference, error) {
+	references := make(map[uuid.UUID][]model.MediaReference, len(assets))
+	for i := range assets {
+		refs, err := l.repo.References(ctx, &assets[i])
+		if err != nil {
+			return nil, err
+		}
+		references[assets[i].ID] = refs
+	}
+	return references, nil
+}
+
+// Delete removes an asset and its stored files
+// An asset still referenced by posts, translations or pages is only deleted when force is set; otherwise the references are
+// returned with a conflict error
+func (l *Library) Delete(ctx context.Context, id uuid.UUID, force bool) ([]model.MediaReference, error) {
+	asset, references, err := l.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(references) > 0 && !force {
+		return references, apierrors.ConflictError{Message: fmt.Sprintf("media asset is referenced by %d post(s), translation(s) or page(s)", len(references))}
+	}
+
+	if err := l.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return nil, l.removeFiles(ctx, asset)
+}
+
+// Open opens a stored file by key
+func (l *Library) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if storage.ValidateKey(key) != nil {
+		return nil, storage.ErrNotFound
+	}
+	return l.store.Get(ctx, key)
+}
+
+// removeFiles deletes an asset's original and variants from storage
+func (l *Library) removeFiles(ctx context.Context, asset *model.MediaAsset) error {
+	var failed error
+	keys := []string{asset.StorageKey}
+	for _, variant := range asset.Variants {
+		keys = append(keys, variant.StorageKey)
+	}
+
+	for _, key := range keys {
+		if err := l.store.Delete(ctx, key); err != nil && failed == nil {
+			failed = err
+		}
+	}
+
+	return failed
+}
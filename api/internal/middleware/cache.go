@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CachePolicy describes how long shared caches such as CloudFront may keep a public response
+type CachePolicy struct {
+	MaxAge               time.Duration
+	StaleWhileRevalidate time.Duration
+}
+
+// CacheControl renders the policy as a Cache-Control header value
+// A zero MaxAge still lets caches store the response, but they must revalidate it on every use
+func (p CachePolicy) CacheControl() string {
+	if p.MaxAge <= 0 {
+		return "no-cache"
+	}
+
+	value := fmt.Sprintf("public, max-age=%d", int(p.MaxAge.Seconds()))
+	if p.StaleWhileRevalidate > 0 {
+		value += fmt.Sprintf(", stale-while-revalidate=%d", int(p.StaleWhileRevalidate.Seconds()))
+	}
+	return value
+}
+
+// HTTPCacheGin returns a Gin middleware for public read routes
+// It buffers successful GET responses, tags them with a strong ETag of the body and the route's Cache-Control
+// policy, and answers If-None-Match or If-Modified-Since with 304 when the client's copy is current.
+// Handlers can set Last-Modified to enable If-Modified-Since when it covers everything in the body,
+// or set Cache-Control themselves to opt out.
+// Requests carrying credentials are never marked cacheable.
+func HTTPCacheGin(policy CachePolicy) gin.HandlerFunc {
+	cacheControl := policy.CacheControl()
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		// Responses may differ by who is asking, so shared caches must key on credentials
+		c.Writer.Header().Add("Vary", "Authorization")
+
+		if c.GetHeader("Authorization") != "" {
+			c.Header("Cache-Control", "private, no-store")
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		buffer := &bufferedWriter{ResponseWriter: original}
+		c.Writer = buffer
+		c.Next()
+		c.Writer = original
+
+		header := original.Header()
+		if original.Status() != http.StatusOK || header.Get("Cache-Control") != "" {
+			original.Write(buffer.body.Bytes())
+			return
+		}
+
+		if header.Get("ETag") == "" {
+			sum := sha256.Sum256(buffer.body.Bytes())
+			header.Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+		}
+		header.Set("Cache-Control", cacheControl)
+
+		if notModified(c.Request, header) {
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			original.WriteHeader(http.StatusNotModified)
+			original.WriteHeaderNow()
+			return
+		}
+
+		original.Write(buffer.body.Bytes())
+	}
+}
+
+// notModified evaluates the request's conditional headers against the response validators
+// If-None-Match takes precedence, and If-Modified-Since is only consulted when it is absent
+func notModified(r *http.Request, header http.Header) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		return etagListContains(match, header.Get("ETag"))
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(header.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// etagListContains reports whether an If-None-Match list matches etag
+// If-None-Match uses weak comparison, so W/"x" matches "x"
+func etagListContains(list, etag string) bool {
+	if strings.TrimSpace(list) == "*" {
+		return true
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(list, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedWriter holds the response body back so it can be hashed before anything is sent
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
@@ -9,7 +9,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/sochoa/sochoa.dev/api/internal/auth"
 	"github.com/sochoa/sochoa.dev/api/internal/logger"
 )
@@ -259,3 +261,150 @@ func TestRecovery(t *testing.T) {
 		t.Error("expected error in response")
 	}
 }
+
+func TestRequireIfMatchGin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.PUT("/", RequireIfMatchGin(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest("PUT", "/", nil))
+	if rec.Code != http.StatusPreconditionRequired {
+		t.Errorf("expected status %d without If-Match, got %d", http.StatusPreconditionRequired, rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/", nil)
+	req.Header.Set("If-Match", `"1"`)
+	engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d with If-Match, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestCORSGinExposesHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/", CORSGin(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Origin", "http://localhost:5173")
+	engine.ServeHTTP(rec, req)
+
+	exposed := rec.Header().Get("Access-Control-Expose-Headers")
+	for _, header := range []string{"ETag", "Last-Modified", "Link", "X-Total-Count"} {
+		if !strings.Contains(exposed, header) {
+			t.Errorf("expected %s to be exposed, got %q", header, exposed)
+		}
+	}
+}
+
+func TestCachePolicyCacheControl(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy CachePolicy
+		want   string
+	}{
+		{"zero max age", CachePolicy{}, "no-cache"},
+		{"max age only", CachePolicy{MaxAge: time.Minute}, "public, max-age=60"},
+		{"stale while revalidate", CachePolicy{MaxAge: 5 * time.Minute, StaleWhileRevalidate: time.Hour}, "public, max-age=300, stale-while-revalidate=3600"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.CacheControl(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHTTPCacheGin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	modified := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	engine := gin.New()
+	engine.GET("/", HTTPCacheGin(CachePolicy{MaxAge: time.Minute, StaleWhileRevalidate: time.Hour}), func(c *gin.Context) {
+		c.Header("Last-Modified", modified.Format(http.TimeFormat))
+		c.JSON(http.StatusOK, gin.H{"hello": "world"})
+	})
+	engine.GET("/missing", HTTPCacheGin(CachePolicy{MaxAge: time.Minute}), func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	})
+
+	serve := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	etag := rec.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `"`) {
+		t.Errorf("expected a strong ETag, got %q", etag)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "public, max-age=60, stale-while-revalidate=3600" {
+		t.Errorf("unexpected Cache-Control %q", got)
+	}
+	if rec.Body.String() != `{"hello":"world"}` {
+		t.Errorf("unexpected body %q", rec.Body.String())
+	}
+
+	if again := serve("/", nil); again.Header().Get("ETag") != etag {
+		t.Errorf("expected the same content to get the same ETag")
+	}
+
+	rec = serve("/", map[string]string{"If-None-Match": `"other", ` + etag})
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("expected an empty 304 for a matching If-None-Match, got %d with %q", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("ETag") != etag || rec.Header().Get("Cache-Control") == "" {
+		t.Errorf("expected 304 to carry the validators and cache policy")
+	}
+
+	rec = serve("/", map[string]string{"If-None-Match": `"stale"`, "If-Modified-Since": modified.Format(http.TimeFormat)})
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected If-None-Match to take precedence over If-Modified-Since, got %d", rec.Code)
+	}
+
+	rec = serve("/", map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)})
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected 304 when not modified since, got %d", rec.Code)
+	}
+
+	rec = serve("/", map[string]string{"If-Modified-Since": modified.Add(-time.Hour).Format(http.TimeFormat)})
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 when modified since, got %d", rec.Code)
+	}
+
+	rec = serve("/", map[string]string{"Authorization": "Bearer token", "If-None-Match": etag})
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected authenticated requests to skip conditional handling, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "private, no-store" {
+		t.Errorf("expected authenticated responses to be uncacheable, got %q", got)
+	}
+	if rec.Header().Get("ETag") != "" {
+		t.Errorf("expected no content ETag on authenticated responses")
+	}
+
+	rec = serve("/missing", nil)
+	if rec.Code != http.StatusNotFound || rec.Header().Get("Cache-Control") != "" || rec.Header().Get("ETag") != "" {
+		t.Errorf("expected errors to pass through uncached, got %d with Cache-Control %q", rec.Code, rec.Header().Get("Cache-Control"))
+	}
+	if rec.Body.String() != `{"error":"not found"}` {
+		t.Errorf("unexpected error body %q", rec.Body.String())
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireIfMatchGin returns a Gin middleware that rejects writes without an If-Match header with 428,
+// so clients cannot overwrite a resource without saying which version they read
+func RequireIfMatchGin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("If-Match") == "" {
+			c.AbortWithStatusJSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required; send the ETag from your last read"})
+			return
+		}
+
+		c.Next()
+	}
+}
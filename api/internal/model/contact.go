@@ -161,58 +161,49 @@ func (r *ContactRepository) GetByID(ctx context.Context, id uuid.UUID) (*Contact
 	return submission, nil
 }
 
-// ListActive retrieves all contact submissions that haven't expired, ordered by creation time (newest first)
-func (r *ContactRepository) ListActive(ctx context.Context, limit int, offset int) ([]ContactSubmission, error) {
-	now := time.Now().UTC()
-	query := `
-		SELECT id, email, name, message, status, expires_at, created_at
-		FROM contact_submissions
-		WHERE expires_at > $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
-	`
+// contactOrder lists contact submissions newest first
+var contactOrder = pageOrder{timeColumn: "created_at", timeDesc: true, keyColumn: "id", keyDesc: true}
 
-	rows, err := r.db.QueryContext(ctx, query, now, limit, offset)
+// ListActive retrieves a page of contact submissions that haven't expired, ordered by creation time (newest first)
+// It returns the cursor for the following page, or nil on the last page
+func (r *ContactRepository) ListActive(ctx context.Context, page PageRequest) ([]ContactSubmission, *Cursor, error) {
+	submissions, next, err := r.list(ctx, `WHERE expires_at > $1`, []interface{}{time.Now().UTC()}, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list active contact submissions: %w", err)
+		return nil, nil, fmt.Errorf("failed to list active contact submissions: %w", err)
 	}
-	defer rows.Close()
+	return submissions, next, nil
+}
 
-	var submissions []ContactSubmission
-	for rows.Next() {
-		submission := ContactSubmission{}
-		err := rows.Scan(
-			&submission.ID,
-			&submission.Email,
-			&submission.Name,
-			&submission.Message,
-			&submission.Status,
-			&submission.ExpiresAt,
-			&submission.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan contact submission: %w", err)
-		}
-		submissions = append(submissions, submission)
+// ListByStatus retrieves a page of active contact submissions with a specific status
+func (r *ContactRepository) ListByStatus(ctx context.Context, status ContactStatus, page PageRequest) ([]ContactSubmission, *Cursor, error) {
+	submissions, next, err := r.list(ctx, `WHERE status = $1 AND expires_at > $2`, []interface{}{status, time.Now().UTC()}, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list contact submissions by status: %w", err)
 	}
+	return submissions, next, nil
+}
+
+// CountActive counts contact submissions that haven't expired
+func (r *ContactRepository) CountActive(ctx context.Context) (int, error) {
+	return r.count(ctx, `WHERE expires_at > $1`, time.Now().UTC())
+}
 
-	return submissions, rows.Err()
+// CountByStatus counts active contact submissions with a specific status
+func (r *ContactRepository) CountByStatus(ctx context.Context, status ContactStatus) (int, error) {
+	return r.count(ctx, `WHERE status = $1 AND expires_at > $2`, status, time.Now().UTC())
 }
 
-// ListByStatus retrieves all active contact submissions with a specific status
-func (r *ContactRepository) ListByStatus(ctx context.Context, status ContactStatus, limit int, offset int) ([]ContactSubmission, error) {
-	now := time.Now().UTC()
+// list runs a paginated contact submission query for the given WHERE clause
+func (r *ContactRepository) list(ctx context.Context, where string, args []interface{}, page PageRequest) ([]ContactSubmission, *Cursor, error) {
 	query := `
 		SELECT id, email, name, message, status, expires_at, created_at
 		FROM contact_submissions
-		WHERE status = $1 AND expires_at > $2
-		ORDER BY created_at DESC
-		LIMIT $3 OFFSET $4
-	`
+		` + where
+	query, args = contactOrder.apply(query, args, page)
 
-	rows, err := r.db.QueryContext(ctx, query, status, now, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list contact submissions by status: %w", err)
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -229,12 +220,28 @@ func (r *ContactRepository) ListByStatus(ctx context.Context, status ContactStat
 			&submission.CreatedAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan contact submission: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan contact submission: %w", err)
 		}
 		submissions = append(submissions, submission)
 	}
 
-	return submissions, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	submissions, next := trimPage(submissions, page.Limit, func(s *ContactSubmission) Cursor {
+		return Cursor{Time: s.CreatedAt, Key: s.ID.String()}
+	})
+	return submissions, next, nil
+}
+
+// count counts contact submissions matching the given WHERE clause
+func (r *ContactRepository) count(ctx context.Context, where string, args ...interface{}) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM contact_submissions `+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count contact submissions: %w", err)
+	}
+	return count, nil
 }
 
 // UpdateStatus updates a contact submission's status
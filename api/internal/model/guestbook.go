@@ -141,57 +141,52 @@ func (r *GuestbookRepository) GetByID(ctx context.Context, id uuid.UUID) (*Guest
 	return entry, nil
 }
 
-// ListApproved retrieves all approved guestbook entries, ordered by creation time (newest first)
-func (r *GuestbookRepository) ListApproved(ctx context.Context, limit int, offset int) ([]GuestbookEntry, error) {
-	query := `
-		SELECT id, user_provider, user_id, display_name, message, approved, deleted_at, created_at
-		FROM guestbook_entries
-		WHERE approved = true AND deleted_at IS NULL
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`
+// approvedOrder lists approved entries newest first; pendingOrder lists the moderation queue oldest first
+var (
+	approvedOrder = pageOrder{timeColumn: "created_at", timeDesc: true, keyColumn: "id", keyDesc: true}
+	pendingOrder  = pageOrder{timeColumn: "created_at", timeDesc: false, keyColumn: "id", keyDesc: false}
+)
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+// ListApproved retrieves a page of approved guestbook entries, ordered by creation time (newest first)
+// It returns the cursor for the following page, or nil on the last page
+func (r *GuestbookRepository) ListApproved(ctx context.Context, page PageRequest) ([]GuestbookEntry, *Cursor, error) {
+	entries, next, err := r.list(ctx, `WHERE approved = true AND deleted_at IS NULL`, approvedOrder, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list approved guestbook entries: %w", err)
+		return nil, nil, fmt.Errorf("failed to list approved guestbook entries: %w", err)
 	}
-	defer rows.Close()
+	return entries, next, nil
+}
 
-	var entries []GuestbookEntry
-	for rows.Next() {
-		entry := GuestbookEntry{}
-		err := rows.Scan(
-			&entry.ID,
-			&entry.UserProvider,
-			&entry.UserID,
-			&entry.DisplayName,
-			&entry.Message,
-			&entry.IsApproved,
-			&entry.DeletedAt,
-			&entry.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan guestbook entry: %w", err)
-		}
-		entries = append(entries, entry)
+// ListPending retrieves a page of pending (not approved) guestbook entries for moderation, oldest first
+func (r *GuestbookRepository) ListPending(ctx context.Context, page PageRequest) ([]GuestbookEntry, *Cursor, error) {
+	entries, next, err := r.list(ctx, `WHERE approved = false AND deleted_at IS NULL`, pendingOrder, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list pending guestbook entries: %w", err)
 	}
+	return entries, next, nil
+}
+
+// CountApproved counts approved guestbook entries
+func (r *GuestbookRepository) CountApproved(ctx context.Context) (int, error) {
+	return r.count(ctx, `WHERE approved = true AND deleted_at IS NULL`)
+}
 
-	return entries, rows.Err()
+// CountPending counts guestbook entries awaiting approval
+func (r *GuestbookRepository) CountPending(ctx context.Context) (int, error) {
+	return r.count(ctx, `WHERE approved = false AND deleted_at IS NULL`)
 }
 
-// ListPending retrieves all pending (not approved) guestbook entries for moderation
-func (r *GuestbookRepository) ListPending(ctx context.Context, limit int, offset int) ([]GuestbookEntry, error) {
+// list runs a paginated guestbook query for the given WHERE clause
+func (r *GuestbookRepository) list(ctx context.Context, where string, order pageOrder, page PageRequest) ([]GuestbookEntry, *Cursor, error) {
 	query := `
 		SELECT id, user_provider, user_id, display_name, message, approved, deleted_at, created_at
 		FROM guestbook_entries
-		WHERE approved = false AND deleted_at IS NULL
-		ORDER BY created_at ASC
-		LIMIT $1 OFFSET $2
-	`
+		` + where
+	query, args := order.apply(query, nil, page)
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pending guestbook entries: %w", err)
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -209,12 +204,28 @@ func (r *GuestbookRepository) ListPending(ctx context.Context, limit int, offset
 			&entry.CreatedAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan guestbook entry: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan guestbook entry: %w", err)
 		}
 		entries = append(entries, entry)
 	}
 
-	return entries, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	entries, next := trimPage(entries, page.Limit, func(e *GuestbookEntry) Cursor {
+		return Cursor{Time: e.CreatedAt, Key: e.ID.String()}
+	})
+	return entries, next, nil
+}
+
+// count counts guestbook entries matching the given WHERE clause
+func (r *GuestbookRepository) count(ctx context.Context, where string) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM guestbook_entries `+where).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count guestbook entries: %w", err)
+	}
+	return count, nil
 }
 
 // Approve marks a guestbook entry as approved
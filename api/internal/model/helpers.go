@@ -4,6 +4,11 @@ import "time"
 
 // GetStartOfDay returns the start of the current day in UTC
 func GetStartOfDay() time.Time {
-	now := time.Now().UTC()
-	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return startOfDay(time.Now())
+}
+
+// startOfDay returns midnight UTC on the day of t
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
 }
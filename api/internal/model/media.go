@@ -0,0 +1,362 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// MediaAsset is an uploaded file in the media library
+// Identical uploads share one asset, identified by the SHA-256 of their content
+type MediaAsset struct {
+	ID          uuid.UUID
+	SHA256      string
+	Filename    string
+	ContentType string
+	Size        int64
+	Width       int // Pixel dimensions; zero for files that are not decodable images
+	Height      int
+	StorageKey  string
+	Variants    []MediaVariant
+	CreatedAt   time.Time
+}
+
+// MediaVariant is a resized copy of an image asset, such as a thumbnail or a srcset width
+type MediaVariant struct {
+	Name        string
+	Width       int
+	Height      int
+	ContentType string
+	Size        int64
+	StorageKey  string
+}
+
+// MediaReferenceKind is the kind of content that references an asset
+type MediaReferenceKind string
+
+const (
+	MediaReferencePost        MediaReferenceKind = "post"
+	MediaReferenceTranslation MediaReferenceKind = "translation"
+	MediaReferencePage        MediaReferenceKind = "page"
+)
+
+// MediaReference is a post, post translation or page whose content mentions an asset
+type MediaReference struct {
+	Kind   MediaReferenceKind
+	ID     uuid.UUID // The post's ID for posts and translations, or the page's ID
+	Slug   string    // The post or translation slug, or the page path
+	Title  string
+	Locale string // Set for translations
+}
+
+var sha256Hex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// MediaRepository handles media asset data access
+type MediaRepository struct {
+	db db.QueryExecutor
+}
+
+// NewMediaRepository creates a new media repository
+func NewMediaRepository(db db.QueryExecutor) *MediaRepository {
+	return &MediaRepository{db: db}
+}
+
+// Validate ensures the asset meets business requirements
+func (a *MediaAsset) Validate() error {
+	if !sha256Hex.MatchString(a.SHA256) {
+		return apierrors.ValidationError{Message: "sha256 must be 64 lowercase hex characters"}
+	}
+
+	if strings.TrimSpace(a.Filename) == "" {
+		return apierrors.ValidationError{Message: "filename is required"}
+	}
+
+	if len(a.Filename) > 255 {
+		return apierrors.ValidationError{Message: "filename must be 255 characters or less"}
+	}
+
+	if a.ContentType == "" {
+		return apierrors.ValidationError{Message: "content type is required"}
+	}
+
+	if a.Size <= 0 {
+		return apierrors.ValidationError{Message: "file is empty"}
+	}
+
+	if a.StorageKey == "" {
+		return apierrors.ValidationError{Message: "storage key is required"}
+	}
+
+	return nil
+}
+
+// mediaOrder lists media assets newest first
+var mediaOrder = pageOrder{timeColumn: "created_at", timeDesc: true, keyColumn: "id", keyDesc: true}
+
+// Create inserts an asset and its variants in a single transaction
+func (r *MediaRepository) Create(ctx context.Context, asset *MediaAsset) error {
+	if asset.ID == uuid.Nil {
+		asset.ID = uuid.New()
+	}
+
+	if err := asset.Validate(); err != nil {
+		return err
+	}
+
+	asset.CreatedAt = time.Now().UTC()
+
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		query := `
+			INSERT INTO media_assets (id, sha256, filename, content_type, size, width, height, storage_key, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`
+
+		_, err := tx.ExecContext(ctx, query,
+			asset.ID,
+			asset.SHA256,
+			asset.Filename,
+			asset.ContentType,
+			asset.Size,
+			asset.Width,
+			asset.Height,
+			asset.StorageKey,
+			asset.CreatedAt,
+		)
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+				return apierrors.ConflictError{Message: "an identical file has already been uploaded"}
+			}
+			return fmt.Errorf("failed to create media asset: %w", err)
+		}
+
+		for _, variant := range asset.Variants {
+			query := `
+				INSERT INTO media_variants (asset_id, name, width, height, content_type, size, storage_key)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+			`
+			_, err := tx.ExecContext(ctx, query,
+				asset.ID,
+				variant.Name,
+				variant.Width,
+				variant.Height,
+				variant.ContentType,
+				variant.Size,
+				variant.StorageKey,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create media variant: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetByID retrieves an asset and its variants by ID
+func (r *MediaRepository) GetByID(ctx context.Context, id uuid.UUID) (*MediaAsset, error) {
+	return r.get(ctx, `WHERE id = $1`, id)
+}
+
+// GetBySHA256 retrieves the asset with the given content hash
+func (r *MediaRepository) GetBySHA256(ctx context.Context, sum string) (*MediaAsset, error) {
+	return r.get(ctx, `WHERE sha256 = $1`, sum)
+}
+
+// List retrieves a page of assets with their variants, newest first
+// It returns the cursor for the following page, or nil on the last page
+func (r *MediaRepository) List(ctx context.Context, page PageRequest) ([]MediaAsset, *Cursor, error) {
+	query := `
+		SELECT id, sha256, filename, content_type, size, COALESCE(width, 0), COALESCE(height, 0), storage_key, created_at
+		FROM media_assets
+		WHERE 1 = 1
+	`
+	query, args := mediaOrder.apply(query, nil, page)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list media assets: %w", err)
+	}
+	defer rows.Close()
+
+	var assets []MediaAsset
+	for rows.Next() {
+		asset := MediaAsset{}
+		if err := scanMediaAsset(rows, &asset); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan media asset: %w", err)
+		}
+		assets = append(assets, asset)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to list media assets: %w", err)
+	}
+
+	assets, next := trimPage(assets, page.Limit, func(a *MediaAsset) Cursor {
+		return Cursor{Time: a.CreatedAt, Key: a.ID.String()}
+	})
+
+	for i := range assets {
+		variants, err := r.variants(ctx, assets[i].ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		assets[i].Variants = variants
+	}
+
+	return assets, next, nil
+}
+
+// Count counts all assets
+func (r *MediaRepository) Count(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM media_assets`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count media assets: %w", err)
+	}
+	return count, nil
+}
+
+// Delete removes an asset record and its variants; stored files are left to the caller
+func (r *MediaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM media_variants WHERE asset_id = $1`, id); err != nil {
+			return fmt.Errorf("failed to delete media variants: %w", err)
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM media_assets WHERE id = $1`, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete media asset: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return apierrors.NotFoundError{Message: "media asset not found"}
+		}
+
+		return nil
+	})
+}
+
+// References finds the posts, post translations and pages whose content mentions the asset, oldest first
+// Every stored file of an asset has its content hash in its key, so one pattern matches the original and all variants
+func (r *MediaRepository) References(ctx context.Context, asset *MediaAsset) ([]MediaReference, error) {
+	pattern := "%" + asset.SHA256 + "%"
+	query := `
+		SELECT 'post' AS kind, id, slug, title, '' AS locale, created_at
+		FROM posts
+		WHERE body LIKE $1 OR summary LIKE $2
+		UNION ALL
+		SELECT 'translation', post_id, slug, title, locale, created_at
+		FROM post_translations
+		WHERE body LIKE $3 OR summary LIKE $4
+		UNION ALL
+		SELECT 'page', id, path, title, '', created_at
+		FROM pages
+		WHERE body LIKE $5
+		ORDER BY created_at ASC, slug ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pattern, pattern, pattern, pattern, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find media references: %w", err)
+	}
+	defer rows.Close()
+
+	var references []MediaReference
+	for rows.Next() {
+		ref := MediaReference{}
+		var createdAt time.Time
+		if err := rows.Scan(&ref.Kind, &ref.ID, &ref.Slug, &ref.Title, &ref.Locale, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan media reference: %w", err)
+		}
+		references = append(references, ref)
+	}
+
+	return references, rows.Err()
+}
+
+// get retrieves a single asset matching the given WHERE clause, with its variants
+func (r *MediaRepository) get(ctx context.Context, where string, arg interface{}) (*MediaAsset, error) {
+	asset := &MediaAsset{}
+
+	query := `
+		SELECT id, sha256, filename, content_type, size, COALESCE(width, 0), COALESCE(height, 0), storage_key, created_at
+		FROM media_assets
+		` + where
+
+	row := r.db.QueryRowContext(ctx, query, arg)
+	if err := scanMediaAsset(row, asset); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, apierrors.NotFoundError{Message: "media asset not found"}
+	}
+
+	variants, err := r.variants(ctx, asset.ID)
+	if err != nil {
+		return nil, err
+	}
+	asset.Variants = variants
+
+	return asset, nil
+}
+
+// variants loads an asset's variants, narrowest first
+func (r *MediaRepository) variants(ctx context.Context, assetID uuid.UUID) ([]MediaVariant, error) {
+	query := `
+		SELECT name, width, height, content_type, size, storage_key
+		FROM media_variants
+		WHERE asset_id = $1
+		ORDER BY width ASC, name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list media variants: %w", err)
+	}
+	defer rows.Close()
+
+	var variants []MediaVariant
+	for rows.Next() {
+		variant := MediaVariant{}
+		err := rows.Scan(
+			&variant.Name,
+			&variant.Width,
+			&variant.Height,
+			&variant.ContentType,
+			&variant.Size,
+			&variant.StorageKey,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan media variant: %w", err)
+		}
+		variants = append(variants, variant)
+	}
+
+	return variants, rows.Err()
+}
+
+// scanMediaAsset reads a media_assets row
+func scanMediaAsset(row rowScanner, asset *MediaAsset) error {
+	return row.Scan(
+		&asset.ID,
+		&asset.SHA256,
+		&asset.Filename,
+		&asset.ContentType,
+		&asset.Size,
+		&asset.Width,
+		&asset.Height,
+		&asset.StorageKey,
+		&asset.CreatedAt,
+	)
+}
@@ -0,0 +1,344 @@
+package model
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// Newsletter limits
+const (
+	// UnconfirmedSubscriberRetention is how long an unconfirmed address is kept, and its confirmation link works
+	UnconfirmedSubscriberRetention = 7 * 24 * time.Hour
+	// MaxConfirmationsPerDay caps the confirmation emails sent to one address each day
+	MaxConfirmationsPerDay = 3
+)
+
+// SubscriberStatus represents where an address is in the double opt-in flow
+type SubscriberStatus string
+
+const (
+	SubscriberStatusPending   SubscriberStatus = "pending"
+	SubscriberStatusConfirmed SubscriberStatus = "confirmed"
+)
+
+// Subscriber is an address on the newsletter list
+// Unsubscribing deletes the subscriber, so no address outlives the reader's consent
+type Subscriber struct {
+	ID                 uuid.UUID
+	Email              string
+	Status             SubscriberStatus
+	UnsubscribeToken   string // Sent in every email's List-Unsubscribe header
+	ConfirmationSentAt *time.Time
+	ConfirmationsSent  int        // Confirmation emails sent on the day of ConfirmationSentAt
+	ExpiresAt          *time.Time // Set while pending; the address is purged once it passes
+	ConfirmedAt        *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// NewsletterRepository handles newsletter subscriber data access
+type NewsletterRepository struct {
+	db db.QueryExecutor
+}
+
+// NewNewsletterRepository creates a new newsletter repository
+func NewNewsletterRepository(db db.QueryExecutor) *NewsletterRepository {
+	return &NewsletterRepository{db: db}
+}
+
+// NormalizeEmail trims and lowercases an address so each reader is listed once
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidateSubscriberEmail ensures email is a bare address that can be subscribed
+func ValidateSubscriberEmail(email string) error {
+	if email == "" {
+		return apierrors.ValidationError{Message: "email is required"}
+	}
+
+	if len(email) > 255 {
+		return apierrors.ValidationError{Message: "email must be 255 characters or less"}
+	}
+
+	// Reject display names and comments as well as malformed addresses
+	if parsed, err := mail.ParseAddress(email); err != nil || parsed.Address != email {
+		return apierrors.ValidationError{Message: "invalid email address"}
+	}
+
+	return nil
+}
+
+// subscriberColumns lists the newsletter_subscribers columns in the order scanSubscriber reads them
+const subscriberColumns = `id, email, status, unsubscribe_token, confirmation_sent_at, confirmations_sent, expires_at, confirmed_at, created_at, updated_at`
+
+// Subscribe records a request to subscribe email and returns a fresh confirmation token to send to it
+// An address that is already confirmed gets no token, so callers can respond the same way without emailing it again
+// Returns a RateLimitError once MaxConfirmationsPerDay confirmation emails have been issued today
+func (r *NewsletterRepository) Subscribe(ctx context.Context, email string, now time.Time) (*Subscriber, string, error) {
+	email = NormalizeEmail(email)
+	if err := ValidateSubscriberEmail(email); err != nil {
+		return nil, "", err
+	}
+
+	now = now.UTC()
+	token, err := newNewsletterToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var subscriber *Subscriber
+	err = db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		existing := &Subscriber{}
+		query := `SELECT ` + subscriberColumns + ` FROM newsletter_subscribers WHERE email = $1`
+		err := scanSubscriber(tx.QueryRowContext(ctx, query, email), existing)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to load subscriber: %w", err)
+		}
+		found := err == nil
+
+		// An expired pending address is treated as new; the purge job may not have removed it yet
+		if found && existing.Status == SubscriberStatusPending && existing.ExpiresAt != nil && !existing.ExpiresAt.After(now) {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM newsletter_subscribers WHERE id = $1`, existing.ID); err != nil {
+				return fmt.Errorf("failed to remove expired subscriber: %w", err)
+			}
+			found = false
+		}
+
+		if found && existing.Status == SubscriberStatusConfirmed {
+			subscriber = existing
+			token = ""
+			return nil
+		}
+
+		expiresAt := now.Add(UnconfirmedSubscriberRetention)
+
+		if found {
+			sent := 1
+			if existing.ConfirmationSentAt != nil && !existing.ConfirmationSentAt.Before(startOfDay(now)) {
+				if existing.ConfirmationsSent >= MaxConfirmationsPerDay {
+					return apierrors.RateLimitError{Message: fmt.Sprintf("rate limit exceeded: %d confirmation emails per day", MaxConfirmationsPerDay)}
+				}
+				sent = existing.ConfirmationsSent + 1
+			}
+
+			query := `
+				UPDATE newsletter_subscribers
+				SET confirm_token_hash = $1, confirmation_sent_at = $2, confirmations_sent = $3, expires_at = $4, updated_at = $5
+				WHERE id = $6
+			`
+			if _, err := tx.ExecContext(ctx, query, hashNewsletterToken(token), now, sent, expiresAt, now, existing.ID); err != nil {
+				return fmt.Errorf("failed to update subscriber: %w", err)
+			}
+
+			existing.ConfirmationSentAt = &now
+			existing.ConfirmationsSent = sent
+			existing.ExpiresAt = &expiresAt
+			existing.UpdatedAt = now
+			subscriber = existing
+			return nil
+		}
+
+		unsubscribeToken, err := newNewsletterToken()
+		if err != nil {
+			return err
+		}
+
+		subscriber = &Subscriber{
+			ID:                 uuid.New(),
+			Email:              email,
+			Status:             SubscriberStatusPending,
+			UnsubscribeToken:   unsubscribeToken,
+			ConfirmationSentAt: &now,
+			ConfirmationsSent:  1,
+			ExpiresAt:          &expiresAt,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}
+
+		query = `
+			INSERT INTO newsletter_subscribers (id, email, status, confirm_token_hash, unsubscribe_token, confirmation_sent_at,
+				confirmations_sent, expires_at, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`
+		_, err = tx.ExecContext(ctx, query,
+			subscriber.ID,
+			subscriber.Email,
+			subscriber.Status,
+			hashNewsletterToken(token),
+			subscriber.UnsubscribeToken,
+			subscriber.ConfirmationSentAt,
+			subscriber.ConfirmationsSent,
+			subscriber.ExpiresAt,
+			subscriber.CreatedAt,
+			subscriber.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create subscriber: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return subscriber, token, nil
+}
+
+// Confirm completes the double opt-in for the pending address a confirmation token was issued to
+// The token is single use, and the address no longer expires
+func (r *NewsletterRepository) Confirm(ctx context.Context, token string, now time.Time) (*Subscriber, error) {
+	now = now.UTC()
+	invalid := apierrors.NotFoundError{Message: "confirmation link is invalid or has expired"}
+	if token == "" {
+		return nil, invalid
+	}
+
+	var subscriber *Subscriber
+	err := db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		subscriber = &Subscriber{}
+		query := `
+			SELECT ` + subscriberColumns + `
+			FROM newsletter_subscribers
+			WHERE confirm_token_hash = $1 AND status = $2 AND expires_at > $3
+		`
+		err := scanSubscriber(tx.QueryRowContext(ctx, query, hashNewsletterToken(token), SubscriberStatusPending, now), subscriber)
+		if errors.Is(err, sql.ErrNoRows) {
+			return invalid
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load subscriber: %w", err)
+		}
+
+		query = `
+			UPDATE newsletter_subscribers
+			SET status = $1, confirm_token_hash = NULL, expires_at = NULL, confirmed_at = $2, updated_at = $3
+			WHERE id = $4
+		`
+		if _, err := tx.ExecContext(ctx, query, SubscriberStatusConfirmed, now, now, subscriber.ID); err != nil {
+			return fmt.Errorf("failed to confirm subscriber: %w", err)
+		}
+
+		subscriber.Status = SubscriberStatusConfirmed
+		subscriber.ExpiresAt = nil
+		subscriber.ConfirmedAt = &now
+		subscriber.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriber, nil
+}
+
+// Unsubscribe deletes the subscriber an unsubscribe token was issued to
+func (r *NewsletterRepository) Unsubscribe(ctx context.Context, token string) error {
+	if token == "" {
+		return apierrors.NotFoundError{Message: "subscription not found"}
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM newsletter_subscribers WHERE unsubscribe_token = $1`, token)
+	if err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "subscription not found"}
+	}
+
+	return nil
+}
+
+// ListConfirmed retrieves every confirmed subscriber in the order they confirmed
+func (r *NewsletterRepository) ListConfirmed(ctx context.Context) ([]Subscriber, error) {
+	query := `
+		SELECT ` + subscriberColumns + `
+		FROM newsletter_subscribers
+		WHERE status = $1
+		ORDER BY confirmed_at ASC, email ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, SubscriberStatusConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscribers: %w", err)
+	}
+	defer rows.Close()
+
+	var subscribers []Subscriber
+	for rows.Next() {
+		subscriber := Subscriber{}
+		if err := scanSubscriber(rows, &subscriber); err != nil {
+			return nil, fmt.Errorf("failed to scan subscriber: %w", err)
+		}
+		subscribers = append(subscribers, subscriber)
+	}
+
+	return subscribers, rows.Err()
+}
+
+// PurgeExpired deletes unconfirmed addresses whose retention has passed and returns how many were removed
+func (r *NewsletterRepository) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	query := `DELETE FROM newsletter_subscribers WHERE status = $1 AND expires_at <= $2`
+	result, err := r.db.ExecContext(ctx, query, SubscriberStatusPending, now.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired subscribers: %w", err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return purged, nil
+}
+
+// scanSubscriber reads a row selected with subscriberColumns into subscriber
+func scanSubscriber(row rowScanner, subscriber *Subscriber) error {
+	return row.Scan(
+		&subscriber.ID,
+		&subscriber.Email,
+		&subscriber.Status,
+		&subscriber.UnsubscribeToken,
+		&subscriber.ConfirmationSentAt,
+		&subscriber.ConfirmationsSent,
+		&subscriber.ExpiresAt,
+		&subscriber.ConfirmedAt,
+		&subscriber.CreatedAt,
+		&subscriber.UpdatedAt,
+	)
+}
+
+// newNewsletterToken generates a random URL-safe token
+func newNewsletterToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// hashNewsletterToken returns the hex SHA-256 of a token, as stored for confirmation tokens
+func hashNewsletterToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSubscriberEmail(t *testing.T) {
+	tests := []struct {
+		email     string
+		shouldErr bool
+	}{
+		{"reader@example.com", false},
+		{NormalizeEmail("  Reader@Example.COM "), false},
+		{"", true},
+		{"reader", true},
+		{"Reader <reader@example.com>", true},
+		{"reader@example.com (comment)", true},
+		{strings.Repeat("a", 250) + "@example.com", true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateSubscriberEmail(tt.email)
+		if tt.shouldErr && err == nil {
+			t.Errorf("%q: expected error, got nil", tt.email)
+		}
+		if !tt.shouldErr && err != nil {
+			t.Errorf("%q: expected no error, got %v", tt.email, err)
+		}
+	}
+}
+
+func TestNewsletterTokens(t *testing.T) {
+	a, err := newNewsletterToken()
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	b, _ := newNewsletterToken()
+
+	if a == b || len(a) != 43 || strings.ContainsAny(a, "+/=") {
+		t.Errorf("expected distinct 43-character URL-safe tokens, got %q and %q", a, b)
+	}
+
+	if hashNewsletterToken(a) != hashNewsletterToken(a) || hashNewsletterToken(a) == hashNewsletterToken(b) {
+		t.Errorf("expected hashes to be stable and distinct")
+	}
+	if len(hashNewsletterToken(a)) != 64 {
+		t.Errorf("expected a 64-character hex hash, got %q", hashNewsletterToken(a))
+	}
+}
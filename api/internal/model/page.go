@@ -0,0 +1,112 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// PageRequest describes which slice of a list to return
+// When After is set the list continues after that cursor and Offset is ignored
+type PageRequest struct {
+	Limit  int
+	Offset int
+	After  *Cursor
+}
+
+// Cursor marks the last row of a page by its sort keys
+// Time is the list's primary sort column and Key breaks ties between rows sharing it
+type Cursor struct {
+	Time time.Time `json:"t"`
+	Key  string    `json:"k"`
+}
+
+// Encode returns the opaque, URL-safe form of the cursor
+func (c Cursor) Encode() string {
+	encoded, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// DecodeCursor parses a cursor produced by Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, apierrors.ValidationError{Message: "invalid cursor"}
+	}
+
+	cursor := &Cursor{}
+	if err := json.Unmarshal(raw, cursor); err != nil || cursor.Time.IsZero() || cursor.Key == "" {
+		return nil, apierrors.ValidationError{Message: "invalid cursor"}
+	}
+
+	return cursor, nil
+}
+
+// pageOrder describes a list's keyset sort: a time column plus a unique tiebreaker column
+type pageOrder struct {
+	timeColumn string
+	timeDesc   bool
+	keyColumn  string
+	keyDesc    bool
+}
+
+// apply appends the keyset condition, ordering and limit for page to a query that already has a WHERE clause
+// One extra row is requested so callers can tell whether another page follows
+func (o pageOrder) apply(query string, args []interface{}, page PageRequest) (string, []interface{}) {
+	if page.After != nil {
+		args = append(args, page.After.Time)
+		timeAfter := len(args)
+		args = append(args, page.After.Time)
+		timeEqual := len(args)
+		args = append(args, page.After.Key)
+		keyAfter := len(args)
+
+		query += fmt.Sprintf(` AND (%s %s $%d OR (%s = $%d AND %s %s $%d))`,
+			o.timeColumn, comparator(o.timeDesc), timeAfter,
+			o.timeColumn, timeEqual,
+			o.keyColumn, comparator(o.keyDesc), keyAfter,
+		)
+	}
+
+	query += fmt.Sprintf(` ORDER BY %s %s, %s %s`, o.timeColumn, direction(o.timeDesc), o.keyColumn, direction(o.keyDesc))
+
+	args = append(args, page.Limit+1)
+	query += fmt.Sprintf(` LIMIT $%d`, len(args))
+
+	if page.After == nil && page.Offset > 0 {
+		args = append(args, page.Offset)
+		query += fmt.Sprintf(` OFFSET $%d`, len(args))
+	}
+
+	return query, args
+}
+
+// comparator returns the operator selecting rows after a position in the given direction
+func comparator(desc bool) string {
+	if desc {
+		return "<"
+	}
+	return ">"
+}
+
+// direction returns the SQL sort direction keyword
+func direction(desc bool) string {
+	if desc {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// trimPage drops the extra row fetched by pageOrder.apply and returns the cursor for the next page, if any
+func trimPage[T any](items []T, limit int, cursorOf func(*T) Cursor) ([]T, *Cursor) {
+	if len(items) <= limit {
+		return items, nil
+	}
+
+	items = items[:limit]
+	next := cursorOf(&items[len(items)-1])
+	return items, &next
+}
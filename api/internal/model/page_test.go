@@ -0,0 +1,82 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := Cursor{Time: time.Date(2025, 3, 1, 12, 30, 0, 123456000, time.UTC), Key: "abc"}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !decoded.Time.Equal(cursor.Time) || decoded.Key != cursor.Key {
+		t.Errorf("expected %+v, got %+v", cursor, decoded)
+	}
+}
+
+func TestDecodeCursorRejectsInvalid(t *testing.T) {
+	tests := []string{"", "!!!", "bm90LWpzb24", "e30"}
+
+	for _, input := range tests {
+		if _, err := DecodeCursor(input); err == nil {
+			t.Errorf("expected error for %q, got nil", input)
+		}
+	}
+}
+
+func TestPageOrderApply(t *testing.T) {
+	order := pageOrder{timeColumn: "created_at", timeDesc: true, keyColumn: "id", keyDesc: true}
+	base := `SELECT id FROM t WHERE x = $1`
+
+	t.Run("offset page", func(t *testing.T) {
+		query, args := order.apply(base, []interface{}{"x"}, PageRequest{Limit: 10, Offset: 20})
+
+		if !strings.HasSuffix(query, ` ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3`) {
+			t.Errorf("unexpected query: %s", query)
+		}
+		if len(args) != 3 || args[1] != 11 || args[2] != 20 {
+			t.Errorf("unexpected args: %v", args)
+		}
+	})
+
+	t.Run("cursor page ignores offset", func(t *testing.T) {
+		after := &Cursor{Time: time.Now().UTC(), Key: "k"}
+		query, args := order.apply(base, []interface{}{"x"}, PageRequest{Limit: 10, Offset: 20, After: after})
+
+		expected := ` AND (created_at < $2 OR (created_at = $3 AND id < $4)) ORDER BY created_at DESC, id DESC LIMIT $5`
+		if !strings.HasSuffix(query, expected) {
+			t.Errorf("unexpected query: %s", query)
+		}
+		if len(args) != 5 || args[3] != "k" || args[4] != 11 {
+			t.Errorf("unexpected args: %v", args)
+		}
+	})
+
+	t.Run("ascending order", func(t *testing.T) {
+		asc := pageOrder{timeColumn: "created_at", keyColumn: "id"}
+		query, _ := asc.apply(base, nil, PageRequest{Limit: 5, After: &Cursor{Time: time.Now(), Key: "k"}})
+
+		if !strings.Contains(query, `created_at > $1 OR (created_at = $2 AND id > $3)`) || !strings.Contains(query, `ORDER BY created_at ASC, id ASC`) {
+			t.Errorf("unexpected query: %s", query)
+		}
+	})
+}
+
+func TestTrimPage(t *testing.T) {
+	cursorOf := func(n *int) Cursor { return Cursor{Key: string(rune('a' + *n))} }
+
+	items, next := trimPage([]int{0, 1, 2}, 3, cursorOf)
+	if len(items) != 3 || next != nil {
+		t.Errorf("expected full last page without cursor, got %v %v", items, next)
+	}
+
+	items, next = trimPage([]int{0, 1, 2, 3}, 3, cursorOf)
+	if len(items) != 3 || next == nil || next.Key != "c" {
+		t.Errorf("expected trimmed page with cursor at last item, got %v %v", items, next)
+	}
+}
@@ -2,6 +2,9 @@ package model
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
@@ -20,6 +23,7 @@ const (
 	PostStatusDraft     PostStatus = "draft"
 	PostStatusPublished PostStatus = "published"
 	PostStatusArchived  PostStatus = "archived"
+	PostStatusScheduled PostStatus = "scheduled"
 )
 
 // Post represents a blog post
@@ -32,10 +36,26 @@ type Post struct {
 	Tags        []string
 	Status      PostStatus
 	PublishedAt *time.Time
+	PublishAt   *time.Time // When a scheduled post goes live
+	UnpublishAt *time.Time // When a published post is archived automatically
+	WordCount   int        // Computed from Body when the post is written
+	ReadingTime int        // Estimated minutes to read, computed from Body
+	TOC         []TOCEntry // Headings parsed from Body
 	UpdatedAt   time.Time
 	CreatedAt   time.Time
+	DeletedAt   *time.Time // When the post was moved to the trash
+	Version     int        // Incremented on every write, for optimistic concurrency
+	Locale      string     // Language the post is written in; translations cover other locales
+
+	// slugOwner is the post that previously used Slug, if any; the repository sets it before validating
+	slugOwner uuid.UUID
+	// slugTranslated is set when a post translation already uses Slug
+	slugTranslated bool
 }
 
+// postColumns lists the posts columns in the order scanPost reads them
+const postColumns = `id, slug, title, summary, body, tags, status, published_at, publish_at, unpublish_at, COALESCE(word_count, 0), COALESCE(reading_time, 0), toc, created_at, updated_at, deleted_at, version, locale`
+
 // PostRepository handles post data access
 type PostRepository struct {
 	db db.QueryExecutor
@@ -56,6 +76,14 @@ func (p *Post) Validate() error {
 		return apierrors.ValidationError{Message: "slug must be lowercase alphanumeric with hyphens only"}
 	}
 
+	if p.slugOwner != uuid.Nil && p.slugOwner != p.ID {
+		return apierrors.ConflictError{Message: fmt.Sprintf("slug '%s' was previously used by another post", p.Slug)}
+	}
+
+	if p.slugTranslated {
+		return apierrors.ConflictError{Message: fmt.Sprintf("slug '%s' is used by a post translation", p.Slug)}
+	}
+
 	if strings.TrimSpace(p.Title) == "" {
 		return apierrors.ValidationError{Message: "title is required"}
 	}
@@ -68,7 +96,17 @@ func (p *Post) Validate() error {
 		return apierrors.ValidationError{Message: "body is required"}
 	}
 
-	if p.Status != PostStatusDraft && p.Status != PostStatusPublished && p.Status != PostStatusArchived {
+	if p.Locale != "" && !IsValidLocale(p.Locale) {
+		return apierrors.ValidationError{Message: "locale must be a language code such as en or es-MX"}
+	}
+
+	for _, tag := range p.Tags {
+		if err := validateTagName(NormalizeTag(tag)); err != nil {
+			return err
+		}
+	}
+
+	if p.Status != PostStatusDraft && p.Status != PostStatusPublished && p.Status != PostStatusArchived && p.Status != PostStatusScheduled {
 		return apierrors.ValidationError{Message: "invalid post status"}
 	}
 
@@ -76,47 +114,118 @@ func (p *Post) Validate() error {
 		return apierrors.ValidationError{Message: "published posts must have a published_at timestamp"}
 	}
 
+	if p.Status == PostStatusScheduled {
+		if p.PublishAt == nil {
+			return apierrors.ValidationError{Message: "scheduled posts must have a publish_at timestamp"}
+		}
+
+		if p.PublishAt.Before(time.Now().UTC()) {
+			return apierrors.ValidationError{Message: "publish_at must be in the future"}
+		}
+	} else if p.PublishAt != nil {
+		return apierrors.ValidationError{Message: "publish_at is only allowed for scheduled posts"}
+	}
+
+	if p.UnpublishAt != nil {
+		if p.Status != PostStatusScheduled && p.Status != PostStatusPublished {
+			return apierrors.ValidationError{Message: "unpublish_at is only allowed for scheduled or published posts"}
+		}
+
+		if p.PublishAt != nil && !p.UnpublishAt.After(*p.PublishAt) {
+			return apierrors.ValidationError{Message: "unpublish_at must be after publish_at"}
+		}
+	}
+
 	return nil
 }
 
+// IsTrashed reports whether the post has been moved to the trash
+func (p *Post) IsTrashed() bool {
+	return p.DeletedAt != nil
+}
+
+// IsPublic reports whether the post may be shown to anonymous readers at the given time
+func (p *Post) IsPublic(now time.Time) bool {
+	if p.IsTrashed() || p.Status != PostStatusPublished {
+		return false
+	}
+
+	if p.PublishedAt == nil || p.PublishedAt.After(now) {
+		return false
+	}
+
+	if p.UnpublishAt != nil && !p.UnpublishAt.After(now) {
+		return false
+	}
+
+	return true
+}
+
 // Create inserts a new post
 func (r *PostRepository) Create(ctx context.Context, post *Post) error {
 	if post.ID == uuid.Nil {
 		post.ID = uuid.New()
 	}
 
+	now := time.Now().UTC()
+	post.Tags = NormalizeTags(post.Tags)
+	post.computeReadingMetadata()
+
+	// Publishing immediately stamps the post before validation requires it
+	if post.Status == PostStatusPublished && post.PublishedAt == nil {
+		post.PublishedAt = &now
+	}
+
+	post.Locale = NormalizeLocale(post.Locale)
+	if post.Locale == "" {
+		post.Locale = DefaultLocale
+	}
+
+	owner, err := slugHistoryOwner(ctx, r.db, post.Slug)
+	if err != nil {
+		return err
+	}
+	post.slugOwner = owner
+
+	if post.slugTranslated, err = translationSlugTaken(ctx, r.db, post.Slug, uuid.Nil, ""); err != nil {
+		return err
+	}
+
 	if err := post.Validate(); err != nil {
 		return err
 	}
 
-	now := time.Now().UTC()
 	post.CreatedAt = now
 	post.UpdatedAt = now
-
-	if post.Status == PostStatusPublished && post.PublishedAt == nil {
-		post.PublishedAt = &now
-	}
+	post.Version = 1
 
 	query := `
-		INSERT INTO posts (id, slug, title, summary, body, tags, status, published_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO posts (id, slug, title, summary, body, tags, status, published_at, publish_at, unpublish_at, word_count, reading_time, toc, created_at, updated_at, version, locale)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err = r.db.ExecContext(ctx, query,
 		post.ID,
 		post.Slug,
 		post.Title,
 		post.Summary,
 		post.Body,
-		post.Tags,
+		tagList(post.Tags),
 		post.Status,
 		post.PublishedAt,
+		post.PublishAt,
+		post.UnpublishAt,
+		post.WordCount,
+		post.ReadingTime,
+		tableOfContents(post.TOC),
 		post.CreatedAt,
 		post.UpdatedAt,
+		post.Version,
+		post.Locale,
 	)
 
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key") {
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
 			return apierrors.ConflictError{Message: fmt.Sprintf("post with slug '%s' already exists", post.Slug)}
 		}
 		return fmt.Errorf("failed to create post: %w", err)
@@ -130,26 +239,13 @@ func (r *PostRepository) GetBySlug(ctx context.Context, slug string) (*Post, err
 	post := &Post{}
 
 	query := `
-		SELECT id, slug, title, summary, body, tags, status, published_at, created_at, updated_at
+		SELECT ` + postColumns + `
 		FROM posts
 		WHERE slug = $1
 	`
 
 	row := r.db.QueryRowContext(ctx, query, slug)
-	err := row.Scan(
-		&post.ID,
-		&post.Slug,
-		&post.Title,
-		&post.Summary,
-		&post.Body,
-		&post.Tags,
-		&post.Status,
-		&post.PublishedAt,
-		&post.CreatedAt,
-		&post.UpdatedAt,
-	)
-
-	if err != nil {
+	if err := scanPost(row, post); err != nil {
 		if errors.Is(err, context.Canceled) {
 			return nil, err
 		}
@@ -164,26 +260,13 @@ func (r *PostRepository) GetByID(ctx context.Context, id uuid.UUID) (*Post, erro
 	post := &Post{}
 
 	query := `
-		SELECT id, slug, title, summary, body, tags, status, published_at, created_at, updated_at
+		SELECT ` + postColumns + `
 		FROM posts
 		WHERE id = $1
 	`
 
 	row := r.db.QueryRowContext(ctx, query, id)
-	err := row.Scan(
-		&post.ID,
-		&post.Slug,
-		&post.Title,
-		&post.Summary,
-		&post.Body,
-		&post.Tags,
-		&post.Status,
-		&post.PublishedAt,
-		&post.CreatedAt,
-		&post.UpdatedAt,
-	)
-
-	if err != nil {
+	if err := scanPost(row, post); err != nil {
 		if errors.Is(err, context.Canceled) {
 			return nil, err
 		}
@@ -193,92 +276,268 @@ func (r *PostRepository) GetByID(ctx context.Context, id uuid.UUID) (*Post, erro
 	return post, nil
 }
 
-// ListPublished retrieves all published posts, optionally filtered by tag
-func (r *PostRepository) ListPublished(ctx context.Context, limit int, offset int, tag string) ([]Post, error) {
-	query := `
-		SELECT id, slug, title, summary, body, tags, status, published_at, created_at, updated_at
+// publishedOrder sorts public post lists newest first
+var publishedOrder = pageOrder{timeColumn: "published_at", timeDesc: true, keyColumn: "id", keyDesc: true}
+
+// ListPublished retrieves a page of publicly visible posts, optionally filtered by tag and by
+// locale, which matches posts written in it or translated into it
+// It returns the cursor for the following page, or nil on the last page
+func (r *PostRepository) ListPublished(ctx context.Context, page PageRequest, tag, locale string) ([]Post, *Cursor, error) {
+	query, args := publishedFilter(time.Now().UTC(), tag, locale)
+	query = `
+		SELECT ` + postColumns + `
 		FROM posts
-		WHERE status = $1
-	`
-	args := []interface{}{PostStatusPublished}
+		` + query
+	query, args = publishedOrder.apply(query, args, page)
 
-	if tag != "" {
-		query += ` AND $4::TEXT = ANY(tags)`
-		args = append(args, tag)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list published posts: %w", err)
 	}
+	defer rows.Close()
 
-	query += ` ORDER BY published_at DESC NULLS LAST LIMIT $2 OFFSET $3`
-	args = append([]interface{}{PostStatusPublished, limit, offset}, args[1:]...)
+	var posts []Post
+	for rows.Next() {
+		post := Post{}
+		if err := scanPost(rows, &post); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan post: %w", err)
+		}
+		posts = append(posts, post)
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	posts, next := trimPage(posts, page.Limit, func(p *Post) Cursor {
+		return Cursor{Time: *p.PublishedAt, Key: p.ID.String()}
+	})
+	return posts, next, nil
+}
+
+// CountPublished counts publicly visible posts, optionally filtered by tag and locale
+func (r *PostRepository) CountPublished(ctx context.Context, tag, locale string) (int, error) {
+	query, args := publishedFilter(time.Now().UTC(), tag, locale)
+	query = `SELECT COUNT(*) FROM posts ` + query
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count published posts: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListAll retrieves every post outside the trash regardless of status, ordered by slug
+// It is meant for bulk tools such as content sync rather than request handling
+func (r *PostRepository) ListAll(ctx context.Context) ([]Post, error) {
+	query := `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE deleted_at IS NULL
+		ORDER BY slug ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list published posts: %w", err)
+		return nil, fmt.Errorf("failed to list posts: %w", err)
 	}
 	defer rows.Close()
 
 	var posts []Post
 	for rows.Next() {
 		post := Post{}
-		err := rows.Scan(
-			&post.ID,
-			&post.Slug,
-			&post.Title,
-			&post.Summary,
-			&post.Body,
-			&post.Tags,
-			&post.Status,
-			&post.PublishedAt,
-			&post.CreatedAt,
-			&post.UpdatedAt,
-		)
-		if err != nil {
+		if err := scanPost(rows, &post); err != nil {
 			return nil, fmt.Errorf("failed to scan post: %w", err)
 		}
 		posts = append(posts, post)
 	}
 
-	return posts, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list posts: %w", err)
+	}
+
+	return posts, nil
 }
 
-// Update updates an existing post
+// publishedFilter builds the WHERE clause selecting publicly visible posts
+func publishedFilter(now time.Time, tag, locale string) (string, []interface{}) {
+	// Matches Post.IsPublic, so a post listed here can also be read by its slug
+	// Published posts always carry published_at, which the list cursor is built from
+	query := `WHERE status = $1 AND published_at IS NOT NULL AND published_at <= $2 AND (unpublish_at IS NULL OR unpublish_at > $3) AND deleted_at IS NULL`
+	args := []interface{}{PostStatusPublished, now, now}
+
+	if tag != "" {
+		args = append(args, tagPattern(NormalizeTag(tag)))
+		query += fmt.Sprintf(` AND tags LIKE $%d ESCAPE '\'`, len(args))
+	}
+
+	if locale != "" {
+		args = append(args, locale, locale)
+		query += fmt.Sprintf(` AND (locale = $%d OR id IN (SELECT post_id FROM post_translations WHERE locale = $%d))`, len(args)-1, len(args))
+	}
+
+	return query, args
+}
+
+// Update updates an existing post; trashed posts must be restored first
+// The write only applies if the stored version still matches post.Version, which is then incremented;
+// otherwise another write got there first and a PreconditionFailedError is returned
+// A changed slug is recorded in the post's slug history so old links keep resolving
+// An empty Locale keeps the stored one
 func (r *PostRepository) Update(ctx context.Context, post *Post) error {
 	if post.ID == uuid.Nil {
 		return apierrors.ValidationError{Message: "post ID is required"}
 	}
 
+	now := time.Now().UTC()
+	post.Tags = NormalizeTags(post.Tags)
+	post.computeReadingMetadata()
+
+	// Publishing immediately stamps the post before validation requires it
+	if post.Status == PostStatusPublished && post.PublishedAt == nil {
+		post.PublishedAt = &now
+	}
+
+	post.Locale = NormalizeLocale(post.Locale)
+
+	owner, err := slugHistoryOwner(ctx, r.db, post.Slug)
+	if err != nil {
+		return err
+	}
+	post.slugOwner = owner
+
+	if post.slugTranslated, err = translationSlugTaken(ctx, r.db, post.Slug, uuid.Nil, ""); err != nil {
+		return err
+	}
+
 	if err := post.Validate(); err != nil {
 		return err
 	}
 
-	post.UpdatedAt = time.Now().UTC()
+	if post.Locale != "" {
+		translated, err := hasTranslation(ctx, r.db, post.ID, post.Locale)
+		if err != nil {
+			return err
+		}
+		if translated {
+			return apierrors.ConflictError{Message: fmt.Sprintf("post already has a '%s' translation", post.Locale)}
+		}
+	}
+
+	post.UpdatedAt = now
+
+	err = db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		// Remember the outgoing slug before it is overwritten
+		history := `
+			INSERT INTO post_slug_history (slug, post_id, created_at)
+			SELECT slug, id, $1 FROM posts WHERE id = $2 AND slug <> $3
+			ON CONFLICT (slug) DO UPDATE SET post_id = excluded.post_id, created_at = excluded.created_at
+		`
+		if _, err := tx.ExecContext(ctx, history, now, post.ID, post.Slug); err != nil {
+			return fmt.Errorf("failed to record slug history: %w", err)
+		}
 
-	if post.Status == PostStatusPublished && post.PublishedAt == nil {
-		post.PublishedAt = &post.UpdatedAt
+		query := `
+			UPDATE posts
+			SET slug = $1, title = $2, summary = $3, body = $4, tags = $5, status = $6, published_at = $7, publish_at = $8, unpublish_at = $9,
+				word_count = $10, reading_time = $11, toc = $12, updated_at = $13, locale = COALESCE(NULLIF($14, ''), locale), version = version + 1
+			WHERE id = $15 AND version = $16 AND deleted_at IS NULL
+		`
+
+		result, err := tx.ExecContext(ctx, query,
+			post.Slug,
+			post.Title,
+			post.Summary,
+			post.Body,
+			tagList(post.Tags),
+			post.Status,
+			post.PublishedAt,
+			post.PublishAt,
+			post.UnpublishAt,
+			post.WordCount,
+			post.ReadingTime,
+			tableOfContents(post.TOC),
+			post.UpdatedAt,
+			post.Locale,
+			post.ID,
+			post.Version,
+		)
+
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+				return apierrors.ConflictError{Message: fmt.Sprintf("post with slug '%s' already exists", post.Slug)}
+			}
+			return fmt.Errorf("failed to update post: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return postWriteMissed(ctx, tx, post.ID)
+		}
+
+		// A post returning to one of its old slugs no longer needs that redirect
+		cleanup := `DELETE FROM post_slug_history WHERE slug = $1 AND post_id = $2`
+		if _, err := tx.ExecContext(ctx, cleanup, post.Slug, post.ID); err != nil {
+			return fmt.Errorf("failed to clean up slug history: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	post.Version++
+	return nil
+}
+
+// postWriteMissed explains why a versioned update matched no row: the post is gone, or it changed since it was read
+func postWriteMissed(ctx context.Context, exec db.QueryExecutor, id uuid.UUID) error {
+	var version int
+	err := exec.QueryRowContext(ctx, `SELECT version FROM posts WHERE id = $1 AND deleted_at IS NULL`, id).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return apierrors.NotFoundError{Message: "post not found"}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check post version: %w", err)
 	}
+	return apierrors.PreconditionFailedError{Message: "post was modified by another request; reload it and retry"}
+}
+
+// GetByHistoricalSlug retrieves the post that previously used the given slug
+func (r *PostRepository) GetByHistoricalSlug(ctx context.Context, slug string) (*Post, error) {
+	post := &Post{}
 
 	query := `
-		UPDATE posts
-		SET slug = $1, title = $2, summary = $3, body = $4, tags = $5, status = $6, published_at = $7, updated_at = $8
-		WHERE id = $9
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE id = (SELECT post_id FROM post_slug_history WHERE slug = $1)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
-		post.Slug,
-		post.Title,
-		post.Summary,
-		post.Body,
-		post.Tags,
-		post.Status,
-		post.PublishedAt,
-		post.UpdatedAt,
-		post.ID,
-	)
+	row := r.db.QueryRowContext(ctx, query, slug)
+	if err := scanPost(row, post); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, apierrors.NotFoundError{Message: "post not found"}
+	}
+
+	return post, nil
+}
+
+// Delete moves a post to the trash (soft delete)
+func (r *PostRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	query := `UPDATE posts SET deleted_at = $1, updated_at = $2, version = version + 1 WHERE id = $3 AND deleted_at IS NULL`
 
+	result, err := r.db.ExecContext(ctx, query, now, now, id)
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key") {
-			return apierrors.ConflictError{Message: fmt.Sprintf("post with slug '%s' already exists", post.Slug)}
-		}
-		return fmt.Errorf("failed to update post: %w", err)
+		return fmt.Errorf("failed to delete post: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
@@ -293,13 +552,83 @@ func (r *PostRepository) Update(ctx context.Context, post *Post) error {
 	return nil
 }
 
-// Delete removes a post
-func (r *PostRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM posts WHERE id = $1`
+// trashOrder lists trashed posts most recently deleted first
+var trashOrder = pageOrder{timeColumn: "deleted_at", timeDesc: true, keyColumn: "id", keyDesc: true}
+
+// ListTrashed retrieves a page of trashed posts, most recently deleted first
+// It returns the cursor for the following page, or nil on the last page
+func (r *PostRepository) ListTrashed(ctx context.Context, page PageRequest) ([]Post, *Cursor, error) {
+	query := `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE deleted_at IS NOT NULL
+	`
+	query, args := trashOrder.apply(query, nil, page)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list trashed posts: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []Post
+	for rows.Next() {
+		post := Post{}
+		if err := scanPost(rows, &post); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan post: %w", err)
+		}
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	posts, next := trimPage(posts, page.Limit, func(p *Post) Cursor {
+		return Cursor{Time: *p.DeletedAt, Key: p.ID.String()}
+	})
+	return posts, next, nil
+}
+
+// CountTrashed counts trashed posts
+func (r *PostRepository) CountTrashed(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM posts WHERE deleted_at IS NOT NULL`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count trashed posts: %w", err)
+	}
+
+	return count, nil
+}
+
+// Restore takes a post out of the trash with the status it had when it was deleted
+func (r *PostRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE posts SET deleted_at = NULL, updated_at = $1, version = version + 1 WHERE id = $2 AND deleted_at IS NOT NULL`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("failed to restore post: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "trashed post not found"}
+	}
+
+	return nil
+}
+
+// Purge permanently removes a trashed post along with its slug history, preview links and reactions
+// Posts must be in the trash before they can be purged
+func (r *PostRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM posts WHERE id = $1 AND deleted_at IS NOT NULL`
 
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("failed to delete post: %w", err)
+		return fmt.Errorf("failed to purge post: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
@@ -308,12 +637,216 @@ func (r *PostRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if rows == 0 {
-		return apierrors.NotFoundError{Message: "post not found"}
+		return apierrors.NotFoundError{Message: "trashed post not found"}
 	}
 
 	return nil
 }
 
+// PurgeTrashedBefore permanently removes posts trashed before the cutoff
+func (r *PostRepository) PurgeTrashedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	query := `DELETE FROM posts WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+
+	result, err := r.db.ExecContext(ctx, query, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge trashed posts: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
+
+// StatusChange records a post the scheduler moved from one status to another
+type StatusChange struct {
+	PostID uuid.UUID
+	Slug   string
+	From   PostStatus
+	To     PostStatus
+	At     time.Time
+}
+
+// PublishDue publishes scheduled posts whose publish_at has passed and returns the posts it published,
+// including those published before an error stopped it
+// The scheduled time becomes the post's published_at so feeds order it as intended
+func (r *PostRepository) PublishDue(ctx context.Context, now time.Time) ([]StatusChange, error) {
+	due := `SELECT id, slug FROM posts WHERE status = $1 AND publish_at <= $2 AND deleted_at IS NULL`
+	update := `
+		UPDATE posts
+		SET status = $1, published_at = publish_at, publish_at = NULL, updated_at = $2, version = version + 1
+		WHERE id = $3 AND status = $4 AND publish_at <= $5 AND deleted_at IS NULL
+	`
+
+	changes, err := r.changeDueStatus(ctx, now, PostStatusScheduled, PostStatusPublished, due, update)
+	if err != nil {
+		return changes, fmt.Errorf("failed to publish scheduled posts: %w", err)
+	}
+
+	return changes, nil
+}
+
+// UnpublishDue archives published posts whose unpublish_at has passed and returns the posts it archived,
+// including those archived before an error stopped it
+func (r *PostRepository) UnpublishDue(ctx context.Context, now time.Time) ([]StatusChange, error) {
+	due := `SELECT id, slug FROM posts WHERE status = $1 AND unpublish_at IS NOT NULL AND unpublish_at <= $2 AND deleted_at IS NULL`
+	update := `
+		UPDATE posts
+		SET status = $1, unpublish_at = NULL, updated_at = $2, version = version + 1
+		WHERE id = $3 AND status = $4 AND unpublish_at IS NOT NULL AND unpublish_at <= $5 AND deleted_at IS NULL
+	`
+
+	changes, err := r.changeDueStatus(ctx, now, PostStatusPublished, PostStatusArchived, due, update)
+	if err != nil {
+		return changes, fmt.Errorf("failed to unpublish expired posts: %w", err)
+	}
+
+	return changes, nil
+}
+
+// changeDueStatus moves the posts the due query finds from one status to another
+// Each post is updated on its own, guarded by the same conditions, so a post changed in between is left out
+func (r *PostRepository) changeDueStatus(ctx context.Context, now time.Time, from, to PostStatus, due, update string) ([]StatusChange, error) {
+	rows, err := r.db.QueryContext(ctx, due, from, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []StatusChange
+	for rows.Next() {
+		change := StatusChange{From: from, To: to, At: now}
+		if err := rows.Scan(&change.PostID, &change.Slug); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		candidates = append(candidates, change)
+	}
+	// Release the connection before updating, as SQLite has only one
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var changes []StatusChange
+	for _, change := range candidates {
+		result, err := r.db.ExecContext(ctx, update, to, now, change.PostID, from, now)
+		if err != nil {
+			return changes, err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return changes, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if affected == 1 {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+// slugHistoryOwner returns the post that previously used slug, or uuid.Nil if none did
+func slugHistoryOwner(ctx context.Context, exec db.QueryExecutor, slug string) (uuid.UUID, error) {
+	query := `SELECT post_id FROM post_slug_history WHERE slug = $1`
+
+	var owner uuid.UUID
+	if err := exec.QueryRowContext(ctx, query, slug).Scan(&owner); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, nil
+		}
+		return uuid.Nil, fmt.Errorf("failed to check slug history: %w", err)
+	}
+
+	return owner, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanPost reads a row selected with postColumns into post
+// Columns selected after postColumns are read into extra, in order
+func scanPost(row rowScanner, post *Post, extra ...interface{}) error {
+	dest := []interface{}{
+		&post.ID,
+		&post.Slug,
+		&post.Title,
+		&post.Summary,
+		&post.Body,
+		(*tagList)(&post.Tags),
+		&post.Status,
+		&post.PublishedAt,
+		&post.PublishAt,
+		&post.UnpublishAt,
+		&post.WordCount,
+		&post.ReadingTime,
+		(*tableOfContents)(&post.TOC),
+		&post.CreatedAt,
+		&post.UpdatedAt,
+		&post.DeletedAt,
+		&post.Version,
+		&post.Locale,
+	}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// tagList stores post tags as a JSON array in the TEXT tags column
+type tagList []string
+
+// Value implements driver.Valuer
+func (t tagList) Value() (driver.Value, error) {
+	if t == nil {
+		t = tagList{}
+	}
+	encoded, err := json.Marshal([]string(t))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tags: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// Scan implements sql.Scanner
+func (t *tagList) Scan(src interface{}) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return fmt.Errorf("unsupported tags column type %T", src)
+	}
+
+	if len(raw) == 0 {
+		*t = nil
+		return nil
+	}
+
+	var tags []string
+	if err := json.Unmarshal(raw, &tags); err != nil {
+		return fmt.Errorf("failed to decode tags: %w", err)
+	}
+	*t = tags
+	return nil
+}
+
+// likeEscaper escapes LIKE wildcards so they match literally in a clause with ESCAPE '\'
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// tagPattern builds a LIKE pattern matching a single tag inside an encoded tagList
+// The pattern escapes wildcards, so the LIKE clause must declare ESCAPE '\'
+func tagPattern(tag string) string {
+	encoded, _ := json.Marshal(tag)
+	return "%" + likeEscaper.Replace(string(encoded)) + "%"
+}
+
 // isValidSlug validates that a slug is lowercase alphanumeric with hyphens
 func isValidSlug(slug string) bool {
 	matched, _ := regexp.MatchString(`^[a-z0-9]+(?:-[a-z0-9]+)*$`, slug)
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// PostTransition is an explicit change of a post's status
+type PostTransition string
+
+const (
+	PostTransitionPublish   PostTransition = "publish"
+	PostTransitionUnpublish PostTransition = "unpublish"
+	PostTransitionArchive   PostTransition = "archive"
+)
+
+// postTransitions is the post state machine: the statuses each transition may start from and the one it leads to
+// Scheduled posts can be published early, unpublished back to a draft or archived
+var postTransitions = map[PostTransition]struct {
+	from []PostStatus
+	to   PostStatus
+}{
+	PostTransitionPublish:   {from: []PostStatus{PostStatusDraft, PostStatusScheduled, PostStatusArchived}, to: PostStatusPublished},
+	PostTransitionUnpublish: {from: []PostStatus{PostStatusPublished, PostStatusScheduled}, to: PostStatusDraft},
+	PostTransitionArchive:   {from: []PostStatus{PostStatusDraft, PostStatusScheduled, PostStatusPublished}, to: PostStatusArchived},
+}
+
+// TransitionOptions adjusts how a transition treats the post's timestamps
+type TransitionOptions struct {
+	ResetPublishedAt bool // Publish with published_at set to now rather than keeping the original date
+}
+
+// Transition moves the post along the state machine, returning a ConflictError when the move is not allowed
+// Publishing keeps an existing published_at, so a republished post keeps its original date unless
+// opts.ResetPublishedAt is set; unpublishing and archiving keep it too, for the next publish.
+// Every transition drops a pending publish_at, and leaving published also drops unpublish_at.
+func (p *Post) Transition(transition PostTransition, now time.Time, opts TransitionOptions) error {
+	rule, ok := postTransitions[transition]
+	if !ok {
+		return apierrors.ValidationError{Message: fmt.Sprintf("unknown transition '%s'", transition)}
+	}
+
+	if !slices.Contains(rule.from, p.Status) {
+		return apierrors.ConflictError{Message: fmt.Sprintf("cannot %s a post that is %s", transition, p.Status)}
+	}
+
+	p.Status = rule.to
+	p.PublishAt = nil
+
+	switch rule.to {
+	case PostStatusPublished:
+		if p.PublishedAt == nil || opts.ResetPublishedAt {
+			p.PublishedAt = &now
+		}
+		// An unpublish time that has already passed would archive the post again on the next job run
+		if p.UnpublishAt != nil && !p.UnpublishAt.After(now) {
+			p.UnpublishAt = nil
+		}
+	default:
+		p.UnpublishAt = nil
+	}
+
+	return nil
+}
+
+// ChangeStatus moves the post to status as an edit asks for it, returning the transition taken
+// Any status other than scheduled is reached through Transition; a draft may also be scheduled, which takes
+// no transition. An unchanged or unknown status returns no transition, leaving Validate to reject the latter.
+func (p *Post) ChangeStatus(status PostStatus, now time.Time) (PostTransition, error) {
+	if status == p.Status {
+		return "", nil
+	}
+
+	if status == PostStatusScheduled {
+		if p.Status != PostStatusDraft {
+			return "", apierrors.ConflictError{Message: fmt.Sprintf("cannot schedule a post that is %s", p.Status)}
+		}
+		p.Status = status
+		return "", nil
+	}
+
+	for transition, rule := range postTransitions {
+		if rule.to == status {
+			return transition, p.Transition(transition, now, TransitionOptions{})
+		}
+	}
+
+	p.Status = status
+	return "", nil
+}
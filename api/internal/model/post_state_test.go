@@ -0,0 +1,115 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+func TestPostTransition(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	original := now.Add(-30 * 24 * time.Hour)
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name            string
+		post            Post
+		transition      PostTransition
+		opts            TransitionOptions
+		wantStatus      PostStatus
+		wantPublishedAt *time.Time
+		wantUnpublishAt *time.Time
+		wantConflict    bool
+	}{
+		{"publish draft", Post{Status: PostStatusDraft}, PostTransitionPublish, TransitionOptions{}, PostStatusPublished, &now, nil, false},
+		{"republish keeps date", Post{Status: PostStatusArchived, PublishedAt: &original}, PostTransitionPublish, TransitionOptions{}, PostStatusPublished, &original, nil, false},
+		{"republish with reset", Post{Status: PostStatusDraft, PublishedAt: &original}, PostTransitionPublish, TransitionOptions{ResetPublishedAt: true}, PostStatusPublished, &now, nil, false},
+		{"publish scheduled early", Post{Status: PostStatusScheduled, PublishAt: &future, UnpublishAt: &future}, PostTransitionPublish, TransitionOptions{}, PostStatusPublished, &now, &future, false},
+		{"publish drops passed unpublish", Post{Status: PostStatusDraft, UnpublishAt: &past}, PostTransitionPublish, TransitionOptions{}, PostStatusPublished, &now, nil, false},
+		{"unpublish keeps date", Post{Status: PostStatusPublished, PublishedAt: &original, UnpublishAt: &future}, PostTransitionUnpublish, TransitionOptions{}, PostStatusDraft, &original, nil, false},
+		{"archive published", Post{Status: PostStatusPublished, PublishedAt: &original}, PostTransitionArchive, TransitionOptions{}, PostStatusArchived, &original, nil, false},
+		{"publish published", Post{Status: PostStatusPublished, PublishedAt: &original}, PostTransitionPublish, TransitionOptions{}, PostStatusPublished, &original, nil, true},
+		{"unpublish draft", Post{Status: PostStatusDraft}, PostTransitionUnpublish, TransitionOptions{}, PostStatusDraft, nil, nil, true},
+		{"unpublish archived", Post{Status: PostStatusArchived}, PostTransitionUnpublish, TransitionOptions{}, PostStatusArchived, nil, nil, true},
+		{"archive archived", Post{Status: PostStatusArchived}, PostTransitionArchive, TransitionOptions{}, PostStatusArchived, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			post := tt.post
+			err := post.Transition(tt.transition, now, tt.opts)
+
+			var conflict apierrors.ConflictError
+			if tt.wantConflict != errors.As(err, &conflict) {
+				t.Fatalf("expected conflict %v, got %v", tt.wantConflict, err)
+			}
+			if post.Status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, post.Status)
+			}
+			if !equalTime(post.PublishedAt, tt.wantPublishedAt) {
+				t.Errorf("expected published_at %v, got %v", tt.wantPublishedAt, post.PublishedAt)
+			}
+			if !equalTime(post.UnpublishAt, tt.wantUnpublishAt) {
+				t.Errorf("expected unpublish_at %v, got %v", tt.wantUnpublishAt, post.UnpublishAt)
+			}
+			if !tt.wantConflict && post.PublishAt != nil {
+				t.Errorf("expected publish_at to be cleared, got %v", post.PublishAt)
+			}
+		})
+	}
+
+	post := Post{Status: PostStatusDraft}
+	if err := post.Transition("delete", now, TransitionOptions{}); err == nil {
+		t.Error("expected an unknown transition to fail")
+	}
+}
+
+func TestPostChangeStatus(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		from           PostStatus
+		to             PostStatus
+		wantTransition PostTransition
+		wantStatus     PostStatus
+		wantConflict   bool
+	}{
+		{"unchanged", PostStatusPublished, PostStatusPublished, "", PostStatusPublished, false},
+		{"publish draft", PostStatusDraft, PostStatusPublished, PostTransitionPublish, PostStatusPublished, false},
+		{"unpublish published", PostStatusPublished, PostStatusDraft, PostTransitionUnpublish, PostStatusDraft, false},
+		{"archive scheduled", PostStatusScheduled, PostStatusArchived, PostTransitionArchive, PostStatusArchived, false},
+		{"schedule draft", PostStatusDraft, PostStatusScheduled, "", PostStatusScheduled, false},
+		{"schedule published", PostStatusPublished, PostStatusScheduled, "", PostStatusPublished, true},
+		{"archived to draft", PostStatusArchived, PostStatusDraft, "", PostStatusArchived, true},
+		{"unknown status", PostStatusDraft, "deleted", "", "deleted", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			post := Post{Status: tt.from}
+			transition, err := post.ChangeStatus(tt.to, now)
+
+			var conflict apierrors.ConflictError
+			if tt.wantConflict != errors.As(err, &conflict) {
+				t.Fatalf("expected conflict %v, got %v", tt.wantConflict, err)
+			}
+			if !tt.wantConflict && transition != tt.wantTransition {
+				t.Errorf("expected transition %q, got %q", tt.wantTransition, transition)
+			}
+			if post.Status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, post.Status)
+			}
+		})
+	}
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
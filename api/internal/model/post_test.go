@@ -4,10 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
 	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
 )
 
@@ -21,7 +23,21 @@ func (m *mockQueryExecutor) QueryRowContext(ctx context.Context, query string, a
 	if m.queryRowFunc != nil {
 		return m.queryRowFunc(ctx, query, args...)
 	}
-	return nil
+	return noRows()
+}
+
+var (
+	noRowsOnce sync.Once
+	noRowsDB   *sql.DB
+)
+
+// noRows returns a row that scans as sql.ErrNoRows, for lookups the mock does not stub
+// database/sql offers no way to build a *sql.Row directly, so it comes from an in-memory SQLite database
+func noRows() *sql.Row {
+	noRowsOnce.Do(func() {
+		noRowsDB, _ = sql.Open("sqlite3", ":memory:")
+	})
+	return noRowsDB.QueryRow(`SELECT 1 WHERE 0 = 1`)
 }
 
 func (m *mockQueryExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
@@ -39,6 +55,11 @@ func (m *mockQueryExecutor) ExecContext(ctx context.Context, query string, args
 }
 
 func TestPostValidate(t *testing.T) {
+	future := time.Now().UTC().Add(24 * time.Hour)
+	past := time.Now().UTC().Add(-24 * time.Hour)
+	postID := uuid.New()
+	otherID := uuid.New()
+
 	tests := []struct {
 		name      string
 		post      *Post
@@ -164,6 +185,97 @@ func TestPostValidate(t *testing.T) {
 			},
 			shouldErr: false,
 		},
+		{
+			name: "scheduled post with future publish_at",
+			post: &Post{
+				Slug:      "valid-slug",
+				Title:     "Title",
+				Body:      "Body",
+				Status:    PostStatusScheduled,
+				PublishAt: &future,
+			},
+			shouldErr: false,
+		},
+		{
+			name: "scheduled post without publish_at",
+			post: &Post{
+				Slug:   "valid-slug",
+				Title:  "Title",
+				Body:   "Body",
+				Status: PostStatusScheduled,
+			},
+			shouldErr: true,
+		},
+		{
+			name: "scheduled post with past publish_at",
+			post: &Post{
+				Slug:      "valid-slug",
+				Title:     "Title",
+				Body:      "Body",
+				Status:    PostStatusScheduled,
+				PublishAt: &past,
+			},
+			shouldErr: true,
+		},
+		{
+			name: "draft post with publish_at",
+			post: &Post{
+				Slug:      "valid-slug",
+				Title:     "Title",
+				Body:      "Body",
+				Status:    PostStatusDraft,
+				PublishAt: &future,
+			},
+			shouldErr: true,
+		},
+		{
+			name: "unpublish_at before publish_at",
+			post: &Post{
+				Slug:        "valid-slug",
+				Title:       "Title",
+				Body:        "Body",
+				Status:      PostStatusScheduled,
+				PublishAt:   &future,
+				UnpublishAt: &past,
+			},
+			shouldErr: true,
+		},
+		{
+			name: "slug previously used by another post",
+			post: &Post{
+				ID:        postID,
+				Slug:      "old-slug",
+				Title:     "Title",
+				Body:      "Body",
+				Status:    PostStatusDraft,
+				slugOwner: otherID,
+			},
+			shouldErr: true,
+			errType:   apierrors.ConflictError{},
+		},
+		{
+			name: "slug previously used by the same post",
+			post: &Post{
+				ID:        postID,
+				Slug:      "old-slug",
+				Title:     "Title",
+				Body:      "Body",
+				Status:    PostStatusDraft,
+				slugOwner: postID,
+			},
+			shouldErr: false,
+		},
+		{
+			name: "draft post with unpublish_at",
+			post: &Post{
+				Slug:        "valid-slug",
+				Title:       "Title",
+				Body:        "Body",
+				Status:      PostStatusDraft,
+				UnpublishAt: &future,
+			},
+			shouldErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -179,6 +291,67 @@ func TestPostValidate(t *testing.T) {
 	}
 }
 
+func TestPostIsPublic(t *testing.T) {
+	now := time.Now().UTC()
+	earlier := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		post     *Post
+		expected bool
+	}{
+		{
+			name:     "published post",
+			post:     &Post{Status: PostStatusPublished, PublishedAt: &earlier},
+			expected: true,
+		},
+		{
+			name:     "draft post",
+			post:     &Post{Status: PostStatusDraft},
+			expected: false,
+		},
+		{
+			name:     "scheduled post",
+			post:     &Post{Status: PostStatusScheduled, PublishAt: &earlier},
+			expected: false,
+		},
+		{
+			name:     "published post with future unpublish_at",
+			post:     &Post{Status: PostStatusPublished, PublishedAt: &earlier, UnpublishAt: &later},
+			expected: true,
+		},
+		{
+			name:     "published post past its unpublish_at",
+			post:     &Post{Status: PostStatusPublished, PublishedAt: &earlier, UnpublishAt: &earlier},
+			expected: false,
+		},
+		{
+			name:     "published post with future published_at",
+			post:     &Post{Status: PostStatusPublished, PublishedAt: &later},
+			expected: false,
+		},
+		{
+			name:     "published post without published_at",
+			post:     &Post{Status: PostStatusPublished},
+			expected: false,
+		},
+		{
+			name:     "trashed published post",
+			post:     &Post{Status: PostStatusPublished, PublishedAt: &earlier, DeletedAt: &earlier},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.post.IsPublic(now); got != tt.expected {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
 type mockResult struct {
 	rowsAffected int64
 }
@@ -374,3 +547,85 @@ func TestPostRepositoryDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestPostRepositoryRestoreAndPurge(t *testing.T) {
+	ctx := context.Background()
+	postID := uuid.New()
+
+	for _, rowsAffected := range []int64{1, 0} {
+		mock := &mockQueryExecutor{
+			execFunc: func(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+				return mockResult{rowsAffected: rowsAffected}, nil
+			},
+		}
+		repo := NewPostRepository(mock)
+
+		for name, op := range map[string]func(context.Context, uuid.UUID) error{"restore": repo.Restore, "purge": repo.Purge} {
+			err := op(ctx, postID)
+			if rowsAffected == 1 && err != nil {
+				t.Errorf("%s: expected no error, got %v", name, err)
+			}
+			var notFound apierrors.NotFoundError
+			if rowsAffected == 0 && !errors.As(err, &notFound) {
+				t.Errorf("%s: expected not found for a post outside the trash, got %v", name, err)
+			}
+		}
+	}
+}
+
+func TestPostRepositoryPublishDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	moved, raced := uuid.New(), uuid.New()
+
+	due, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer due.Close()
+
+	var gotArgs []interface{}
+	mock := &mockQueryExecutor{
+		queryFunc: func(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+			return due.QueryContext(ctx, `SELECT ?, 'moved' UNION ALL SELECT ?, 'raced'`, moved.String(), raced.String())
+		},
+		execFunc: func(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+			gotArgs = args
+			// The second post was changed by someone else after it was found
+			if args[2] == raced {
+				return mockResult{rowsAffected: 0}, nil
+			}
+			return mockResult{rowsAffected: 1}, nil
+		},
+	}
+	repo := NewPostRepository(mock)
+
+	published, err := repo.PublishDue(ctx, now)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(published) != 1 || published[0].PostID != moved || published[0].Slug != "moved" {
+		t.Fatalf("expected only the moved post to be published, got %+v", published)
+	}
+	if published[0].From != PostStatusScheduled || published[0].To != PostStatusPublished {
+		t.Errorf("expected scheduled to published, got %s to %s", published[0].From, published[0].To)
+	}
+	if gotArgs[0] != PostStatusPublished || gotArgs[3] != PostStatusScheduled {
+		t.Fatalf("expected scheduled posts to become published, got args %v", gotArgs)
+	}
+}
+
+func TestPostRepositoryUnpublishDue(t *testing.T) {
+	ctx := context.Background()
+
+	mock := &mockQueryExecutor{
+		queryFunc: func(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	repo := NewPostRepository(mock)
+
+	if _, err := repo.UnpublishDue(ctx, time.Now().UTC()); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
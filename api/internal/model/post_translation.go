@@ -0,0 +1,326 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// DefaultLocale is the locale of posts created without one
+const DefaultLocale = "en"
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// NormalizeLocale canonicalizes a language tag: lowercase language, uppercase region, hyphen separated
+// es_mx, ES-mx and es-MX all become es-MX
+func NormalizeLocale(locale string) string {
+	language, region, found := strings.Cut(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-")
+	if !found {
+		return strings.ToLower(language)
+	}
+	return strings.ToLower(language) + "-" + strings.ToUpper(region)
+}
+
+// IsValidLocale reports whether locale is a normalized language code with an optional region, such as en or es-MX
+func IsValidLocale(locale string) bool {
+	return localePattern.MatchString(locale)
+}
+
+// PostTranslation is a post's title, summary and body in another locale, reachable under its own slug
+type PostTranslation struct {
+	PostID      uuid.UUID
+	Locale      string
+	Slug        string
+	Title       string
+	Summary     string
+	Body        string
+	WordCount   int        // Computed from Body when the translation is written
+	ReadingTime int        // Estimated minutes to read, computed from Body
+	TOC         []TOCEntry // Headings parsed from Body
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// postTranslationColumns lists the post_translations columns in the order scanPostTranslation reads them
+const postTranslationColumns = `post_id, locale, slug, title, summary, body, word_count, reading_time, toc, created_at, updated_at`
+
+// Validate ensures the translation meets business requirements
+func (t *PostTranslation) Validate() error {
+	if !IsValidLocale(t.Locale) {
+		return apierrors.ValidationError{Message: "locale must be a language code such as en or es-MX"}
+	}
+
+	if strings.TrimSpace(t.Slug) == "" {
+		return apierrors.ValidationError{Message: "slug is required"}
+	}
+
+	if !isValidSlug(t.Slug) {
+		return apierrors.ValidationError{Message: "slug must be lowercase alphanumeric with hyphens only"}
+	}
+
+	if strings.TrimSpace(t.Title) == "" {
+		return apierrors.ValidationError{Message: "title is required"}
+	}
+
+	if len(t.Title) > 255 {
+		return apierrors.ValidationError{Message: "title must be 255 characters or less"}
+	}
+
+	if strings.TrimSpace(t.Body) == "" {
+		return apierrors.ValidationError{Message: "body is required"}
+	}
+
+	return nil
+}
+
+// Translate replaces the post's localized fields with those of translation
+// Everything else, such as status, tags and timestamps, stays the post's own
+func (p *Post) Translate(translation *PostTranslation) {
+	p.Locale = translation.Locale
+	p.Slug = translation.Slug
+	p.Title = translation.Title
+	p.Summary = translation.Summary
+	p.Body = translation.Body
+	p.WordCount = translation.WordCount
+	p.ReadingTime = translation.ReadingTime
+	p.TOC = translation.TOC
+}
+
+// SaveTranslation creates or replaces the post's translation into translation.Locale
+// The post must exist outside the trash, and the locale must differ from the one the post is written in
+func (r *PostRepository) SaveTranslation(ctx context.Context, translation *PostTranslation) error {
+	translation.Locale = NormalizeLocale(translation.Locale)
+	translation.WordCount, translation.ReadingTime, translation.TOC = ReadingMetadata(translation.Body)
+
+	if err := translation.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		var locale string
+		err := tx.QueryRowContext(ctx, `SELECT locale FROM posts WHERE id = $1 AND deleted_at IS NULL`, translation.PostID).Scan(&locale)
+		if errors.Is(err, sql.ErrNoRows) {
+			return apierrors.NotFoundError{Message: "post not found"}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load post: %w", err)
+		}
+
+		if locale == translation.Locale {
+			return apierrors.ValidationError{Message: fmt.Sprintf("post is already written in '%s'", locale)}
+		}
+
+		if err := checkTranslationSlug(ctx, tx, translation); err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO post_translations (post_id, locale, slug, title, summary, body, word_count, reading_time, toc, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (post_id, locale) DO UPDATE SET
+				slug = excluded.slug, title = excluded.title, summary = excluded.summary, body = excluded.body,
+				word_count = excluded.word_count, reading_time = excluded.reading_time, toc = excluded.toc, updated_at = excluded.updated_at
+		`
+
+		_, err = tx.ExecContext(ctx, query,
+			translation.PostID,
+			translation.Locale,
+			translation.Slug,
+			translation.Title,
+			translation.Summary,
+			translation.Body,
+			translation.WordCount,
+			translation.ReadingTime,
+			tableOfContents(translation.TOC),
+			now,
+			now,
+		)
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+				return apierrors.ConflictError{Message: fmt.Sprintf("slug '%s' is already in use", translation.Slug)}
+			}
+			return fmt.Errorf("failed to save post translation: %w", err)
+		}
+
+		// Replacing a translation keeps when it was first written
+		row := tx.QueryRowContext(ctx, `SELECT created_at, updated_at FROM post_translations WHERE post_id = $1 AND locale = $2`, translation.PostID, translation.Locale)
+		if err := row.Scan(&translation.CreatedAt, &translation.UpdatedAt); err != nil {
+			return fmt.Errorf("failed to reload post translation: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// checkTranslationSlug rejects a translation slug that a post, another translation or another post's old slug already uses
+func checkTranslationSlug(ctx context.Context, exec db.QueryExecutor, translation *PostTranslation) error {
+	var id uuid.UUID
+	err := exec.QueryRowContext(ctx, `SELECT id FROM posts WHERE slug = $1`, translation.Slug).Scan(&id)
+	if err == nil {
+		return apierrors.ConflictError{Message: fmt.Sprintf("post with slug '%s' already exists", translation.Slug)}
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to check post slugs: %w", err)
+	}
+
+	taken, err := translationSlugTaken(ctx, exec, translation.Slug, translation.PostID, translation.Locale)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return apierrors.ConflictError{Message: fmt.Sprintf("slug '%s' is used by a post translation", translation.Slug)}
+	}
+
+	owner, err := slugHistoryOwner(ctx, exec, translation.Slug)
+	if err != nil {
+		return err
+	}
+	if owner != uuid.Nil && owner != translation.PostID {
+		return apierrors.ConflictError{Message: fmt.Sprintf("slug '%s' was previously used by another post", translation.Slug)}
+	}
+
+	return nil
+}
+
+// translationSlugTaken reports whether a translation other than the post's translation into locale uses slug
+// Pass uuid.Nil to consider every translation
+func translationSlugTaken(ctx context.Context, exec db.QueryExecutor, slug string, postID uuid.UUID, locale string) (bool, error) {
+	query := `SELECT post_id, locale FROM post_translations WHERE slug = $1`
+
+	var owner uuid.UUID
+	var ownerLocale string
+	if err := exec.QueryRowContext(ctx, query, slug).Scan(&owner, &ownerLocale); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check translation slugs: %w", err)
+	}
+
+	return owner != postID || ownerLocale != locale, nil
+}
+
+// hasTranslation reports whether the post has a translation into locale
+func hasTranslation(ctx context.Context, exec db.QueryExecutor, postID uuid.UUID, locale string) (bool, error) {
+	query := `SELECT 1 FROM post_translations WHERE post_id = $1 AND locale = $2`
+
+	var found int
+	if err := exec.QueryRowContext(ctx, query, postID, locale).Scan(&found); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check post translations: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetTranslationBySlug retrieves the translation published under slug
+func (r *PostRepository) GetTranslationBySlug(ctx context.Context, slug string) (*PostTranslation, error) {
+	translation := &PostTranslation{}
+
+	query := `
+		SELECT ` + postTranslationColumns + `
+		FROM post_translations
+		WHERE slug = $1
+	`
+
+	row := r.db.QueryRowContext(ctx, query, slug)
+	if err := scanPostTranslation(row, translation); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, apierrors.NotFoundError{Message: "post not found"}
+	}
+
+	return translation, nil
+}
+
+// ListTranslations retrieves the translations of each of the given posts, ordered by locale
+func (r *PostRepository) ListTranslations(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID][]PostTranslation, error) {
+	translations := make(map[uuid.UUID][]PostTranslation)
+	if len(postIDs) == 0 {
+		return translations, nil
+	}
+
+	placeholders := make([]string, len(postIDs))
+	args := make([]interface{}, len(postIDs))
+	for i, id := range postIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	query := `
+		SELECT ` + postTranslationColumns + `
+		FROM post_translations
+		WHERE post_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY post_id, locale
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list post translations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		translation := PostTranslation{}
+		if err := scanPostTranslation(rows, &translation); err != nil {
+			return nil, fmt.Errorf("failed to scan post translation: %w", err)
+		}
+		translations[translation.PostID] = append(translations[translation.PostID], translation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+// DeleteTranslation removes the post's translation into locale
+func (r *PostRepository) DeleteTranslation(ctx context.Context, postID uuid.UUID, locale string) error {
+	query := `DELETE FROM post_translations WHERE post_id = $1 AND locale = $2`
+
+	result, err := r.db.ExecContext(ctx, query, postID, NormalizeLocale(locale))
+	if err != nil {
+		return fmt.Errorf("failed to delete post translation: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "translation not found"}
+	}
+
+	return nil
+}
+
+// scanPostTranslation reads a row selected with postTranslationColumns into translation
+func scanPostTranslation(row rowScanner, translation *PostTranslation) error {
+	return row.Scan(
+		&translation.PostID,
+		&translation.Locale,
+		&translation.Slug,
+		&translation.Title,
+		&translation.Summary,
+		&translation.Body,
+		&translation.WordCount,
+		&translation.ReadingTime,
+		(*tableOfContents)(&translation.TOC),
+		&translation.CreatedAt,
+		&translation.UpdatedAt,
+	)
+}
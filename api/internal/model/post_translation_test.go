@@ -0,0 +1,58 @@
+package model
+
+import "testing"
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		valid bool
+	}{
+		{"en", "en", true},
+		{" ES ", "es", true},
+		{"es_mx", "es-MX", true},
+		{"ES-mx", "es-MX", true},
+		{"fil", "fil", true},
+		{"", "", false},
+		{"english", "english", false},
+		{"es-419", "es-419", false},
+		{"zh-Hant-TW", "zh-HANT-TW", false},
+	}
+
+	for _, tt := range tests {
+		got := NormalizeLocale(tt.input)
+		if got != tt.want {
+			t.Errorf("NormalizeLocale(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+		if IsValidLocale(got) != tt.valid {
+			t.Errorf("IsValidLocale(%q) = %v, want %v", got, !tt.valid, tt.valid)
+		}
+	}
+}
+
+func TestPostTranslationValidate(t *testing.T) {
+	valid := PostTranslation{Locale: "es", Slug: "hola-mundo", Title: "Hola", Body: "Cuerpo"}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected a valid translation, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*PostTranslation)
+	}{
+		{"invalid locale", func(tr *PostTranslation) { tr.Locale = "Spanish" }},
+		{"missing slug", func(tr *PostTranslation) { tr.Slug = "" }},
+		{"invalid slug", func(tr *PostTranslation) { tr.Slug = "Hola Mundo" }},
+		{"missing title", func(tr *PostTranslation) { tr.Title = " " }},
+		{"missing body", func(tr *PostTranslation) { tr.Body = "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translation := valid
+			tt.modify(&translation)
+			if err := translation.Validate(); err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// MaxPreviewTokenLifetime caps how long a preview link stays valid
+const MaxPreviewTokenLifetime = 30 * 24 * time.Hour
+
+// PreviewToken records a shareable preview link minted for a post
+// The signed token itself is derived from these fields and never stored
+type PreviewToken struct {
+	ID        uuid.UUID
+	PostID    uuid.UUID
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// PreviewTokenRepository handles preview token data access
+type PreviewTokenRepository struct {
+	db db.QueryExecutor
+}
+
+// NewPreviewTokenRepository creates a new preview token repository
+func NewPreviewTokenRepository(db db.QueryExecutor) *PreviewTokenRepository {
+	return &PreviewTokenRepository{db: db}
+}
+
+// Validate ensures the preview token meets business requirements
+func (p *PreviewToken) Validate() error {
+	if p.PostID == uuid.Nil {
+		return apierrors.ValidationError{Message: "post_id is required"}
+	}
+
+	if p.ExpiresAt.IsZero() {
+		return apierrors.ValidationError{Message: "expires_at is required"}
+	}
+
+	now := time.Now().UTC()
+	if !p.ExpiresAt.After(now) {
+		return apierrors.ValidationError{Message: "expires_at must be in the future"}
+	}
+
+	if p.ExpiresAt.After(now.Add(MaxPreviewTokenLifetime)) {
+		return apierrors.ValidationError{Message: "expires_at must be within 30 days"}
+	}
+
+	return nil
+}
+
+// IsActive reports whether the token can still be used at the given time
+func (p *PreviewToken) IsActive(now time.Time) bool {
+	return p.RevokedAt == nil && p.ExpiresAt.After(now)
+}
+
+// Create inserts a new preview token
+func (r *PreviewTokenRepository) Create(ctx context.Context, token *PreviewToken) error {
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+
+	// Tokens carry second precision, so the stored expiry must match
+	token.ExpiresAt = token.ExpiresAt.UTC().Truncate(time.Second)
+
+	if err := token.Validate(); err != nil {
+		return err
+	}
+
+	token.CreatedAt = time.Now().UTC()
+
+	query := `
+		INSERT INTO post_preview_tokens (id, post_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		token.ID,
+		token.PostID,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create preview token: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a preview token by ID
+func (r *PreviewTokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*PreviewToken, error) {
+	token := &PreviewToken{}
+
+	query := `
+		SELECT id, post_id, expires_at, revoked_at, created_at
+		FROM post_preview_tokens
+		WHERE id = $1
+	`
+
+	row := r.db.QueryRowContext(ctx, query, id)
+	err := row.Scan(
+		&token.ID,
+		&token.PostID,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, apierrors.NotFoundError{Message: "preview token not found"}
+	}
+
+	return token, nil
+}
+
+// ListByPost retrieves all preview tokens for a post, newest first
+func (r *PreviewTokenRepository) ListByPost(ctx context.Context, postID uuid.UUID) ([]PreviewToken, error) {
+	query := `
+		SELECT id, post_id, expires_at, revoked_at, created_at
+		FROM post_preview_tokens
+		WHERE post_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list preview tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []PreviewToken
+	for rows.Next() {
+		token := PreviewToken{}
+		err := rows.Scan(
+			&token.ID,
+			&token.PostID,
+			&token.ExpiresAt,
+			&token.RevokedAt,
+			&token.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan preview token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// Revoke marks a preview token as revoked so its link stops working
+func (r *PreviewTokenRepository) Revoke(ctx context.Context, postID, id uuid.UUID) error {
+	now := time.Now().UTC()
+	query := `UPDATE post_preview_tokens SET revoked_at = $1 WHERE id = $2 AND post_id = $3 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, now, id, postID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke preview token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "preview token not found"}
+	}
+
+	return nil
+}
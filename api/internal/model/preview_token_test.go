@@ -0,0 +1,110 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPreviewTokenValidate(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name      string
+		token     *PreviewToken
+		shouldErr bool
+	}{
+		{
+			name:      "valid token",
+			token:     &PreviewToken{PostID: uuid.New(), ExpiresAt: now.Add(time.Hour)},
+			shouldErr: false,
+		},
+		{
+			name:      "missing post",
+			token:     &PreviewToken{ExpiresAt: now.Add(time.Hour)},
+			shouldErr: true,
+		},
+		{
+			name:      "missing expiry",
+			token:     &PreviewToken{PostID: uuid.New()},
+			shouldErr: true,
+		},
+		{
+			name:      "expiry in the past",
+			token:     &PreviewToken{PostID: uuid.New(), ExpiresAt: now.Add(-time.Hour)},
+			shouldErr: true,
+		},
+		{
+			name:      "expiry beyond maximum lifetime",
+			token:     &PreviewToken{PostID: uuid.New(), ExpiresAt: now.Add(MaxPreviewTokenLifetime + time.Hour)},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.token.Validate()
+			if tt.shouldErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.shouldErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPreviewTokenIsActive(t *testing.T) {
+	now := time.Now().UTC()
+	revokedAt := now.Add(-time.Minute)
+
+	active := &PreviewToken{ExpiresAt: now.Add(time.Hour)}
+	if !active.IsActive(now) {
+		t.Error("expected unexpired token to be active")
+	}
+
+	expired := &PreviewToken{ExpiresAt: now.Add(-time.Hour)}
+	if expired.IsActive(now) {
+		t.Error("expected expired token to be inactive")
+	}
+
+	revoked := &PreviewToken{ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}
+	if revoked.IsActive(now) {
+		t.Error("expected revoked token to be inactive")
+	}
+}
+
+func TestPreviewTokenRepositoryRevoke(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		shouldErr    bool
+	}{
+		{name: "revoke active token", rowsAffected: 1, shouldErr: false},
+		{name: "token not found or already revoked", rowsAffected: 0, shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockQueryExecutor{
+				execFunc: func(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+					return mockResult{rowsAffected: tt.rowsAffected}, nil
+				},
+			}
+			repo := NewPreviewTokenRepository(mock)
+
+			err := repo.Revoke(ctx, uuid.New(), uuid.New())
+			if tt.shouldErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.shouldErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
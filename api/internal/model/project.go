@@ -0,0 +1,482 @@
+package model
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// ProjectStatus represents whether a project is shown publicly
+type ProjectStatus string
+
+const (
+	ProjectStatusDraft     ProjectStatus = "draft"
+	ProjectStatusPublished ProjectStatus = "published"
+)
+
+// Project is a case study on the work page
+type Project struct {
+	ID          uuid.UUID
+	Slug        string
+	Title       string
+	Summary     string // One or two sentences for cards and the home page
+	Problem     string
+	Role        string
+	Stack       []string
+	Outcomes    []string
+	Links       []ProjectLink
+	Tags        []string // Shared with posts, so tag pages and renames cover both
+	Status      ProjectStatus
+	Featured    bool
+	Position    int // Lower positions are listed first; ties go to the most recently published
+	PublishedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ProjectLink points at something related to a project, such as its source or a live demo
+type ProjectLink struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// ProjectFilter narrows a project listing
+type ProjectFilter struct {
+	Tag           string
+	FeaturedOnly  bool
+	PublishedOnly bool
+}
+
+// ProjectRepository handles project data access
+type ProjectRepository struct {
+	db db.QueryExecutor
+}
+
+// NewProjectRepository creates a new project repository
+func NewProjectRepository(db db.QueryExecutor) *ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+// Validate ensures the project meets business requirements
+func (p *Project) Validate() error {
+	if strings.TrimSpace(p.Slug) == "" {
+		return apierrors.ValidationError{Message: "slug is required"}
+	}
+
+	if !isValidSlug(p.Slug) {
+		return apierrors.ValidationError{Message: "slug must be lowercase alphanumeric with hyphens only"}
+	}
+
+	if strings.TrimSpace(p.Title) == "" {
+		return apierrors.ValidationError{Message: "title is required"}
+	}
+
+	if len(p.Title) > 255 {
+		return apierrors.ValidationError{Message: "title must be 255 characters or less"}
+	}
+
+	if len(p.Summary) > 500 {
+		return apierrors.ValidationError{Message: "summary must be 500 characters or less"}
+	}
+
+	if len(p.Problem) > 10000 {
+		return apierrors.ValidationError{Message: "problem must be 10000 characters or less"}
+	}
+
+	if len(p.Role) > 255 {
+		return apierrors.ValidationError{Message: "role must be 255 characters or less"}
+	}
+
+	if p.Status != ProjectStatusDraft && p.Status != ProjectStatusPublished {
+		return apierrors.ValidationError{Message: "status must be one of: draft, published"}
+	}
+
+	if p.Status == ProjectStatusPublished && strings.TrimSpace(p.Summary) == "" {
+		return apierrors.ValidationError{Message: "summary is required to publish a project"}
+	}
+
+	if p.Position < 0 {
+		return apierrors.ValidationError{Message: "position must not be negative"}
+	}
+
+	if len(p.Stack) > 50 {
+		return apierrors.ValidationError{Message: "stack may list at most 50 technologies"}
+	}
+
+	for _, item := range p.Stack {
+		if strings.TrimSpace(item) == "" || len(item) > 100 {
+			return apierrors.ValidationError{Message: "stack entries must be 1 to 100 characters"}
+		}
+	}
+
+	if len(p.Outcomes) > 50 {
+		return apierrors.ValidationError{Message: "a project may have at most 50 outcomes"}
+	}
+
+	for _, outcome := range p.Outcomes {
+		if strings.TrimSpace(outcome) == "" || len(outcome) > 1000 {
+			return apierrors.ValidationError{Message: "outcomes must be 1 to 1000 characters"}
+		}
+	}
+
+	if len(p.Links) > 20 {
+		return apierrors.ValidationError{Message: "a project may have at most 20 links"}
+	}
+
+	for _, link := range p.Links {
+		if strings.TrimSpace(link.Label) == "" || len(link.Label) > 100 {
+			return apierrors.ValidationError{Message: "link labels must be 1 to 100 characters"}
+		}
+		if !isWebURL(link.URL) {
+			return apierrors.ValidationError{Message: fmt.Sprintf("link '%s' must be an absolute http or https URL", link.Label)}
+		}
+	}
+
+	for _, tag := range p.Tags {
+		if err := validateTagName(tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsPublic reports whether the project is shown to readers
+func (p *Project) IsPublic() bool {
+	return p.Status == ProjectStatusPublished
+}
+
+// projectColumns lists the projects columns in the order scanProject reads them
+const projectColumns = `id, slug, title, summary, problem, role, stack, outcomes, links, tags, status, featured, position, published_at, created_at, updated_at`
+
+// Create inserts a new project; publishing it records when it was first published
+func (r *ProjectRepository) Create(ctx context.Context, project *Project) error {
+	if project.ID == uuid.Nil {
+		project.ID = uuid.New()
+	}
+
+	if project.Status == "" {
+		project.Status = ProjectStatusDraft
+	}
+
+	if err := project.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	project.CreatedAt = now
+	project.UpdatedAt = now
+	if project.Status == ProjectStatusPublished && project.PublishedAt == nil {
+		project.PublishedAt = &now
+	}
+
+	query := `
+		INSERT INTO projects (` + projectColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		project.ID,
+		project.Slug,
+		project.Title,
+		project.Summary,
+		project.Problem,
+		project.Role,
+		tagList(project.Stack),
+		tagList(project.Outcomes),
+		projectLinks(project.Links),
+		tagList(project.Tags),
+		project.Status,
+		project.Featured,
+		project.Position,
+		project.PublishedAt,
+		project.CreatedAt,
+		project.UpdatedAt,
+	)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+			return apierrors.ConflictError{Message: fmt.Sprintf("project with slug '%s' already exists", project.Slug)}
+		}
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a project by ID, whatever its status
+func (r *ProjectRepository) GetByID(ctx context.Context, id uuid.UUID) (*Project, error) {
+	return r.get(ctx, `WHERE id = $1`, id)
+}
+
+// GetBySlug retrieves a project by slug, whatever its status
+func (r *ProjectRepository) GetBySlug(ctx context.Context, slug string) (*Project, error) {
+	return r.get(ctx, `WHERE slug = $1`, slug)
+}
+
+// List retrieves the projects matching filter, by position and then most recently published
+func (r *ProjectRepository) List(ctx context.Context, filter ProjectFilter) ([]Project, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.PublishedOnly {
+		args = append(args, ProjectStatusPublished)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	if filter.FeaturedOnly {
+		args = append(args, true)
+		conditions = append(conditions, fmt.Sprintf("featured = $%d", len(args)))
+	}
+
+	if filter.Tag != "" {
+		args = append(args, tagPattern(NormalizeTag(filter.Tag)))
+		conditions = append(conditions, fmt.Sprintf(`tags LIKE $%d ESCAPE '\'`, len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Drafts have no published_at, so they sort after published projects at the same position
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+		` + where + `
+		ORDER BY position ASC, CASE WHEN published_at IS NULL THEN 1 ELSE 0 END, published_at DESC, title ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []Project
+	for rows.Next() {
+		project := Project{}
+		if err := scanProject(rows, &project); err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+// Update replaces a project's content; publishing it for the first time records when
+func (r *ProjectRepository) Update(ctx context.Context, project *Project) error {
+	if project.ID == uuid.Nil {
+		return apierrors.ValidationError{Message: "project ID is required"}
+	}
+
+	if err := project.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	project.UpdatedAt = now
+	if project.Status == ProjectStatusPublished && project.PublishedAt == nil {
+		project.PublishedAt = &now
+	}
+
+	query := `
+		UPDATE projects
+		SET slug = $1, title = $2, summary = $3, problem = $4, role = $5, stack = $6, outcomes = $7, links = $8,
+			tags = $9, status = $10, featured = $11, position = $12, published_at = $13, updated_at = $14
+		WHERE id = $15
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		project.Slug,
+		project.Title,
+		project.Summary,
+		project.Problem,
+		project.Role,
+		tagList(project.Stack),
+		tagList(project.Outcomes),
+		projectLinks(project.Links),
+		tagList(project.Tags),
+		project.Status,
+		project.Featured,
+		project.Position,
+		project.PublishedAt,
+		project.UpdatedAt,
+		project.ID,
+	)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+			return apierrors.ConflictError{Message: fmt.Sprintf("project with slug '%s' already exists", project.Slug)}
+		}
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "project not found"}
+	}
+
+	return nil
+}
+
+// Delete removes a project
+func (r *ProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM projects WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "project not found"}
+	}
+
+	return nil
+}
+
+// Reorder gives the listed projects positions 1, 2, 3... in that order, in a single transaction
+// Projects left out keep their positions
+func (r *ProjectRepository) Reorder(ctx context.Context, ids []uuid.UUID) error {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return apierrors.ValidationError{Message: fmt.Sprintf("project %s is listed more than once", id)}
+		}
+		seen[id] = true
+	}
+
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		now := time.Now().UTC()
+		for i, id := range ids {
+			query := `UPDATE projects SET position = $1, updated_at = $2 WHERE id = $3`
+			result, err := tx.ExecContext(ctx, query, i+1, now, id)
+			if err != nil {
+				return fmt.Errorf("failed to reorder projects: %w", err)
+			}
+
+			rows, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("failed to get rows affected: %w", err)
+			}
+			if rows == 0 {
+				return apierrors.ValidationError{Message: fmt.Sprintf("project %s not found", id)}
+			}
+		}
+
+		return nil
+	})
+}
+
+// get retrieves a single project matching the given WHERE clause
+func (r *ProjectRepository) get(ctx context.Context, where string, arg interface{}) (*Project, error) {
+	project := &Project{}
+
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+		` + where
+
+	if err := scanProject(r.db.QueryRowContext(ctx, query, arg), project); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, apierrors.NotFoundError{Message: "project not found"}
+	}
+
+	return project, nil
+}
+
+// scanProject reads a row selected with projectColumns into project
+func scanProject(row rowScanner, project *Project) error {
+	var summary, problem, role *string
+	err := row.Scan(
+		&project.ID,
+		&project.Slug,
+		&project.Title,
+		&summary,
+		&problem,
+		&role,
+		(*tagList)(&project.Stack),
+		(*tagList)(&project.Outcomes),
+		(*projectLinks)(&project.Links),
+		(*tagList)(&project.Tags),
+		&project.Status,
+		&project.Featured,
+		&project.Position,
+		&project.PublishedAt,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+	)
+	project.Summary = derefString(summary)
+	project.Problem = derefString(problem)
+	project.Role = derefString(role)
+	return err
+}
+
+// isWebURL reports whether s is an absolute http or https URL
+func isWebURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// projectLinks stores project links as a JSON array in the TEXT links column
+type projectLinks []ProjectLink
+
+// Value implements driver.Valuer
+func (l projectLinks) Value() (driver.Value, error) {
+	if l == nil {
+		l = projectLinks{}
+	}
+	encoded, err := json.Marshal([]ProjectLink(l))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode links: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// Scan implements sql.Scanner
+func (l *projectLinks) Scan(src interface{}) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return fmt.Errorf("unsupported links column type %T", src)
+	}
+
+	if len(raw) == 0 {
+		*l = nil
+		return nil
+	}
+
+	var links []ProjectLink
+	if err := json.Unmarshal(raw, &links); err != nil {
+		return fmt.Errorf("failed to decode links: %w", err)
+	}
+	*l = links
+	return nil
+}
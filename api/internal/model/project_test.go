@@ -0,0 +1,54 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProjectValidate(t *testing.T) {
+	valid := func() *Project {
+		return &Project{
+			Slug:    "payments-platform",
+			Title:   "Payments platform",
+			Summary: "Rebuilt checkout for scale.",
+			Status:  ProjectStatusPublished,
+			Links:   []ProjectLink{{Label: "Source", URL: "https://github.com/example/payments"}},
+			Tags:    []string{"go", "aws"},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(p *Project)
+		shouldErr bool
+	}{
+		{name: "valid project", modify: func(p *Project) {}, shouldErr: false},
+		{name: "draft without summary", modify: func(p *Project) { p.Status, p.Summary = ProjectStatusDraft, "" }, shouldErr: false},
+		{name: "published without summary", modify: func(p *Project) { p.Summary = "" }, shouldErr: true},
+		{name: "invalid slug", modify: func(p *Project) { p.Slug = "Payments Platform" }, shouldErr: true},
+		{name: "missing title", modify: func(p *Project) { p.Title = "" }, shouldErr: true},
+		{name: "title too long", modify: func(p *Project) { p.Title = strings.Repeat("a", 256) }, shouldErr: true},
+		{name: "unknown status", modify: func(p *Project) { p.Status = "archived" }, shouldErr: true},
+		{name: "negative position", modify: func(p *Project) { p.Position = -1 }, shouldErr: true},
+		{name: "blank stack entry", modify: func(p *Project) { p.Stack = []string{"Go", " "} }, shouldErr: true},
+		{name: "blank outcome", modify: func(p *Project) { p.Outcomes = []string{""} }, shouldErr: true},
+		{name: "relative link", modify: func(p *Project) { p.Links[0].URL = "/projects/payments" }, shouldErr: true},
+		{name: "non-web link", modify: func(p *Project) { p.Links[0].URL = "javascript:alert(1)" }, shouldErr: true},
+		{name: "unlabelled link", modify: func(p *Project) { p.Links[0].Label = "" }, shouldErr: true},
+		{name: "tag too long", modify: func(p *Project) { p.Tags = []string{strings.Repeat("a", MaxTagLength+1)} }, shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := valid()
+			tt.modify(project)
+			err := project.Validate()
+			if tt.shouldErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.shouldErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
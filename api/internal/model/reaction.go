@@ -0,0 +1,211 @@
+package model
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// ReactionEmojis is the fixed set of reactions readers can leave on a post, in display order
+var ReactionEmojis = []string{"👍", "❤️", "🎉", "💡", "👀"}
+
+// IsReactionEmoji reports whether emoji is one of the allowed reactions
+func IsReactionEmoji(emoji string) bool {
+	return slices.Contains(ReactionEmojis, emoji)
+}
+
+// reactionDayLayout keys each salt by its UTC calendar day
+const reactionDayLayout = "2006-01-02"
+
+// MaxReactionsPerDay caps how many reactions one address can leave across all posts in a day
+const MaxReactionsPerDay = 30
+
+// SenderHash identifies an address for one day without storing it, whatever user agent it sends
+// Like VisitorHash it is an HMAC under the day's salt
+func SenderHash(salt, ip string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VisitorHash identifies an anonymous visitor for one day without storing their address
+// The hash is an HMAC under the day's salt, so it cannot be reversed or linked across days
+// once the salt is discarded
+func VisitorHash(salt, ip, userAgent string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(ip))
+	mac.Write([]byte{0})
+	mac.Write([]byte(userAgent))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ReactionRepository handles post reaction data access
+type ReactionRepository struct {
+	db db.QueryExecutor
+}
+
+// NewReactionRepository creates a new reaction repository
+func NewReactionRepository(db db.QueryExecutor) *ReactionRepository {
+	return &ReactionRepository{db: db}
+}
+
+// DailySalt returns the salt for now's UTC day, creating it on first use
+// Concurrent callers racing to create it all end up with the stored salt
+func (r *ReactionRepository) DailySalt(ctx context.Context, now time.Time) (string, error) {
+	day := now.UTC().Format(reactionDayLayout)
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate reaction salt: %w", err)
+	}
+
+	insert := `
+		INSERT INTO reaction_salts (day, salt, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (day) DO NOTHING
+	`
+	if _, err := r.db.ExecContext(ctx, insert, day, hex.EncodeToString(random), now.UTC()); err != nil {
+		return "", fmt.Errorf("failed to create reaction salt: %w", err)
+	}
+
+	var salt string
+	if err := r.db.QueryRowContext(ctx, `SELECT salt FROM reaction_salts WHERE day = $1`, day).Scan(&salt); err != nil {
+		return "", fmt.Errorf("failed to get reaction salt: %w", err)
+	}
+	return salt, nil
+}
+
+// PurgeSalts deletes the salts and rate limit counts of days before now's UTC day, returning how many salts were removed
+// Without its salt, a past day's visitor hashes can no longer be recomputed from an address
+func (r *ReactionRepository) PurgeSalts(ctx context.Context, now time.Time) (int64, error) {
+	day := now.UTC().Format(reactionDayLayout)
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM reaction_rate_limits WHERE day < $1`, day); err != nil {
+		return 0, fmt.Errorf("failed to purge reaction rate limits: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM reaction_salts WHERE day < $1`, day)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge reaction salts: %w", err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge reaction salts: %w", err)
+	}
+	return purged, nil
+}
+
+// Add records a visitor's reaction to a post and charges it to senderHash's budget for the day
+// It reports false when the visitor already left that reaction, which is not an error and costs nothing
+// Returns a RateLimitError once the sender has left MaxReactionsPerDay reactions today
+func (r *ReactionRepository) Add(ctx context.Context, postID uuid.UUID, emoji, visitorHash, senderHash string, now time.Time) (bool, error) {
+	now = now.UTC()
+
+	var added bool
+	err := db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		insert := `
+			INSERT INTO post_reactions (post_id, emoji, visitor_hash, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (post_id, emoji, visitor_hash) DO NOTHING
+		`
+		result, err := tx.ExecContext(ctx, insert, postID, emoji, visitorHash, now)
+		if err != nil {
+			return fmt.Errorf("failed to add reaction: %w", err)
+		}
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to add reaction: %w", err)
+		}
+		if inserted == 0 {
+			return nil
+		}
+
+		// The conditional upsert locks the sender's row, so concurrent requests cannot all pass the cap
+		charge := `
+			INSERT INTO reaction_rate_limits (day, sender_hash, reactions)
+			VALUES ($1, $2, 1)
+			ON CONFLICT (day, sender_hash) DO UPDATE SET reactions = reaction_rate_limits.reactions + 1
+			WHERE reaction_rate_limits.reactions < $3
+		`
+		result, err = tx.ExecContext(ctx, charge, now.Format(reactionDayLayout), senderHash, MaxReactionsPerDay)
+		if err != nil {
+			return fmt.Errorf("failed to check reaction rate limit: %w", err)
+		}
+		charged, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to check reaction rate limit: %w", err)
+		}
+		if charged == 0 {
+			return apierrors.RateLimitError{Message: fmt.Sprintf("rate limit exceeded: %d reactions per day", MaxReactionsPerDay)}
+		}
+
+		added = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return added, nil
+}
+
+// Counts totals the reactions on each of the given posts, keyed by post ID and then emoji
+// Every requested post is present, with a zero count for each allowed emoji nobody has used
+func (r *ReactionRepository) Counts(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]map[string]int, error) {
+	counts := make(map[uuid.UUID]map[string]int, len(postIDs))
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+
+	placeholders := make([]string, len(postIDs))
+	args := make([]interface{}, len(postIDs))
+	for i, postID := range postIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = postID
+
+		counts[postID] = make(map[string]int, len(ReactionEmojis))
+		for _, emoji := range ReactionEmojis {
+			counts[postID][emoji] = 0
+		}
+	}
+
+	query := `
+		SELECT post_id, emoji, COUNT(*)
+		FROM post_reactions
+		WHERE post_id IN (` + strings.Join(placeholders, ", ") + `)
+		GROUP BY post_id, emoji
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID uuid.UUID
+		var emoji string
+		var count int
+		if err := rows.Scan(&postID, &emoji, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction count: %w", err)
+		}
+		// Emojis dropped from the allowed set are no longer reported
+		if byEmoji, ok := counts[postID]; ok && IsReactionEmoji(emoji) {
+			byEmoji[emoji] = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+
+	return counts, nil
+}
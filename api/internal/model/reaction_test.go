@@ -0,0 +1,37 @@
+package model
+
+import "testing"
+
+func TestVisitorHash(t *testing.T) {
+	hash := VisitorHash("salt", "192.0.2.1", "Mozilla/5.0")
+	if len(hash) != 64 {
+		t.Fatalf("expected a 64 character hex hash, got %q", hash)
+	}
+	if hash != VisitorHash("salt", "192.0.2.1", "Mozilla/5.0") {
+		t.Error("expected the same visitor to hash identically within a day")
+	}
+
+	for name, other := range map[string]string{
+		"salt":       VisitorHash("next-day", "192.0.2.1", "Mozilla/5.0"),
+		"address":    VisitorHash("salt", "192.0.2.2", "Mozilla/5.0"),
+		"user agent": VisitorHash("salt", "192.0.2.1", "curl/8.5.0"),
+		"boundary":   VisitorHash("salt", "192.0.2.1M", "ozilla/5.0"),
+	} {
+		if other == hash {
+			t.Errorf("expected a different %s to change the hash", name)
+		}
+	}
+}
+
+func TestIsReactionEmoji(t *testing.T) {
+	for _, emoji := range ReactionEmojis {
+		if !IsReactionEmoji(emoji) {
+			t.Errorf("expected %s to be allowed", emoji)
+		}
+	}
+	for _, emoji := range []string{"", "🙂", "+1", "👍👍"} {
+		if IsReactionEmoji(emoji) {
+			t.Errorf("expected %q to be rejected", emoji)
+		}
+	}
+}
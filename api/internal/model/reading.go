@@ -0,0 +1,236 @@
+package model
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// WordsPerMinute is the reading speed used to estimate reading time
+const WordsPerMinute = 200
+
+// TOCEntry is a markdown heading in a post's table of contents
+// Children holds the headings nested beneath it
+type TOCEntry struct {
+	Level    int        `json:"level"`
+	Text     string     `json:"text"`
+	Anchor   string     `json:"anchor"`
+	Children []TOCEntry `json:"children,omitempty"`
+}
+
+var (
+	atxHeading    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	markdownImage = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLink  = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	markdownHTML  = regexp.MustCompile(`<[^>]+>`)
+)
+
+// computeReadingMetadata derives the word count, reading time and table of contents from the post body
+func (p *Post) computeReadingMetadata() {
+	p.WordCount, p.ReadingTime, p.TOC = ReadingMetadata(p.Body)
+}
+
+// ReadingMetadata returns the word count, estimated reading time in minutes and
+// table of contents of a markdown document
+// Fenced code blocks are skipped: they are neither counted as prose nor searched for headings
+func ReadingMetadata(markdown string) (words, minutes int, toc []TOCEntry) {
+	var headings []TOCEntry
+	anchors := make(map[string]int)
+	fence := ""
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		text := line
+		if match := atxHeading.FindStringSubmatch(line); match != nil {
+			text = plainMarkdown(match[2])
+			if text != "" {
+				headings = append(headings, TOCEntry{
+					Level:  len(match[1]),
+					Text:   text,
+					Anchor: uniqueAnchor(anchors, headingAnchor(text)),
+				})
+			}
+		}
+
+		words += countWords(plainMarkdown(text))
+	}
+
+	if words > 0 {
+		minutes = (words + WordsPerMinute - 1) / WordsPerMinute
+	}
+
+	return words, minutes, nestHeadings(headings)
+}
+
+// plainMarkdown strips inline markdown syntax, keeping link and image text
+func plainMarkdown(s string) string {
+	s = markdownImage.ReplaceAllString(s, "$1")
+	s = markdownLink.ReplaceAllString(s, "$1")
+	s = markdownHTML.ReplaceAllString(s, "")
+	s = strings.NewReplacer("`", "", "**", "", "__", "", "*", "", "~~", "").Replace(s)
+	return strings.TrimSpace(s)
+}
+
+// countWords counts whitespace-separated tokens containing a letter or digit
+func countWords(s string) int {
+	count := 0
+	for _, field := range strings.Fields(s) {
+		if strings.IndexFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// headingAnchor turns heading text into a URL fragment the way common markdown renderers do:
+// lowercase, punctuation dropped and spaces replaced with hyphens
+func headingAnchor(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune('-')
+		}
+	}
+	if b.Len() == 0 {
+		return "section"
+	}
+	return b.String()
+}
+
+// uniqueAnchor suffixes repeated anchors with -1, -2, ... so every heading has its own fragment
+func uniqueAnchor(seen map[string]int, anchor string) string {
+	n, exists := seen[anchor]
+	seen[anchor] = n + 1
+	if !exists {
+		return anchor
+	}
+
+	suffixed := anchor + "-" + strconv.Itoa(n)
+	if _, taken := seen[suffixed]; taken {
+		return uniqueAnchor(seen, anchor)
+	}
+	seen[suffixed] = 1
+	return suffixed
+}
+
+// nestHeadings builds the table of contents tree: each heading nests under the
+// nearest preceding heading of a smaller level
+func nestHeadings(headings []TOCEntry) []TOCEntry {
+	var build func(i, parentLevel int) ([]TOCEntry, int)
+	build = func(i, parentLevel int) ([]TOCEntry, int) {
+		var entries []TOCEntry
+		for i < len(headings) && headings[i].Level > parentLevel {
+			entry := headings[i]
+			entry.Children, i = build(i+1, entry.Level)
+			entries = append(entries, entry)
+		}
+		return entries, i
+	}
+
+	entries, _ := build(0, 0)
+	return entries
+}
+
+// ComputeMissingReadingMetadata fills in reading metadata for posts written before it was stored
+func (r *PostRepository) ComputeMissingReadingMetadata(ctx context.Context) (int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, body FROM posts WHERE toc IS NULL`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find posts without reading metadata: %w", err)
+	}
+
+	var posts []Post
+	for rows.Next() {
+		post := Post{}
+		if err := rows.Scan(&post.ID, &post.Body); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan post: %w", err)
+		}
+		posts = append(posts, post)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return 0, fmt.Errorf("failed to find posts without reading metadata: %w", err)
+	}
+
+	var updated int64
+	for _, post := range posts {
+		post.computeReadingMetadata()
+
+		// updated_at and version are left alone: the post's content has not changed, and bumping
+		// the version would make an editor's in-flight save fail its If-Match check
+		query := `UPDATE posts SET word_count = $1, reading_time = $2, toc = $3 WHERE id = $4 AND toc IS NULL`
+		result, err := r.db.ExecContext(ctx, query, post.WordCount, post.ReadingTime, tableOfContents(post.TOC), post.ID)
+		if err != nil {
+			return updated, fmt.Errorf("failed to store reading metadata: %w", err)
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			updated += n
+		}
+	}
+
+	return updated, nil
+}
+
+// tableOfContents stores a post's table of contents as JSON in the TEXT toc column
+type tableOfContents []TOCEntry
+
+// Value implements driver.Valuer
+func (t tableOfContents) Value() (driver.Value, error) {
+	if t == nil {
+		t = tableOfContents{}
+	}
+	encoded, err := json.Marshal([]TOCEntry(t))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode table of contents: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// Scan implements sql.Scanner
+func (t *tableOfContents) Scan(src interface{}) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return fmt.Errorf("unsupported toc column type %T", src)
+	}
+
+	if len(raw) == 0 {
+		*t = nil
+		return nil
+	}
+
+	var toc []TOCEntry
+	if err := json.Unmarshal(raw, &toc); err != nil {
+		return fmt.Errorf("failed to decode table of contents: %w", err)
+	}
+	*t = toc
+	return nil
+}
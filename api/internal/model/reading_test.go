@@ -0,0 +1,101 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadingMetadata(t *testing.T) {
+	body := strings.Join([]string{
+		"# Getting Started",
+		"Install the **CLI** and read the [docs](https://example.com).",
+		"",
+		"## Setup",
+		"Run it once.",
+		"",
+		"```go",
+		"# not a heading",
+		"fmt.Println(\"ignored words here\")",
+		"```",
+		"",
+		"### Config file",
+		"## Setup",
+		"#hashtag is not a heading",
+		"# Wrapping Up!",
+	}, "\n")
+
+	words, minutes, toc := ReadingMetadata(body)
+
+	// Headings count as words; the fenced block does not
+	if words != 23 {
+		t.Errorf("expected 23 words, got %d", words)
+	}
+	if minutes != 1 {
+		t.Errorf("expected 1 minute, got %d", minutes)
+	}
+
+	expected := []TOCEntry{
+		{Level: 1, Text: "Getting Started", Anchor: "getting-started", Children: []TOCEntry{
+			{Level: 2, Text: "Setup", Anchor: "setup", Children: []TOCEntry{
+				{Level: 3, Text: "Config file", Anchor: "config-file"},
+			}},
+			{Level: 2, Text: "Setup", Anchor: "setup-1"},
+		}},
+		{Level: 1, Text: "Wrapping Up!", Anchor: "wrapping-up"},
+	}
+	if !reflect.DeepEqual(toc, expected) {
+		t.Errorf("expected toc %+v, got %+v", expected, toc)
+	}
+}
+
+func TestReadingMetadataReadingTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		words    int
+		expected int
+	}{
+		{name: "empty", words: 0, expected: 0},
+		{name: "short", words: 1, expected: 1},
+		{name: "exactly one minute", words: WordsPerMinute, expected: 1},
+		{name: "rounds up", words: WordsPerMinute + 1, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, minutes, _ := ReadingMetadata(strings.Repeat("word ", tt.words))
+			if minutes != tt.expected {
+				t.Errorf("expected %d minutes, got %d", tt.expected, minutes)
+			}
+		})
+	}
+}
+
+func TestNestHeadingsSkippedLevels(t *testing.T) {
+	toc := nestHeadings([]TOCEntry{
+		{Level: 2, Text: "Intro"},
+		{Level: 4, Text: "Detail"},
+		{Level: 3, Text: "Aside"},
+		{Level: 1, Text: "Top"},
+	})
+
+	if len(toc) != 2 || toc[0].Text != "Intro" || toc[1].Text != "Top" {
+		t.Fatalf("expected Intro and Top at the root, got %+v", toc)
+	}
+	if len(toc[0].Children) != 2 || toc[0].Children[0].Text != "Detail" || toc[0].Children[1].Text != "Aside" {
+		t.Errorf("expected Detail and Aside under Intro, got %+v", toc[0].Children)
+	}
+}
+
+func TestUniqueAnchor(t *testing.T) {
+	seen := make(map[string]int)
+	var got []string
+	for _, anchor := range []string{"intro", "intro", "intro-1", "intro"} {
+		got = append(got, uniqueAnchor(seen, anchor))
+	}
+
+	expected := []string{"intro", "intro-1", "intro-1-1", "intro-2"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,370 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+)
+
+const (
+	// DefaultRelatedLimit is the number of related posts returned when none is configured
+	DefaultRelatedLimit = 5
+	// MaxRelatedLimit caps the number of related posts a request may ask for
+	MaxRelatedLimit = 20
+
+	// relatedIndexDepth is how many candidates are stored per post; extra rows
+	// leave room for candidates that are hidden when the list is read
+	relatedIndexDepth = 50
+	// relatedTagWeight is the share of a score that comes from tag overlap; TF-IDF similarity provides the rest
+	relatedTagWeight = 0.5
+	// relatedTitleBoost counts title terms this many times when building a post's term vector
+	relatedTitleBoost = 2
+)
+
+// RelatedOptions controls how related posts are ranked and how many are returned
+type RelatedOptions struct {
+	Limit           int
+	RecencyHalfLife time.Duration // Age at which a candidate's score halves; zero disables recency weighting
+}
+
+// RelatedPost is a post recommended alongside another, with its ranking score
+type RelatedPost struct {
+	Post  Post
+	Score float64
+}
+
+// RelatedPostRepository maintains and queries the precomputed related-posts index
+type RelatedPostRepository struct {
+	db db.QueryExecutor
+}
+
+// NewRelatedPostRepository creates a new related post repository
+func NewRelatedPostRepository(db db.QueryExecutor) *RelatedPostRepository {
+	return &RelatedPostRepository{db: db}
+}
+
+// relatedDocument is the text and tags of a post used to compute similarity
+type relatedDocument struct {
+	id    uuid.UUID
+	tags  []string
+	terms map[string]float64 // Term frequencies
+}
+
+// relatedScore is a candidate's similarity to a post
+type relatedScore struct {
+	id    uuid.UUID
+	score float64
+}
+
+// List returns the publicly visible posts most related to postID, best first
+func (r *RelatedPostRepository) List(ctx context.Context, postID uuid.UUID, now time.Time, opts RelatedOptions) ([]RelatedPost, error) {
+	query := `
+		SELECT ` + postColumns + `, s.score
+		FROM posts
+		JOIN (SELECT related_id, score FROM post_similarity WHERE post_id = $1) s ON s.related_id = posts.id
+		WHERE status = $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, postID, PostStatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list related posts: %w", err)
+	}
+	defer rows.Close()
+
+	var related []RelatedPost
+	for rows.Next() {
+		candidate := RelatedPost{}
+		post := &candidate.Post
+		if err := scanPost(rows, post, &candidate.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan related post: %w", err)
+		}
+
+		if !post.IsPublic(now) {
+			continue
+		}
+		candidate.Score *= recencyWeight(post, now, opts.RecencyHalfLife)
+		related = append(related, candidate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list related posts: %w", err)
+	}
+
+	sort.SliceStable(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		return related[i].Post.Slug < related[j].Post.Slug
+	})
+
+	if opts.Limit > 0 && len(related) > opts.Limit {
+		related = related[:opts.Limit]
+	}
+
+	return related, nil
+}
+
+// Rebuild recomputes the whole index from every post in a single transaction
+// All posts are indexed whatever their status; visibility is applied when the index is read
+func (r *RelatedPostRepository) Rebuild(ctx context.Context, now time.Time) error {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, title, body, tags FROM posts`)
+	if err != nil {
+		return fmt.Errorf("failed to load posts for related index: %w", err)
+	}
+
+	var docs []relatedDocument
+	for rows.Next() {
+		var id uuid.UUID
+		var title, body string
+		var tags []string
+		if err := rows.Scan(&id, &title, &body, (*tagList)(&tags)); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan post for related index: %w", err)
+		}
+		docs = append(docs, newRelatedDocument(id, title, body, tags))
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("failed to load posts for related index: %w", err)
+	}
+
+	index := buildRelatedIndex(docs, relatedIndexDepth)
+
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM post_similarity`); err != nil {
+			return fmt.Errorf("failed to clear related index: %w", err)
+		}
+
+		for _, doc := range docs {
+			for _, candidate := range index[doc.id] {
+				query := `INSERT INTO post_similarity (post_id, related_id, score) VALUES ($1, $2, $3)`
+				if _, err := tx.ExecContext(ctx, query, doc.id, candidate.id, candidate.score); err != nil {
+					return fmt.Errorf("failed to store related post: %w", err)
+				}
+			}
+		}
+
+		query := `
+			INSERT INTO related_index_state (id, post_count, built_at)
+			VALUES (1, $1, $2)
+			ON CONFLICT (id) DO UPDATE SET post_count = excluded.post_count, built_at = excluded.built_at
+		`
+		if _, err := tx.ExecContext(ctx, query, len(docs), now); err != nil {
+			return fmt.Errorf("failed to record related index build: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// IsStale reports whether posts were added, changed or removed since the index was last built
+func (r *RelatedPostRepository) IsStale(ctx context.Context) (bool, error) {
+	var indexed int
+	var builtAt time.Time
+	err := r.db.QueryRowContext(ctx, `SELECT post_count, built_at FROM related_index_state WHERE id = 1`).Scan(&indexed, &builtAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read related index state: %w", err)
+	}
+
+	var total, changed int
+	query := `SELECT COUNT(*), COUNT(CASE WHEN updated_at > $1 THEN 1 END) FROM posts`
+	if err := r.db.QueryRowContext(ctx, query, builtAt).Scan(&total, &changed); err != nil {
+		return false, fmt.Errorf("failed to check posts for related index: %w", err)
+	}
+
+	return total != indexed || changed > 0, nil
+}
+
+// RefreshIfStale rebuilds the index when posts changed since it was last built
+func (r *RelatedPostRepository) RefreshIfStale(ctx context.Context, now time.Time) (bool, error) {
+	stale, err := r.IsStale(ctx)
+	if err != nil || !stale {
+		return false, err
+	}
+
+	if err := r.Rebuild(ctx, now); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// newRelatedDocument tokenizes a post's title and body into term frequencies
+func newRelatedDocument(id uuid.UUID, title, body string, tags []string) relatedDocument {
+	counts := make(map[string]float64)
+	total := 0.0
+
+	for _, term := range relatedTerms(title) {
+		counts[term] += relatedTitleBoost
+		total += relatedTitleBoost
+	}
+	for _, term := range relatedTerms(body) {
+		counts[term]++
+		total++
+	}
+
+	for term := range counts {
+		counts[term] /= total
+	}
+
+	return relatedDocument{id: id, tags: tags, terms: counts}
+}
+
+// buildRelatedIndex scores every pair of documents and keeps the best depth candidates per document
+// A pair's score blends the Jaccard overlap of their tags with the cosine similarity of their TF-IDF vectors
+func buildRelatedIndex(docs []relatedDocument, depth int) map[uuid.UUID][]relatedScore {
+	documentFrequency := make(map[string]int)
+	for _, doc := range docs {
+		for term := range doc.terms {
+			documentFrequency[term]++
+		}
+	}
+
+	// Smoothed IDF is zero for terms found in every document, so they never contribute
+	vectors := make([]map[string]float64, len(docs))
+	for i, doc := range docs {
+		vector := make(map[string]float64, len(doc.terms))
+		norm := 0.0
+		for term, tf := range doc.terms {
+			weight := tf * math.Log(float64(1+len(docs))/float64(1+documentFrequency[term]))
+			if weight > 0 {
+				vector[term] = weight
+				norm += weight * weight
+			}
+		}
+		norm = math.Sqrt(norm)
+		for term := range vector {
+			vector[term] /= norm
+		}
+		vectors[i] = vector
+	}
+
+	index := make(map[uuid.UUID][]relatedScore, len(docs))
+	for i := range docs {
+		for j := i + 1; j < len(docs); j++ {
+			score := relatedTagWeight*tagOverlap(docs[i].tags, docs[j].tags) +
+				(1-relatedTagWeight)*cosine(vectors[i], vectors[j])
+			if score <= 0 {
+				continue
+			}
+			index[docs[i].id] = append(index[docs[i].id], relatedScore{id: docs[j].id, score: score})
+			index[docs[j].id] = append(index[docs[j].id], relatedScore{id: docs[i].id, score: score})
+		}
+	}
+
+	for id, candidates := range index {
+		sort.Slice(candidates, func(a, b int) bool {
+			if candidates[a].score != candidates[b].score {
+				return candidates[a].score > candidates[b].score
+			}
+			return candidates[a].id.String() < candidates[b].id.String()
+		})
+		if len(candidates) > depth {
+			index[id] = candidates[:depth]
+		}
+	}
+
+	return index
+}
+
+// relatedStopWords are common English words that say nothing about a post's topic
+var relatedStopWords = map[string]bool{
+	"about": true, "after": true, "also": true, "and": true, "are": true, "because": true,
+	"but": true, "can": true, "could": true, "for": true, "from": true, "had": true,
+	"has": true, "have": true, "how": true, "into": true, "its": true, "just": true,
+	"more": true, "not": true, "now": true, "one": true, "only": true, "our": true,
+	"out": true, "should": true, "some": true, "than": true, "that": true, "the": true,
+	"their": true, "them": true, "then": true, "there": true, "these": true, "they": true,
+	"this": true, "use": true, "using": true, "was": true, "were": true, "what": true,
+	"when": true, "which": true, "while": true, "who": true, "why": true, "will": true,
+	"with": true, "would": true, "you": true, "your": true,
+}
+
+// relatedTerms splits text into lowercase words, dropping short words and stop words
+func relatedTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := words[:0]
+	for _, word := range words {
+		if len(word) < 3 || relatedStopWords[word] {
+			continue
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+// tagOverlap returns the Jaccard similarity of two tag sets
+func tagOverlap(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	set := make(map[string]bool, len(a))
+	for _, tag := range a {
+		set[tag] = true
+	}
+
+	shared := 0
+	union := len(set)
+	seen := make(map[string]bool, len(b))
+	for _, tag := range b {
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		if set[tag] {
+			shared++
+		} else {
+			union++
+		}
+	}
+
+	return float64(shared) / float64(union)
+}
+
+// cosine returns the dot product of two unit-length sparse vectors
+func cosine(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+
+	dot := 0.0
+	for term, weight := range a {
+		dot += weight * b[term]
+	}
+	return dot
+}
+
+// recencyWeight halves a post's weight for every halfLife since it was published
+func recencyWeight(post *Post, now time.Time, halfLife time.Duration) float64 {
+	if halfLife <= 0 {
+		return 1
+	}
+
+	published := post.CreatedAt
+	if post.PublishedAt != nil {
+		published = *post.PublishedAt
+	}
+
+	age := now.Sub(published)
+	if age <= 0 {
+		return 1
+	}
+
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
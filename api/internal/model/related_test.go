@@ -0,0 +1,88 @@
+package model
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRelatedTerms(t *testing.T) {
+	got := relatedTerms("Building a REST API with Go: the basics, part 2!")
+	expected := []string{"building", "rest", "api", "basics", "part"}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestTagOverlap(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     []string
+		expected float64
+	}{
+		{name: "identical", a: []string{"go", "sql"}, b: []string{"sql", "go"}, expected: 1},
+		{name: "partial", a: []string{"go", "sql"}, b: []string{"go", "aws"}, expected: 1.0 / 3},
+		{name: "disjoint", a: []string{"go"}, b: []string{"aws"}, expected: 0},
+		{name: "empty", a: nil, b: []string{"go"}, expected: 0},
+		{name: "duplicates ignored", a: []string{"go"}, b: []string{"go", "go"}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tagOverlap(tt.a, tt.b); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBuildRelatedIndex(t *testing.T) {
+	goAPI := newRelatedDocument(uuid.New(), "Writing HTTP handlers in Go", "Gin routers, middleware and handlers for a JSON API", []string{"go", "api"})
+	goTests := newRelatedDocument(uuid.New(), "Testing HTTP handlers", "Table-driven tests for handlers and middleware", []string{"go", "testing"})
+	baking := newRelatedDocument(uuid.New(), "Sourdough starter notes", "Flour, water and patience", []string{"baking"})
+
+	index := buildRelatedIndex([]relatedDocument{goAPI, goTests, baking}, 10)
+
+	candidates := index[goAPI.id]
+	if len(candidates) != 1 || candidates[0].id != goTests.id {
+		t.Fatalf("expected only the testing post to relate to the API post, got %+v", candidates)
+	}
+	if candidates[0].score <= relatedTagWeight/3 {
+		t.Errorf("expected text similarity to add to tag overlap, got score %v", candidates[0].score)
+	}
+
+	if reverse := index[goTests.id]; len(reverse) != 1 || reverse[0].score != candidates[0].score {
+		t.Errorf("expected symmetric scores, got %+v", reverse)
+	}
+
+	if len(index[baking.id]) != 0 {
+		t.Errorf("expected no candidates for an unrelated post, got %+v", index[baking.id])
+	}
+
+	if shallow := buildRelatedIndex([]relatedDocument{goAPI, goTests, baking}, 0); len(shallow[goAPI.id]) != 0 {
+		t.Errorf("expected depth to cap candidates, got %+v", shallow[goAPI.id])
+	}
+}
+
+func TestRecencyWeight(t *testing.T) {
+	now := time.Now().UTC()
+	yearAgo := now.Add(-365 * 24 * time.Hour)
+	post := &Post{PublishedAt: &yearAgo}
+
+	if got := recencyWeight(post, now, 0); got != 1 {
+		t.Errorf("expected weighting disabled without a half-life, got %v", got)
+	}
+
+	if got := recencyWeight(post, now, 365*24*time.Hour); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("expected weight 0.5 after one half-life, got %v", got)
+	}
+
+	future := now.Add(time.Hour)
+	if got := recencyWeight(&Post{PublishedAt: &future}, now, time.Hour); got != 1 {
+		t.Errorf("expected full weight for a post newer than now, got %v", got)
+	}
+}
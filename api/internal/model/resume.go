@@ -0,0 +1,612 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// ResumeSectionKind is what a resume section describes
+type ResumeSectionKind string
+
+const (
+	ResumeSectionExperience ResumeSectionKind = "experience"
+	ResumeSectionSkills     ResumeSectionKind = "skills"
+	ResumeSectionEducation  ResumeSectionKind = "education"
+)
+
+// ResumeSection is one entry on a resume, such as a role, a group of skills or a degree
+// Sections are written once and shared by every variant that includes them
+type ResumeSection struct {
+	ID           uuid.UUID
+	Kind         ResumeSectionKind
+	Title        string // Role, skill group or degree
+	Organization string
+	Location     string
+	StartDate    string // YYYY-MM, empty when not dated
+	EndDate      string // YYYY-MM, empty while ongoing
+	Summary      string
+	Highlights   []string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ResumeVariant is a resume tailored for an audience, such as consulting or engineering leadership
+type ResumeVariant struct {
+	ID        uuid.UUID
+	Slug      string
+	Name      string
+	Headline  string
+	Summary   string
+	Sections  []ResumeSection // Included sections in display order
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ResumeRepository handles resume variant and section data access
+type ResumeRepository struct {
+	db db.QueryExecutor
+}
+
+// NewResumeRepository creates a new resume repository
+func NewResumeRepository(db db.QueryExecutor) *ResumeRepository {
+	return &ResumeRepository{db: db}
+}
+
+// resumeDatePattern matches a year and month such as 2024-03
+var resumeDatePattern = regexp.MustCompile(`^\d{4}-(0[1-9]|1[0-2])$`)
+
+// IsValid reports whether k is a known section kind
+func (k ResumeSectionKind) IsValid() bool {
+	switch k {
+	case ResumeSectionExperience, ResumeSectionSkills, ResumeSectionEducation:
+		return true
+	}
+	return false
+}
+
+// Validate ensures the section meets business requirements
+func (s *ResumeSection) Validate() error {
+	if !s.Kind.IsValid() {
+		return apierrors.ValidationError{Message: "kind must be one of: experience, skills, education"}
+	}
+
+	if strings.TrimSpace(s.Title) == "" {
+		return apierrors.ValidationError{Message: "title is required"}
+	}
+
+	if len(s.Title) > 255 {
+		return apierrors.ValidationError{Message: "title must be 255 characters or less"}
+	}
+
+	if len(s.Organization) > 255 {
+		return apierrors.ValidationError{Message: "organization must be 255 characters or less"}
+	}
+
+	if len(s.Location) > 255 {
+		return apierrors.ValidationError{Message: "location must be 255 characters or less"}
+	}
+
+	if s.StartDate != "" && !resumeDatePattern.MatchString(s.StartDate) {
+		return apierrors.ValidationError{Message: "start_date must be formatted YYYY-MM"}
+	}
+
+	if s.EndDate != "" && !resumeDatePattern.MatchString(s.EndDate) {
+		return apierrors.ValidationError{Message: "end_date must be formatted YYYY-MM"}
+	}
+
+	// YYYY-MM strings order the same way as the months they name
+	if s.StartDate != "" && s.EndDate != "" && s.EndDate < s.StartDate {
+		return apierrors.ValidationError{Message: "end_date must not be before start_date"}
+	}
+
+	if len(s.Summary) > 5000 {
+		return apierrors.ValidationError{Message: "summary must be 5000 characters or less"}
+	}
+
+	if len(s.Highlights) > 50 {
+		return apierrors.ValidationError{Message: "a section may have at most 50 highlights"}
+	}
+
+	for _, highlight := range s.Highlights {
+		if strings.TrimSpace(highlight) == "" {
+			return apierrors.ValidationError{Message: "highlights must not be empty"}
+		}
+		if len(highlight) > 1000 {
+			return apierrors.ValidationError{Message: "highlights must be 1000 characters or less"}
+		}
+	}
+
+	return nil
+}
+
+// Validate ensures the variant meets business requirements
+func (v *ResumeVariant) Validate() error {
+	if strings.TrimSpace(v.Slug) == "" {
+		return apierrors.ValidationError{Message: "slug is required"}
+	}
+
+	if !isValidSlug(v.Slug) {
+		return apierrors.ValidationError{Message: "slug must be lowercase alphanumeric with hyphens only"}
+	}
+
+	if strings.TrimSpace(v.Name) == "" {
+		return apierrors.ValidationError{Message: "name is required"}
+	}
+
+	if len(v.Name) > 255 {
+		return apierrors.ValidationError{Message: "name must be 255 characters or less"}
+	}
+
+	if len(v.Headline) > 255 {
+		return apierrors.ValidationError{Message: "headline must be 255 characters or less"}
+	}
+
+	if len(v.Summary) > 5000 {
+		return apierrors.ValidationError{Message: "summary must be 5000 characters or less"}
+	}
+
+	return nil
+}
+
+// CreateSection inserts a new section, not yet included in any variant
+func (r *ResumeRepository) CreateSection(ctx context.Context, section *ResumeSection) error {
+	if section.ID == uuid.Nil {
+		section.ID = uuid.New()
+	}
+
+	if err := section.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	section.CreatedAt = now
+	section.UpdatedAt = now
+
+	query := `
+		INSERT INTO resume_sections (id, kind, title, organization, location, start_date, end_date, summary, highlights, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		section.ID,
+		section.Kind,
+		section.Title,
+		section.Organization,
+		section.Location,
+		section.StartDate,
+		section.EndDate,
+		section.Summary,
+		tagList(section.Highlights),
+		section.CreatedAt,
+		section.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create resume section: %w", err)
+	}
+
+	return nil
+}
+
+// GetSection retrieves a section by ID
+func (r *ResumeRepository) GetSection(ctx context.Context, id uuid.UUID) (*ResumeSection, error) {
+	section := &ResumeSection{}
+
+	query := `
+		SELECT ` + resumeSectionColumns + `
+		FROM resume_sections
+		WHERE id = $1
+	`
+
+	if err := scanResumeSection(r.db.QueryRowContext(ctx, query, id), section); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, apierrors.NotFoundError{Message: "resume section not found"}
+	}
+
+	return section, nil
+}
+
+// ListSections retrieves every section grouped by kind, most recent first
+func (r *ResumeRepository) ListSections(ctx context.Context) ([]ResumeSection, error) {
+	query := `
+		SELECT ` + resumeSectionColumns + `
+		FROM resume_sections
+		ORDER BY kind ASC, start_date DESC, title ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resume sections: %w", err)
+	}
+	defer rows.Close()
+
+	var sections []ResumeSection
+	for rows.Next() {
+		section := ResumeSection{}
+		if err := scanResumeSection(rows, &section); err != nil {
+			return nil, fmt.Errorf("failed to scan resume section: %w", err)
+		}
+		sections = append(sections, section)
+	}
+
+	return sections, rows.Err()
+}
+
+// UpdateSection replaces a section's content
+// Variants including the section are marked updated so their caches and Last-Modified move with it
+func (r *ResumeRepository) UpdateSection(ctx context.Context, section *ResumeSection) error {
+	if section.ID == uuid.Nil {
+		return apierrors.ValidationError{Message: "resume section ID is required"}
+	}
+
+	if err := section.Validate(); err != nil {
+		return err
+	}
+
+	section.UpdatedAt = time.Now().UTC()
+
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		query := `
+			UPDATE resume_sections
+			SET kind = $1, title = $2, organization = $3, location = $4, start_date = $5, end_date = $6,
+				summary = $7, highlights = $8, updated_at = $9
+			WHERE id = $10
+		`
+
+		result, err := tx.ExecContext(ctx, query,
+			section.Kind,
+			section.Title,
+			section.Organization,
+			section.Location,
+			section.StartDate,
+			section.EndDate,
+			section.Summary,
+			tagList(section.Highlights),
+			section.UpdatedAt,
+			section.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update resume section: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return apierrors.NotFoundError{Message: "resume section not found"}
+		}
+
+		return touchVariantsWithSection(ctx, tx, section.ID, section.UpdatedAt)
+	})
+}
+
+// DeleteSection removes a section from every variant and deletes it
+func (r *ResumeRepository) DeleteSection(ctx context.Context, id uuid.UUID) error {
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		if err := touchVariantsWithSection(ctx, tx, id, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM resume_sections WHERE id = $1`, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete resume section: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return apierrors.NotFoundError{Message: "resume section not found"}
+		}
+
+		return nil
+	})
+}
+
+// CreateVariant inserts a new variant without sections
+func (r *ResumeRepository) CreateVariant(ctx context.Context, variant *ResumeVariant) error {
+	if variant.ID == uuid.Nil {
+		variant.ID = uuid.New()
+	}
+
+	if err := variant.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	variant.CreatedAt = now
+	variant.UpdatedAt = now
+
+	query := `
+		INSERT INTO resume_variants (id, slug, name, headline, summary, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		variant.ID,
+		variant.Slug,
+		variant.Name,
+		variant.Headline,
+		variant.Summary,
+		variant.CreatedAt,
+		variant.UpdatedAt,
+	)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+			return apierrors.ConflictError{Message: fmt.Sprintf("resume variant with slug '%s' already exists", variant.Slug)}
+		}
+		return fmt.Errorf("failed to create resume variant: %w", err)
+	}
+
+	return nil
+}
+
+// GetVariantByID retrieves a variant with its sections in order
+func (r *ResumeRepository) GetVariantByID(ctx context.Context, id uuid.UUID) (*ResumeVariant, error) {
+	return r.getVariant(ctx, `WHERE id = $1`, id)
+}
+
+// GetVariantBySlug retrieves a variant with its sections in order
+func (r *ResumeRepository) GetVariantBySlug(ctx context.Context, slug string) (*ResumeVariant, error) {
+	return r.getVariant(ctx, `WHERE slug = $1`, slug)
+}
+
+// ListVariants retrieves all variants ordered by name, without sections
+func (r *ResumeRepository) ListVariants(ctx context.Context) ([]ResumeVariant, error) {
+	query := `
+		SELECT id, slug, name, headline, summary, created_at, updated_at
+		FROM resume_variants
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resume variants: %w", err)
+	}
+	defer rows.Close()
+
+	var variants []ResumeVariant
+	for rows.Next() {
+		variant := ResumeVariant{}
+		if err := scanResumeVariant(rows, &variant); err != nil {
+			return nil, fmt.Errorf("failed to scan resume variant: %w", err)
+		}
+		variants = append(variants, variant)
+	}
+
+	return variants, rows.Err()
+}
+
+// UpdateVariant updates a variant's slug, name, headline and summary
+func (r *ResumeRepository) UpdateVariant(ctx context.Context, variant *ResumeVariant) error {
+	if variant.ID == uuid.Nil {
+		return apierrors.ValidationError{Message: "resume variant ID is required"}
+	}
+
+	if err := variant.Validate(); err != nil {
+		return err
+	}
+
+	variant.UpdatedAt = time.Now().UTC()
+
+	query := `
+		UPDATE resume_variants
+		SET slug = $1, name = $2, headline = $3, summary = $4, updated_at = $5
+		WHERE id = $6
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		variant.Slug,
+		variant.Name,
+		variant.Headline,
+		variant.Summary,
+		variant.UpdatedAt,
+		variant.ID,
+	)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+			return apierrors.ConflictError{Message: fmt.Sprintf("resume variant with slug '%s' already exists", variant.Slug)}
+		}
+		return fmt.Errorf("failed to update resume variant: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "resume variant not found"}
+	}
+
+	return nil
+}
+
+// DeleteVariant removes a variant; its sections are kept for the other variants
+func (r *ResumeRepository) DeleteVariant(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM resume_variants WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete resume variant: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "resume variant not found"}
+	}
+
+	return nil
+}
+
+// SetVariantSections replaces the sections a variant includes with sectionIDs, in that order, in a single transaction
+func (r *ResumeRepository) SetVariantSections(ctx context.Context, id uuid.UUID, sectionIDs []uuid.UUID) error {
+	seen := make(map[uuid.UUID]bool, len(sectionIDs))
+	for _, sectionID := range sectionIDs {
+		if seen[sectionID] {
+			return apierrors.ValidationError{Message: fmt.Sprintf("section %s is listed more than once", sectionID)}
+		}
+		seen[sectionID] = true
+	}
+
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		var count int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM resume_variants WHERE id = $1`, id).Scan(&count); err != nil {
+			return fmt.Errorf("failed to check resume variant: %w", err)
+		}
+		if count == 0 {
+			return apierrors.NotFoundError{Message: "resume variant not found"}
+		}
+
+		for _, sectionID := range sectionIDs {
+			if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM resume_sections WHERE id = $1`, sectionID).Scan(&count); err != nil {
+				return fmt.Errorf("failed to check resume section: %w", err)
+			}
+			if count == 0 {
+				return apierrors.ValidationError{Message: fmt.Sprintf("section %s not found", sectionID)}
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM resume_variant_sections WHERE variant_id = $1`, id); err != nil {
+			return fmt.Errorf("failed to clear resume variant sections: %w", err)
+		}
+
+		for i, sectionID := range sectionIDs {
+			query := `INSERT INTO resume_variant_sections (variant_id, section_id, position) VALUES ($1, $2, $3)`
+			if _, err := tx.ExecContext(ctx, query, id, sectionID, i+1); err != nil {
+				return fmt.Errorf("failed to add resume variant section: %w", err)
+			}
+		}
+
+		query := `UPDATE resume_variants SET updated_at = $1 WHERE id = $2`
+		if _, err := tx.ExecContext(ctx, query, time.Now().UTC(), id); err != nil {
+			return fmt.Errorf("failed to update resume variant: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// getVariant retrieves a single variant matching the given WHERE clause, with its sections
+func (r *ResumeRepository) getVariant(ctx context.Context, where string, arg interface{}) (*ResumeVariant, error) {
+	variant := &ResumeVariant{}
+
+	query := `
+		SELECT id, slug, name, headline, summary, created_at, updated_at
+		FROM resume_variants
+		` + where
+
+	if err := scanResumeVariant(r.db.QueryRowContext(ctx, query, arg), variant); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, apierrors.NotFoundError{Message: "resume variant not found"}
+	}
+
+	query = `
+		SELECT ` + resumeSectionColumns + `
+		FROM resume_sections
+		JOIN resume_variant_sections ON section_id = id
+		WHERE variant_id = $1
+		ORDER BY position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, variant.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resume variant sections: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		section := ResumeSection{}
+		if err := scanResumeSection(rows, &section); err != nil {
+			return nil, fmt.Errorf("failed to scan resume section: %w", err)
+		}
+		variant.Sections = append(variant.Sections, section)
+	}
+
+	return variant, rows.Err()
+}
+
+// touchVariantsWithSection marks every variant including a section as updated at now
+func touchVariantsWithSection(ctx context.Context, tx db.QueryExecutor, sectionID uuid.UUID, now time.Time) error {
+	query := `
+		UPDATE resume_variants SET updated_at = $1
+		WHERE id IN (SELECT variant_id FROM resume_variant_sections WHERE section_id = $2)
+	`
+	if _, err := tx.ExecContext(ctx, query, now, sectionID); err != nil {
+		return fmt.Errorf("failed to update resume variants: %w", err)
+	}
+	return nil
+}
+
+// resumeSectionColumns lists the resume_sections columns in the order scanResumeSection reads them
+// None of them are also in resume_variant_sections, so they can be selected unqualified across the join
+const resumeSectionColumns = `id, kind, title, organization, location, start_date, end_date, summary, highlights, created_at, updated_at`
+
+// scanResumeSection reads a section row
+func scanResumeSection(row rowScanner, section *ResumeSection) error {
+	var organization, location, startDate, endDate, summary *string
+	err := row.Scan(
+		&section.ID,
+		&section.Kind,
+		&section.Title,
+		&organization,
+		&location,
+		&startDate,
+		&endDate,
+		&summary,
+		(*tagList)(&section.Highlights),
+		&section.CreatedAt,
+		&section.UpdatedAt,
+	)
+	section.Organization = derefString(organization)
+	section.Location = derefString(location)
+	section.StartDate = derefString(startDate)
+	section.EndDate = derefString(endDate)
+	section.Summary = derefString(summary)
+	return err
+}
+
+// scanResumeVariant reads a variant row
+func scanResumeVariant(row rowScanner, variant *ResumeVariant) error {
+	var headline, summary *string
+	err := row.Scan(
+		&variant.ID,
+		&variant.Slug,
+		&variant.Name,
+		&headline,
+		&summary,
+		&variant.CreatedAt,
+		&variant.UpdatedAt,
+	)
+	variant.Headline = derefString(headline)
+	variant.Summary = derefString(summary)
+	return err
+}
+
+// derefString returns the string a nullable column scanned into, or "" for NULL
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
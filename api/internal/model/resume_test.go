@@ -0,0 +1,71 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResumeSectionValidate(t *testing.T) {
+	valid := func() *ResumeSection {
+		return &ResumeSection{Kind: ResumeSectionExperience, Title: "Staff Engineer", StartDate: "2020-01", EndDate: "2023-06"}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(s *ResumeSection)
+		shouldErr bool
+	}{
+		{name: "valid section", modify: func(s *ResumeSection) {}, shouldErr: false},
+		{name: "undated section", modify: func(s *ResumeSection) { s.StartDate, s.EndDate = "", "" }, shouldErr: false},
+		{name: "ongoing role", modify: func(s *ResumeSection) { s.EndDate = "" }, shouldErr: false},
+		{name: "same start and end month", modify: func(s *ResumeSection) { s.EndDate = s.StartDate }, shouldErr: false},
+		{name: "unknown kind", modify: func(s *ResumeSection) { s.Kind = "hobbies" }, shouldErr: true},
+		{name: "missing title", modify: func(s *ResumeSection) { s.Title = " " }, shouldErr: true},
+		{name: "title too long", modify: func(s *ResumeSection) { s.Title = strings.Repeat("a", 256) }, shouldErr: true},
+		{name: "full date", modify: func(s *ResumeSection) { s.StartDate = "2020-01-15" }, shouldErr: true},
+		{name: "invalid month", modify: func(s *ResumeSection) { s.EndDate = "2023-13" }, shouldErr: true},
+		{name: "ends before it starts", modify: func(s *ResumeSection) { s.EndDate = "2019-12" }, shouldErr: true},
+		{name: "blank highlight", modify: func(s *ResumeSection) { s.Highlights = []string{"Led migration", ""} }, shouldErr: true},
+		{name: "too many highlights", modify: func(s *ResumeSection) { s.Highlights = make([]string, 51) }, shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := valid()
+			tt.modify(section)
+			err := section.Validate()
+			if tt.shouldErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.shouldErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestResumeVariantValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		variant   *ResumeVariant
+		shouldErr bool
+	}{
+		{name: "valid variant", variant: &ResumeVariant{Slug: "consulting", Name: "Consulting"}, shouldErr: false},
+		{name: "missing slug", variant: &ResumeVariant{Name: "Consulting"}, shouldErr: true},
+		{name: "invalid slug", variant: &ResumeVariant{Slug: "Engineering Leadership", Name: "Leadership"}, shouldErr: true},
+		{name: "missing name", variant: &ResumeVariant{Slug: "consulting"}, shouldErr: true},
+		{name: "headline too long", variant: &ResumeVariant{Slug: "consulting", Name: "Consulting", Headline: strings.Repeat("a", 256)}, shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.variant.Validate()
+			if tt.shouldErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.shouldErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,496 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// Series represents an ordered collection of posts, such as a multi-part article
+type Series struct {
+	ID          uuid.UUID
+	Slug        string
+	Title       string
+	Description string
+	Posts       []SeriesPost // Members in reading order
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// SeriesPost is a post's place within a series
+type SeriesPost struct {
+	PostID   uuid.UUID
+	Slug     string
+	Title    string
+	Position int // 1-based position among the members shown
+
+	post Post // Visibility fields used to filter members for public readers
+}
+
+// SeriesNavigation locates a post within its series
+type SeriesNavigation struct {
+	SeriesID    uuid.UUID
+	SeriesSlug  string
+	SeriesTitle string
+	Position    int
+	Total       int
+	Previous    *SeriesPost
+	Next        *SeriesPost
+}
+
+// SeriesRepository handles series data access
+type SeriesRepository struct {
+	db db.QueryExecutor
+}
+
+// NewSeriesRepository creates a new series repository
+func NewSeriesRepository(db db.QueryExecutor) *SeriesRepository {
+	return &SeriesRepository{db: db}
+}
+
+// Validate ensures the series meets business requirements
+func (s *Series) Validate() error {
+	if strings.TrimSpace(s.Slug) == "" {
+		return apierrors.ValidationError{Message: "slug is required"}
+	}
+
+	if !isValidSlug(s.Slug) {
+		return apierrors.ValidationError{Message: "slug must be lowercase alphanumeric with hyphens only"}
+	}
+
+	if strings.TrimSpace(s.Title) == "" {
+		return apierrors.ValidationError{Message: "title is required"}
+	}
+
+	if len(s.Title) > 255 {
+		return apierrors.ValidationError{Message: "title must be 255 characters or less"}
+	}
+
+	if len(s.Description) > 5000 {
+		return apierrors.ValidationError{Message: "description must be 5000 characters or less"}
+	}
+
+	return nil
+}
+
+// Create inserts a new series without members
+func (r *SeriesRepository) Create(ctx context.Context, series *Series) error {
+	if series.ID == uuid.Nil {
+		series.ID = uuid.New()
+	}
+
+	if err := series.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	series.CreatedAt = now
+	series.UpdatedAt = now
+
+	query := `
+		INSERT INTO series (id, slug, title, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		series.ID,
+		series.Slug,
+		series.Title,
+		series.Description,
+		series.CreatedAt,
+		series.UpdatedAt,
+	)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+			return apierrors.ConflictError{Message: fmt.Sprintf("series with slug '%s' already exists", series.Slug)}
+		}
+		return fmt.Errorf("failed to create series: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a series with all of its members, whatever their status
+func (r *SeriesRepository) GetByID(ctx context.Context, id uuid.UUID) (*Series, error) {
+	series, err := r.get(ctx, `WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := r.members(ctx, []uuid.UUID{series.ID})
+	if err != nil {
+		return nil, err
+	}
+	series.Posts = numbered(members[series.ID])
+
+	return series, nil
+}
+
+// GetBySlug retrieves a series with the members that are publicly visible at the given time
+func (r *SeriesRepository) GetBySlug(ctx context.Context, slug string, now time.Time) (*Series, error) {
+	series, err := r.get(ctx, `WHERE slug = $1`, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := r.members(ctx, []uuid.UUID{series.ID})
+	if err != nil {
+		return nil, err
+	}
+	series.Posts = numbered(visibleMembers(members[series.ID], now, uuid.Nil))
+
+	return series, nil
+}
+
+// List retrieves all series ordered by title, without members
+func (r *SeriesRepository) List(ctx context.Context) ([]Series, error) {
+	query := `
+		SELECT id, slug, title, description, created_at, updated_at
+		FROM series
+		ORDER BY title ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series: %w", err)
+	}
+	defer rows.Close()
+
+	var list []Series
+	for rows.Next() {
+		series := Series{}
+		if err := scanSeries(rows, &series); err != nil {
+			return nil, fmt.Errorf("failed to scan series: %w", err)
+		}
+		list = append(list, series)
+	}
+
+	return list, rows.Err()
+}
+
+// Update updates a series' slug, title and description
+func (r *SeriesRepository) Update(ctx context.Context, series *Series) error {
+	if series.ID == uuid.Nil {
+		return apierrors.ValidationError{Message: "series ID is required"}
+	}
+
+	if err := series.Validate(); err != nil {
+		return err
+	}
+
+	series.UpdatedAt = time.Now().UTC()
+
+	query := `
+		UPDATE series
+		SET slug = $1, title = $2, description = $3, updated_at = $4
+		WHERE id = $5
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		series.Slug,
+		series.Title,
+		series.Description,
+		series.UpdatedAt,
+		series.ID,
+	)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+			return apierrors.ConflictError{Message: fmt.Sprintf("series with slug '%s' already exists", series.Slug)}
+		}
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "series not found"}
+	}
+
+	return nil
+}
+
+// Delete removes a series; its posts are kept
+func (r *SeriesRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM series WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete series: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "series not found"}
+	}
+
+	return nil
+}
+
+// SetPosts replaces a series' members with postIDs, in that order, in a single transaction
+// A post may belong to only one series
+func (r *SeriesRepository) SetPosts(ctx context.Context, id uuid.UUID, postIDs []uuid.UUID) error {
+	seen := make(map[uuid.UUID]bool, len(postIDs))
+	for _, postID := range postIDs {
+		if seen[postID] {
+			return apierrors.ValidationError{Message: fmt.Sprintf("post %s is listed more than once", postID)}
+		}
+		seen[postID] = true
+	}
+
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		var count int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM series WHERE id = $1`, id).Scan(&count); err != nil {
+			return fmt.Errorf("failed to check series: %w", err)
+		}
+		if count == 0 {
+			return apierrors.NotFoundError{Message: "series not found"}
+		}
+
+		for _, postID := range postIDs {
+			if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM posts WHERE id = $1`, postID).Scan(&count); err != nil {
+				return fmt.Errorf("failed to check post: %w", err)
+			}
+			if count == 0 {
+				return apierrors.ValidationError{Message: fmt.Sprintf("post %s not found", postID)}
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM series_posts WHERE series_id = $1`, id); err != nil {
+			return fmt.Errorf("failed to clear series posts: %w", err)
+		}
+
+		for i, postID := range postIDs {
+			query := `INSERT INTO series_posts (series_id, post_id, position) VALUES ($1, $2, $3)`
+			if _, err := tx.ExecContext(ctx, query, id, postID, i+1); err != nil {
+				if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+					return apierrors.ConflictError{Message: fmt.Sprintf("post %s already belongs to another series", postID)}
+				}
+				return fmt.Errorf("failed to add series post: %w", err)
+			}
+		}
+
+		query := `UPDATE series SET updated_at = $1 WHERE id = $2`
+		if _, err := tx.ExecContext(ctx, query, time.Now().UTC(), id); err != nil {
+			return fmt.Errorf("failed to update series: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Navigation locates each of the given posts within its series, keyed by post ID
+// Posts outside any series are absent from the result. Unless includeHidden is set,
+// neighbours and totals only count members that are publicly visible at now
+func (r *SeriesRepository) Navigation(ctx context.Context, postIDs []uuid.UUID, now time.Time, includeHidden bool) (map[uuid.UUID]*SeriesNavigation, error) {
+	navigation := make(map[uuid.UUID]*SeriesNavigation)
+	if len(postIDs) == 0 {
+		return navigation, nil
+	}
+
+	placeholders := make([]string, len(postIDs))
+	args := make([]interface{}, len(postIDs))
+	for i, postID := range postIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = postID
+	}
+
+	query := `
+		SELECT s.id, s.slug, s.title
+		FROM series s
+		WHERE s.id IN (SELECT series_id FROM series_posts WHERE post_id IN (` + strings.Join(placeholders, ", ") + `))
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post series: %w", err)
+	}
+
+	var found []Series
+	for rows.Next() {
+		series := Series{}
+		if err := rows.Scan(&series.ID, &series.Slug, &series.Title); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan series: %w", err)
+		}
+		found = append(found, series)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post series: %w", err)
+	}
+
+	if len(found) == 0 {
+		return navigation, nil
+	}
+
+	seriesIDs := make([]uuid.UUID, len(found))
+	for i, series := range found {
+		seriesIDs[i] = series.ID
+	}
+
+	members, err := r.members(ctx, seriesIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[uuid.UUID]bool, len(postIDs))
+	for _, postID := range postIDs {
+		wanted[postID] = true
+	}
+
+	for _, series := range found {
+		for _, member := range members[series.ID] {
+			if !wanted[member.PostID] {
+				continue
+			}
+
+			shown := members[series.ID]
+			if !includeHidden {
+				shown = visibleMembers(shown, now, member.PostID)
+			}
+			shown = numbered(shown)
+
+			for i, m := range shown {
+				if m.PostID != member.PostID {
+					continue
+				}
+				nav := &SeriesNavigation{
+					SeriesID:    series.ID,
+					SeriesSlug:  series.Slug,
+					SeriesTitle: series.Title,
+					Position:    m.Position,
+					Total:       len(shown),
+				}
+				if i > 0 {
+					nav.Previous = &shown[i-1]
+				}
+				if i < len(shown)-1 {
+					nav.Next = &shown[i+1]
+				}
+				navigation[member.PostID] = nav
+			}
+		}
+	}
+
+	return navigation, nil
+}
+
+// get retrieves a single series' metadata matching the given WHERE clause
+func (r *SeriesRepository) get(ctx context.Context, where string, arg interface{}) (*Series, error) {
+	series := &Series{}
+
+	query := `
+		SELECT id, slug, title, description, created_at, updated_at
+		FROM series
+		` + where
+
+	row := r.db.QueryRowContext(ctx, query, arg)
+	if err := scanSeries(row, series); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, apierrors.NotFoundError{Message: "series not found"}
+	}
+
+	return series, nil
+}
+
+// members loads the ordered members of each series, keyed by series ID
+func (r *SeriesRepository) members(ctx context.Context, seriesIDs []uuid.UUID) (map[uuid.UUID][]SeriesPost, error) {
+	placeholders := make([]string, len(seriesIDs))
+	args := make([]interface{}, len(seriesIDs))
+	for i, id := range seriesIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	query := `
+		SELECT sp.series_id, p.id, p.slug, p.title, p.status, p.published_at, p.unpublish_at, p.deleted_at
+		FROM series_posts sp
+		JOIN posts p ON p.id = sp.post_id
+		WHERE sp.series_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY sp.series_id, sp.position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series posts: %w", err)
+	}
+	defer rows.Close()
+
+	members := make(map[uuid.UUID][]SeriesPost)
+	for rows.Next() {
+		var seriesID uuid.UUID
+		member := SeriesPost{}
+		err := rows.Scan(
+			&seriesID,
+			&member.PostID,
+			&member.Slug,
+			&member.Title,
+			&member.post.Status,
+			&member.post.PublishedAt,
+			&member.post.UnpublishAt,
+			&member.post.DeletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan series post: %w", err)
+		}
+		members[seriesID] = append(members[seriesID], member)
+	}
+
+	return members, rows.Err()
+}
+
+// visibleMembers keeps members that are public at now, plus the member being viewed
+func visibleMembers(members []SeriesPost, now time.Time, viewing uuid.UUID) []SeriesPost {
+	var visible []SeriesPost
+	for _, member := range members {
+		if member.PostID == viewing || member.post.IsPublic(now) {
+			visible = append(visible, member)
+		}
+	}
+	return visible
+}
+
+// numbered returns a copy of members with 1-based positions assigned in order
+func numbered(members []SeriesPost) []SeriesPost {
+	result := make([]SeriesPost, len(members))
+	for i, member := range members {
+		member.Position = i + 1
+		result[i] = member
+	}
+	return result
+}
+
+// scanSeries reads a series metadata row
+func scanSeries(row rowScanner, series *Series) error {
+	var description *string
+	err := row.Scan(
+		&series.ID,
+		&series.Slug,
+		&series.Title,
+		&description,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+	)
+	if description != nil {
+		series.Description = *description
+	}
+	return err
+}
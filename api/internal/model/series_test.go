@@ -0,0 +1,66 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSeriesValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		series    *Series
+		shouldErr bool
+	}{
+		{name: "valid series", series: &Series{Slug: "building-a-blog", Title: "Building a Blog"}, shouldErr: false},
+		{name: "missing slug", series: &Series{Title: "Building a Blog"}, shouldErr: true},
+		{name: "invalid slug", series: &Series{Slug: "Building A Blog", Title: "Building a Blog"}, shouldErr: true},
+		{name: "missing title", series: &Series{Slug: "building-a-blog"}, shouldErr: true},
+		{name: "title too long", series: &Series{Slug: "building-a-blog", Title: strings.Repeat("a", 256)}, shouldErr: true},
+		{name: "description too long", series: &Series{Slug: "building-a-blog", Title: "Building a Blog", Description: strings.Repeat("a", 5001)}, shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.series.Validate()
+			if tt.shouldErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.shouldErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVisibleMembersNumbered(t *testing.T) {
+	now := time.Now().UTC()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	published := SeriesPost{PostID: uuid.New(), Slug: "part-1", post: Post{Status: PostStatusPublished, PublishedAt: &past}}
+	draft := SeriesPost{PostID: uuid.New(), Slug: "part-2", post: Post{Status: PostStatusDraft}}
+	scheduled := SeriesPost{PostID: uuid.New(), Slug: "part-3", post: Post{Status: PostStatusPublished, PublishedAt: &future}}
+	last := SeriesPost{PostID: uuid.New(), Slug: "part-4", post: Post{Status: PostStatusPublished, PublishedAt: &past}}
+	members := []SeriesPost{published, draft, scheduled, last}
+
+	got := numbered(visibleMembers(members, now, uuid.Nil))
+	if len(got) != 2 || got[0].Slug != "part-1" || got[1].Slug != "part-4" {
+		t.Fatalf("expected only public members, got %+v", got)
+	}
+	if got[0].Position != 1 || got[1].Position != 2 {
+		t.Errorf("expected positions 1 and 2, got %d and %d", got[0].Position, got[1].Position)
+	}
+
+	// The member being viewed is kept even when it is not public
+	got = numbered(visibleMembers(members, now, draft.PostID))
+	if len(got) != 3 || got[1].Slug != "part-2" || got[1].Position != 2 {
+		t.Errorf("expected viewed draft at position 2, got %+v", got)
+	}
+
+	if members[0].Position != 0 {
+		t.Error("expected numbered to leave its input untouched")
+	}
+}
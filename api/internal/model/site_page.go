@@ -0,0 +1,453 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// SitePageStatus represents whether a CMS page is shown publicly
+type SitePageStatus string
+
+const (
+	SitePageStatusDraft     SitePageStatus = "draft"
+	SitePageStatusPublished SitePageStatus = "published"
+)
+
+// SitePage is an editable page of site copy, such as About or the home intro
+// Pages nest by path: about/values is a child of about
+type SitePage struct {
+	ID             uuid.UUID
+	Path           string // Slash-separated slugs without leading or trailing slashes
+	Title          string
+	Body           string // Markdown
+	Status         SitePageStatus
+	SEOTitle       string // Overrides Title in the document title and share cards
+	SEODescription string
+	CanonicalURL   string
+	NoIndex        bool
+	WordCount      int // Computed from Body when the page is written
+	ReadingTime    int
+	TOC            []TOCEntry
+	PublishedAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// SitePageRepository handles CMS page data access
+type SitePageRepository struct {
+	db db.QueryExecutor
+}
+
+// NewSitePageRepository creates a new CMS page repository
+func NewSitePageRepository(db db.QueryExecutor) *SitePageRepository {
+	return &SitePageRepository{db: db}
+}
+
+// NormalizePagePath trims surrounding whitespace and slashes from a page path
+func NormalizePagePath(path string) string {
+	return strings.Trim(strings.TrimSpace(path), "/")
+}
+
+// ParentPath returns the path of the page's parent, or "" for a top-level page
+func (p *SitePage) ParentPath() string {
+	if i := strings.LastIndex(p.Path, "/"); i >= 0 {
+		return p.Path[:i]
+	}
+	return ""
+}
+
+// AncestorPaths returns the paths of the pages above this one, nearest first
+func (p *SitePage) AncestorPaths() []string {
+	var paths []string
+	for path := p.Path; strings.Contains(path, "/"); {
+		path = path[:strings.LastIndex(path, "/")]
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// Validate ensures the page meets business requirements
+// Each path segment follows the slug rules for posts, and the title and body follow the post rules
+func (p *SitePage) Validate() error {
+	if p.Path == "" {
+		return apierrors.ValidationError{Message: "path is required"}
+	}
+
+	if len(p.Path) > 255 {
+		return apierrors.ValidationError{Message: "path must be 255 characters or less"}
+	}
+
+	for _, segment := range strings.Split(p.Path, "/") {
+		if !isValidSlug(segment) {
+			return apierrors.ValidationError{Message: "path segments must be lowercase alphanumeric with hyphens only"}
+		}
+	}
+
+	if strings.TrimSpace(p.Title) == "" {
+		return apierrors.ValidationError{Message: "title is required"}
+	}
+
+	if len(p.Title) > 255 {
+		return apierrors.ValidationError{Message: "title must be 255 characters or less"}
+	}
+
+	if strings.TrimSpace(p.Body) == "" {
+		return apierrors.ValidationError{Message: "body is required"}
+	}
+
+	if p.Status != SitePageStatusDraft && p.Status != SitePageStatusPublished {
+		return apierrors.ValidationError{Message: "status must be one of: draft, published"}
+	}
+
+	if len(p.SEOTitle) > 255 {
+		return apierrors.ValidationError{Message: "seo_title must be 255 characters or less"}
+	}
+
+	if len(p.SEODescription) > 500 {
+		return apierrors.ValidationError{Message: "seo_description must be 500 characters or less"}
+	}
+
+	if p.CanonicalURL != "" && !isWebURL(p.CanonicalURL) {
+		return apierrors.ValidationError{Message: "canonical_url must be an absolute http or https URL"}
+	}
+
+	return nil
+}
+
+// IsPublic reports whether the page is shown to readers
+func (p *SitePage) IsPublic() bool {
+	return p.Status == SitePageStatusPublished
+}
+
+// sitePageColumns lists the pages columns in the order scanSitePage reads them
+const sitePageColumns = `id, path, title, body, status, seo_title, seo_description, canonical_url, noindex, word_count, reading_time, toc, published_at, created_at, updated_at`
+
+// Create inserts a new page beneath an existing parent; publishing it records when it was first published
+func (r *SitePageRepository) Create(ctx context.Context, page *SitePage) error {
+	if page.ID == uuid.Nil {
+		page.ID = uuid.New()
+	}
+
+	if page.Status == "" {
+		page.Status = SitePageStatusDraft
+	}
+
+	page.Path = NormalizePagePath(page.Path)
+	if err := page.Validate(); err != nil {
+		return err
+	}
+
+	page.WordCount, page.ReadingTime, page.TOC = ReadingMetadata(page.Body)
+
+	now := time.Now().UTC()
+	page.CreatedAt = now
+	page.UpdatedAt = now
+	if page.Status == SitePageStatusPublished && page.PublishedAt == nil {
+		page.PublishedAt = &now
+	}
+
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		if err := checkParentPage(ctx, tx, page.ParentPath()); err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO pages (` + sitePageColumns + `)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		`
+
+		_, err := tx.ExecContext(ctx, query,
+			page.ID,
+			page.Path,
+			page.Title,
+			page.Body,
+			page.Status,
+			page.SEOTitle,
+			page.SEODescription,
+			page.CanonicalURL,
+			page.NoIndex,
+			page.WordCount,
+			page.ReadingTime,
+			tableOfContents(page.TOC),
+			page.PublishedAt,
+			page.CreatedAt,
+			page.UpdatedAt,
+		)
+
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+				return apierrors.ConflictError{Message: fmt.Sprintf("page '%s' already exists", page.Path)}
+			}
+			return fmt.Errorf("failed to create page: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetByID retrieves a page by ID, whatever its status
+func (r *SitePageRepository) GetByID(ctx context.Context, id uuid.UUID) (*SitePage, error) {
+	return r.get(ctx, `WHERE id = $1`, id)
+}
+
+// GetByPath retrieves a page by path, whatever its status
+func (r *SitePageRepository) GetByPath(ctx context.Context, path string) (*SitePage, error) {
+	return r.get(ctx, `WHERE path = $1`, NormalizePagePath(path))
+}
+
+// HasDraftAncestor reports whether any page above page is a draft, which hides page from readers as well
+func (r *SitePageRepository) HasDraftAncestor(ctx context.Context, page *SitePage) (bool, error) {
+	ancestors := page.AncestorPaths()
+	if len(ancestors) == 0 {
+		return false, nil
+	}
+
+	placeholders := make([]string, len(ancestors))
+	args := []interface{}{SitePageStatusPublished}
+	for i, path := range ancestors {
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		args = append(args, path)
+	}
+
+	query := `SELECT COUNT(*) FROM pages WHERE status <> $1 AND path IN (` + strings.Join(placeholders, ", ") + `)`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check ancestor pages: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// List retrieves every page, or only published pages, in path order so children follow their parents
+func (r *SitePageRepository) List(ctx context.Context, publishedOnly bool) ([]SitePage, error) {
+	where := ""
+	var args []interface{}
+	if publishedOnly {
+		where = "WHERE status = $1"
+		args = append(args, SitePageStatusPublished)
+	}
+
+	return r.list(ctx, where, args...)
+}
+
+// ListChildren retrieves the pages directly beneath path, or only the published ones, in path order
+func (r *SitePageRepository) ListChildren(ctx context.Context, path string, publishedOnly bool) ([]SitePage, error) {
+	// Path segments are slugs, so they never contain LIKE wildcards
+	prefix := NormalizePagePath(path) + "/"
+	where := `WHERE path LIKE $1 AND path NOT LIKE $2`
+	args := []interface{}{prefix + "%", prefix + "%/%"}
+	if publishedOnly {
+		where += " AND status = $3"
+		args = append(args, SitePageStatusPublished)
+	}
+
+	return r.list(ctx, where, args...)
+}
+
+// Update replaces a page's content; changing its path moves its descendants along with it
+func (r *SitePageRepository) Update(ctx context.Context, page *SitePage) error {
+	if page.ID == uuid.Nil {
+		return apierrors.ValidationError{Message: "page ID is required"}
+	}
+
+	page.Path = NormalizePagePath(page.Path)
+	if err := page.Validate(); err != nil {
+		return err
+	}
+
+	page.WordCount, page.ReadingTime, page.TOC = ReadingMetadata(page.Body)
+
+	now := time.Now().UTC()
+	page.UpdatedAt = now
+	if page.Status == SitePageStatusPublished && page.PublishedAt == nil {
+		page.PublishedAt = &now
+	}
+
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		var oldPath string
+		err := tx.QueryRowContext(ctx, `SELECT path FROM pages WHERE id = $1`, page.ID).Scan(&oldPath)
+		if errors.Is(err, sql.ErrNoRows) {
+			return apierrors.NotFoundError{Message: "page not found"}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load page: %w", err)
+		}
+
+		if page.Path != oldPath {
+			if strings.HasPrefix(page.Path, oldPath+"/") {
+				return apierrors.ValidationError{Message: "a page cannot be moved beneath itself"}
+			}
+			if err := checkParentPage(ctx, tx, page.ParentPath()); err != nil {
+				return err
+			}
+		}
+
+		query := `
+			UPDATE pages
+			SET path = $1, title = $2, body = $3, status = $4, seo_title = $5, seo_description = $6, canonical_url = $7,
+				noindex = $8, word_count = $9, reading_time = $10, toc = $11, published_at = $12, updated_at = $13
+			WHERE id = $14
+		`
+
+		_, err = tx.ExecContext(ctx, query,
+			page.Path,
+			page.Title,
+			page.Body,
+			page.Status,
+			page.SEOTitle,
+			page.SEODescription,
+			page.CanonicalURL,
+			page.NoIndex,
+			page.WordCount,
+			page.ReadingTime,
+			tableOfContents(page.TOC),
+			page.PublishedAt,
+			page.UpdatedAt,
+			page.ID,
+		)
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+				return apierrors.ConflictError{Message: fmt.Sprintf("page '%s' already exists", page.Path)}
+			}
+			return fmt.Errorf("failed to update page: %w", err)
+		}
+
+		if page.Path != oldPath {
+			query := `UPDATE pages SET path = $1 || SUBSTR(path, $2), updated_at = $3 WHERE path LIKE $4`
+			if _, err := tx.ExecContext(ctx, query, page.Path, len(oldPath)+1, now, oldPath+"/%"); err != nil {
+				if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+					return apierrors.ConflictError{Message: fmt.Sprintf("pages beneath '%s' already exist", page.Path)}
+				}
+				return fmt.Errorf("failed to move child pages: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Delete removes a page that has no child pages
+func (r *SitePageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		var path string
+		err := tx.QueryRowContext(ctx, `SELECT path FROM pages WHERE id = $1`, id).Scan(&path)
+		if errors.Is(err, sql.ErrNoRows) {
+			return apierrors.NotFoundError{Message: "page not found"}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load page: %w", err)
+		}
+
+		var children int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM pages WHERE path LIKE $1`, path+"/%").Scan(&children); err != nil {
+			return fmt.Errorf("failed to check child pages: %w", err)
+		}
+		if children > 0 {
+			return apierrors.ConflictError{Message: fmt.Sprintf("page '%s' has child pages; move or delete them first", path)}
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM pages WHERE id = $1`, id); err != nil {
+			return fmt.Errorf("failed to delete page: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// checkParentPage ensures the parent of a page exists; top-level pages have no parent to check
+func checkParentPage(ctx context.Context, exec db.QueryExecutor, parent string) error {
+	if parent == "" {
+		return nil
+	}
+
+	var count int
+	if err := exec.QueryRowContext(ctx, `SELECT COUNT(*) FROM pages WHERE path = $1`, parent).Scan(&count); err != nil {
+		return fmt.Errorf("failed to check parent page: %w", err)
+	}
+	if count == 0 {
+		return apierrors.ValidationError{Message: fmt.Sprintf("parent page '%s' does not exist", parent)}
+	}
+
+	return nil
+}
+
+// get retrieves a single page matching the given WHERE clause
+func (r *SitePageRepository) get(ctx context.Context, where string, arg interface{}) (*SitePage, error) {
+	page := &SitePage{}
+
+	query := `
+		SELECT ` + sitePageColumns + `
+		FROM pages
+		` + where
+
+	if err := scanSitePage(r.db.QueryRowContext(ctx, query, arg), page); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, apierrors.NotFoundError{Message: "page not found"}
+	}
+
+	return page, nil
+}
+
+// list runs a page query for the given WHERE clause, in path order
+func (r *SitePageRepository) list(ctx context.Context, where string, args ...interface{}) ([]SitePage, error) {
+	query := `
+		SELECT ` + sitePageColumns + `
+		FROM pages
+		` + where + `
+		ORDER BY path ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pages: %w", err)
+	}
+	defer rows.Close()
+
+	var pages []SitePage
+	for rows.Next() {
+		page := SitePage{}
+		if err := scanSitePage(rows, &page); err != nil {
+			return nil, fmt.Errorf("failed to scan page: %w", err)
+		}
+		pages = append(pages, page)
+	}
+
+	return pages, rows.Err()
+}
+
+// scanSitePage reads a row selected with sitePageColumns into page
+func scanSitePage(row rowScanner, page *SitePage) error {
+	var seoTitle, seoDescription, canonicalURL *string
+	err := row.Scan(
+		&page.ID,
+		&page.Path,
+		&page.Title,
+		&page.Body,
+		&page.Status,
+		&seoTitle,
+		&seoDescription,
+		&canonicalURL,
+		&page.NoIndex,
+		&page.WordCount,
+		&page.ReadingTime,
+		(*tableOfContents)(&page.TOC),
+		&page.PublishedAt,
+		&page.CreatedAt,
+		&page.UpdatedAt,
+	)
+	page.SEOTitle = derefString(seoTitle)
+	page.SEODescription = derefString(seoDescription)
+	page.CanonicalURL = derefString(canonicalURL)
+	return err
+}
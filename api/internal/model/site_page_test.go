@@ -0,0 +1,80 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSitePageValidate(t *testing.T) {
+	valid := func() *SitePage {
+		return &SitePage{
+			Path:           "about/values",
+			Title:          "Values",
+			Body:           "## Boring technology\n\nPick the tools you know.",
+			Status:         SitePageStatusPublished,
+			SEODescription: "What I care about when building software",
+			CanonicalURL:   "https://sochoa.dev/about/values",
+		}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(p *SitePage)
+		shouldErr bool
+	}{
+		{name: "valid page", modify: func(p *SitePage) {}, shouldErr: false},
+		{name: "top-level page", modify: func(p *SitePage) { p.Path = "about" }, shouldErr: false},
+		{name: "missing path", modify: func(p *SitePage) { p.Path = "" }, shouldErr: true},
+		{name: "empty segment", modify: func(p *SitePage) { p.Path = "about//values" }, shouldErr: true},
+		{name: "uppercase segment", modify: func(p *SitePage) { p.Path = "about/Values" }, shouldErr: true},
+		{name: "path too long", modify: func(p *SitePage) { p.Path = strings.Repeat("a", 256) }, shouldErr: true},
+		{name: "missing title", modify: func(p *SitePage) { p.Title = " " }, shouldErr: true},
+		{name: "title too long", modify: func(p *SitePage) { p.Title = strings.Repeat("a", 256) }, shouldErr: true},
+		{name: "missing body", modify: func(p *SitePage) { p.Body = "\n" }, shouldErr: true},
+		{name: "unknown status", modify: func(p *SitePage) { p.Status = "archived" }, shouldErr: true},
+		{name: "seo title too long", modify: func(p *SitePage) { p.SEOTitle = strings.Repeat("a", 256) }, shouldErr: true},
+		{name: "seo description too long", modify: func(p *SitePage) { p.SEODescription = strings.Repeat("a", 501) }, shouldErr: true},
+		{name: "relative canonical url", modify: func(p *SitePage) { p.CanonicalURL = "/about/values" }, shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := valid()
+			tt.modify(page)
+			err := page.Validate()
+			if tt.shouldErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.shouldErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSitePagePaths(t *testing.T) {
+	if got := NormalizePagePath(" /about/values/ "); got != "about/values" {
+		t.Errorf("expected about/values, got %q", got)
+	}
+
+	tests := map[string]string{
+		"about":          "",
+		"about/values":   "about",
+		"about/values/x": "about/values",
+	}
+	for path, parent := range tests {
+		page := &SitePage{Path: path}
+		if got := page.ParentPath(); got != parent {
+			t.Errorf("%s: expected parent %q, got %q", path, parent, got)
+		}
+	}
+
+	page := &SitePage{Path: "about/values/x"}
+	if got := page.AncestorPaths(); !slices.Equal(got, []string{"about/values", "about"}) {
+		t.Errorf("expected ancestors nearest first, got %v", got)
+	}
+	if got := (&SitePage{Path: "about"}).AncestorPaths(); len(got) != 0 {
+		t.Errorf("expected no ancestors for a top-level page, got %v", got)
+	}
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"strings"
@@ -28,6 +29,7 @@ type VisitorStat struct {
 	Errors5xx      int
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	Version        int // Incremented on every write, for optimistic concurrency
 }
 
 // StatsRepository handles visitor stats data access
@@ -94,10 +96,11 @@ func (r *StatsRepository) Create(ctx context.Context, stat *VisitorStat) error {
 	now := time.Now().UTC()
 	stat.CreatedAt = now
 	stat.UpdatedAt = now
+	stat.Version = 1
 
 	query := `
-		INSERT INTO visitor_stats (id, date, page_path, country, referrer_domain, pageviews, unique_visitors, latency_p50, latency_p95, latency_p99, errors_4xx, errors_5xx, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		INSERT INTO visitor_stats (id, date, page_path, country, referrer_domain, pageviews, unique_visitors, latency_p50, latency_p95, latency_p99, errors_4xx, errors_5xx, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -115,6 +118,7 @@ func (r *StatsRepository) Create(ctx context.Context, stat *VisitorStat) error {
 		stat.Errors5xx,
 		stat.CreatedAt,
 		stat.UpdatedAt,
+		stat.Version,
 	)
 
 	if err != nil {
@@ -132,7 +136,7 @@ func (r *StatsRepository) GetByID(ctx context.Context, id uuid.UUID) (*VisitorSt
 	stat := &VisitorStat{}
 
 	query := `
-		SELECT id, date, page_path, country, referrer_domain, pageviews, unique_visitors, latency_p50, latency_p95, latency_p99, errors_4xx, errors_5xx, created_at, updated_at
+		SELECT id, date, page_path, country, referrer_domain, pageviews, unique_visitors, latency_p50, latency_p95, latency_p99, errors_4xx, errors_5xx, created_at, updated_at, version
 		FROM visitor_stats
 		WHERE id = $1
 	`
@@ -153,6 +157,7 @@ func (r *StatsRepository) GetByID(ctx context.Context, id uuid.UUID) (*VisitorSt
 		&stat.Errors5xx,
 		&stat.CreatedAt,
 		&stat.UpdatedAt,
+		&stat.Version,
 	)
 
 	if err != nil {
@@ -170,7 +175,7 @@ func (r *StatsRepository) GetByDateAndPath(ctx context.Context, date time.Time,
 	stat := &VisitorStat{}
 
 	query := `
-		SELECT id, date, page_path, country, referrer_domain, pageviews, unique_visitors, latency_p50, latency_p95, latency_p99, errors_4xx, errors_5xx, created_at, updated_at
+		SELECT id, date, page_path, country, referrer_domain, pageviews, unique_visitors, latency_p50, latency_p95, latency_p99, errors_4xx, errors_5xx, created_at, updated_at, version
 		FROM visitor_stats
 		WHERE date = $1 AND page_path = $2
 	`
@@ -191,6 +196,7 @@ func (r *StatsRepository) GetByDateAndPath(ctx context.Context, date time.Time,
 		&stat.Errors5xx,
 		&stat.CreatedAt,
 		&stat.UpdatedAt,
+		&stat.Version,
 	)
 
 	if err != nil {
@@ -203,63 +209,60 @@ func (r *StatsRepository) GetByDateAndPath(ctx context.Context, date time.Time,
 	return stat, nil
 }
 
-// ListByDateRange retrieves all visitor stats within a date range
-func (r *StatsRepository) ListByDateRange(ctx context.Context, startDate, endDate time.Time, limit int, offset int) ([]VisitorStat, error) {
-	query := `
-		SELECT id, date, page_path, country, referrer_domain, pageviews, unique_visitors, latency_p50, latency_p95, latency_p99, errors_4xx, errors_5xx, created_at, updated_at
-		FROM visitor_stats
-		WHERE date >= $1 AND date <= $2
-		ORDER BY date DESC, page_path ASC
-		LIMIT $3 OFFSET $4
-	`
+// Stats lists sort by day, newest first; within a day, date ranges order by page and page lists by ID
+var (
+	dateRangeOrder = pageOrder{timeColumn: "date", timeDesc: true, keyColumn: "page_path", keyDesc: false}
+	pageStatsOrder = pageOrder{timeColumn: "date", timeDesc: true, keyColumn: "id", keyDesc: true}
+)
 
-	rows, err := r.db.QueryContext(ctx, query, startDate, endDate, limit, offset)
+// ListByDateRange retrieves a page of visitor stats within a date range
+// It returns the cursor for the following page, or nil on the last page
+func (r *StatsRepository) ListByDateRange(ctx context.Context, startDate, endDate time.Time, page PageRequest) ([]VisitorStat, *Cursor, error) {
+	stats, err := r.list(ctx, `WHERE date >= $1 AND date <= $2`, []interface{}{startDate, endDate}, dateRangeOrder, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list visitor stats: %w", err)
+		return nil, nil, fmt.Errorf("failed to list visitor stats: %w", err)
 	}
-	defer rows.Close()
 
-	var stats []VisitorStat
-	for rows.Next() {
-		stat := VisitorStat{}
-		err := rows.Scan(
-			&stat.ID,
-			&stat.Date,
-			&stat.PagePath,
-			&stat.Country,
-			&stat.ReferrerDomain,
-			&stat.Pageviews,
-			&stat.UniqueVisitors,
-			&stat.LatencyP50,
-			&stat.LatencyP95,
-			&stat.LatencyP99,
-			&stat.Errors4xx,
-			&stat.Errors5xx,
-			&stat.CreatedAt,
-			&stat.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan visitor stat: %w", err)
-		}
-		stats = append(stats, stat)
+	stats, next := trimPage(stats, page.Limit, func(s *VisitorStat) Cursor {
+		return Cursor{Time: s.Date, Key: s.PagePath}
+	})
+	return stats, next, nil
+}
+
+// ListByPage retrieves a page of visitor stats for a specific page path
+func (r *StatsRepository) ListByPage(ctx context.Context, pagePath string, page PageRequest) ([]VisitorStat, *Cursor, error) {
+	stats, err := r.list(ctx, `WHERE page_path = $1`, []interface{}{pagePath}, pageStatsOrder, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list visitor stats by page: %w", err)
 	}
 
-	return stats, rows.Err()
+	stats, next := trimPage(stats, page.Limit, func(s *VisitorStat) Cursor {
+		return Cursor{Time: s.Date, Key: s.ID.String()}
+	})
+	return stats, next, nil
+}
+
+// CountByDateRange counts visitor stats within a date range
+func (r *StatsRepository) CountByDateRange(ctx context.Context, startDate, endDate time.Time) (int, error) {
+	return r.count(ctx, `WHERE date >= $1 AND date <= $2`, startDate, endDate)
+}
+
+// CountByPage counts visitor stats for a specific page path
+func (r *StatsRepository) CountByPage(ctx context.Context, pagePath string) (int, error) {
+	return r.count(ctx, `WHERE page_path = $1`, pagePath)
 }
 
-// ListByPage retrieves all visitor stats for a specific page path
-func (r *StatsRepository) ListByPage(ctx context.Context, pagePath string, limit int, offset int) ([]VisitorStat, error) {
+// list runs a paginated visitor stats query for the given WHERE clause
+func (r *StatsRepository) list(ctx context.Context, where string, args []interface{}, order pageOrder, page PageRequest) ([]VisitorStat, error) {
 	query := `
-		SELECT id, date, page_path, country, referrer_domain, pageviews, unique_visitors, latency_p50, latency_p95, latency_p99, errors_4xx, errors_5xx, created_at, updated_at
+		SELECT id, date, page_path, country, referrer_domain, pageviews, unique_visitors, latency_p50, latency_p95, latency_p99, errors_4xx, errors_5xx, created_at, updated_at, version
 		FROM visitor_stats
-		WHERE page_path = $1
-		ORDER BY date DESC
-		LIMIT $2 OFFSET $3
-	`
+		` + where
+	query, args = order.apply(query, args, page)
 
-	rows, err := r.db.QueryContext(ctx, query, pagePath, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list visitor stats by page: %w", err)
+		return nil, err
 	}
 	defer rows.Close()
 
@@ -281,6 +284,7 @@ func (r *StatsRepository) ListByPage(ctx context.Context, pagePath string, limit
 			&stat.Errors5xx,
 			&stat.CreatedAt,
 			&stat.UpdatedAt,
+			&stat.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan visitor stat: %w", err)
@@ -291,7 +295,18 @@ func (r *StatsRepository) ListByPage(ctx context.Context, pagePath string, limit
 	return stats, rows.Err()
 }
 
+// count counts visitor stats matching the given WHERE clause
+func (r *StatsRepository) count(ctx context.Context, where string, args ...interface{}) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM visitor_stats `+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count visitor stats: %w", err)
+	}
+	return count, nil
+}
+
 // Update updates an existing visitor stat
+// The write only applies if the stored version still matches stat.Version, which is then incremented;
+// otherwise another write got there first and a PreconditionFailedError is returned
 func (r *StatsRepository) Update(ctx context.Context, stat *VisitorStat) error {
 	if stat.ID == uuid.Nil {
 		return apierrors.ValidationError{Message: "stat ID is required"}
@@ -305,8 +320,8 @@ func (r *StatsRepository) Update(ctx context.Context, stat *VisitorStat) error {
 
 	query := `
 		UPDATE visitor_stats
-		SET date = $1, page_path = $2, country = $3, referrer_domain = $4, pageviews = $5, unique_visitors = $6, latency_p50 = $7, latency_p95 = $8, latency_p99 = $9, errors_4xx = $10, errors_5xx = $11, updated_at = $12
-		WHERE id = $13
+		SET date = $1, page_path = $2, country = $3, referrer_domain = $4, pageviews = $5, unique_visitors = $6, latency_p50 = $7, latency_p95 = $8, latency_p99 = $9, errors_4xx = $10, errors_5xx = $11, updated_at = $12, version = version + 1
+		WHERE id = $13 AND version = $14
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -323,6 +338,7 @@ func (r *StatsRepository) Update(ctx context.Context, stat *VisitorStat) error {
 		stat.Errors5xx,
 		stat.UpdatedAt,
 		stat.ID,
+		stat.Version,
 	)
 
 	if err != nil {
@@ -338,9 +354,18 @@ func (r *StatsRepository) Update(ctx context.Context, stat *VisitorStat) error {
 	}
 
 	if rows == 0 {
-		return apierrors.NotFoundError{Message: "visitor stat not found"}
+		var version int
+		err := r.db.QueryRowContext(ctx, `SELECT version FROM visitor_stats WHERE id = $1`, stat.ID).Scan(&version)
+		if errors.Is(err, sql.ErrNoRows) {
+			return apierrors.NotFoundError{Message: "visitor stat not found"}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check visitor stat version: %w", err)
+		}
+		return apierrors.PreconditionFailedError{Message: "visitor stat was modified by another request; reload it and retry"}
 	}
 
+	stat.Version++
 	return nil
 }
 
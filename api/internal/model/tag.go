@@ -0,0 +1,670 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// MaxTagLength is the longest allowed canonical tag name
+const MaxTagLength = 64
+
+// MaxTagDescriptionLength is the longest allowed tag description
+const MaxTagDescriptionLength = 2000
+
+// Tag represents a canonical tag with its catalog metadata
+type Tag struct {
+	Name         string
+	Description  string
+	PostCount    int // Number of publicly visible posts carrying the tag
+	ProjectCount int // Number of published projects carrying the tag
+}
+
+// TagAlias maps an alternative spelling to a canonical tag
+type TagAlias struct {
+	Alias     string
+	Tag       string
+	CreatedAt time.Time
+}
+
+// TagRepository handles tag catalog data access and tag-wide changes to posts and projects
+type TagRepository struct {
+	db db.QueryExecutor
+}
+
+// NewTagRepository creates a new tag repository
+func NewTagRepository(db db.QueryExecutor) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// NormalizeTag returns the canonical spelling of a tag: lowercase, trimmed,
+// with internal whitespace collapsed to single hyphens
+func NormalizeTag(tag string) string {
+	fields := strings.FieldsFunc(strings.ToLower(tag), unicode.IsSpace)
+	return strings.Join(fields, "-")
+}
+
+// NormalizeTags normalizes a list of tags, dropping empty and duplicate entries
+func NormalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		name := NormalizeTag(tag)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+
+	return normalized
+}
+
+// validateTagName ensures a normalized tag name is usable
+func validateTagName(name string) error {
+	if name == "" {
+		return apierrors.ValidationError{Message: "tag is required"}
+	}
+
+	if len(name) > MaxTagLength {
+		return apierrors.ValidationError{Message: fmt.Sprintf("tag must be %d characters or less", MaxTagLength)}
+	}
+
+	return nil
+}
+
+// Validate ensures the tag meets business requirements
+func (t *Tag) Validate() error {
+	if err := validateTagName(t.Name); err != nil {
+		return err
+	}
+
+	if len(t.Description) > MaxTagDescriptionLength {
+		return apierrors.ValidationError{Message: fmt.Sprintf("description must be %d characters or less", MaxTagDescriptionLength)}
+	}
+
+	return nil
+}
+
+// Validate ensures the alias meets business requirements
+func (a *TagAlias) Validate() error {
+	if a.Alias == "" {
+		return apierrors.ValidationError{Message: "alias is required"}
+	}
+
+	if len(a.Alias) > MaxTagLength {
+		return apierrors.ValidationError{Message: fmt.Sprintf("alias must be %d characters or less", MaxTagLength)}
+	}
+
+	if err := validateTagName(a.Tag); err != nil {
+		return err
+	}
+
+	if a.Alias == a.Tag {
+		return apierrors.ValidationError{Message: "alias must differ from the tag it points to"}
+	}
+
+	return nil
+}
+
+// ListWithCounts returns every tag on a publicly visible post or published project with its counts,
+// most used on posts first
+func (r *TagRepository) ListWithCounts(ctx context.Context, now time.Time) ([]Tag, error) {
+	counts, err := r.publishedTagCounts(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	projectCounts, err := r.projectTagCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions, err := r.descriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]Tag, 0, len(counts))
+	for name, count := range counts {
+		tags = append(tags, Tag{Name: name, Description: descriptions[name], PostCount: count, ProjectCount: projectCounts[name]})
+	}
+	for name, count := range projectCounts {
+		if counts[name] == 0 {
+			tags = append(tags, Tag{Name: name, Description: descriptions[name], ProjectCount: count})
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].PostCount != tags[j].PostCount {
+			return tags[i].PostCount > tags[j].PostCount
+		}
+		if tags[i].ProjectCount != tags[j].ProjectCount {
+			return tags[i].ProjectCount > tags[j].ProjectCount
+		}
+		return tags[i].Name < tags[j].Name
+	})
+
+	return tags, nil
+}
+
+// Get retrieves a single tag, resolving aliases to the canonical tag
+// A tag is found if it is on a visible post or published project, or has a catalog entry
+func (r *TagRepository) Get(ctx context.Context, name string, now time.Time) (*Tag, error) {
+	canonical, err := r.Resolve(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := r.publishedTagCounts(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	projectCounts, err := r.projectTagCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := &Tag{Name: canonical, PostCount: counts[canonical], ProjectCount: projectCounts[canonical]}
+
+	query := `SELECT description FROM tags WHERE name = $1`
+	var description sql.NullString
+	err = r.db.QueryRowContext(ctx, query, canonical).Scan(&description)
+	switch {
+	case err == nil:
+		tag.Description = description.String
+	case errors.Is(err, sql.ErrNoRows):
+		if tag.PostCount == 0 && tag.ProjectCount == 0 {
+			return nil, apierrors.NotFoundError{Message: "tag not found"}
+		}
+	default:
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// SetDescription creates or updates the catalog entry for a tag
+func (r *TagRepository) SetDescription(ctx context.Context, tag *Tag) error {
+	tag.Name = NormalizeTag(tag.Name)
+	tag.Description = strings.TrimSpace(tag.Description)
+
+	if err := tag.Validate(); err != nil {
+		return err
+	}
+
+	isAlias, err := r.isAlias(ctx, r.db, tag.Name)
+	if err != nil {
+		return err
+	}
+	if isAlias {
+		return apierrors.ValidationError{Message: fmt.Sprintf("'%s' is an alias; describe its canonical tag instead", tag.Name)}
+	}
+
+	now := time.Now().UTC()
+	query := `
+		INSERT INTO tags (name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO UPDATE SET description = excluded.description, updated_at = excluded.updated_at
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, tag.Name, tag.Description, now, now); err != nil {
+		return fmt.Errorf("failed to set tag description: %w", err)
+	}
+
+	return nil
+}
+
+// Resolve returns the canonical name for a tag, following its alias if it has one
+func (r *TagRepository) Resolve(ctx context.Context, name string) (string, error) {
+	normalized := NormalizeTag(name)
+
+	query := `SELECT tag FROM tag_aliases WHERE alias = $1`
+	var canonical string
+	err := r.db.QueryRowContext(ctx, query, normalized).Scan(&canonical)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return normalized, nil
+		}
+		return "", fmt.Errorf("failed to resolve tag: %w", err)
+	}
+
+	return canonical, nil
+}
+
+// Canonicalize normalizes a list of tags and replaces aliases with their canonical tags
+func (r *TagRepository) Canonicalize(ctx context.Context, tags []string) ([]string, error) {
+	normalized := NormalizeTags(tags)
+	if len(normalized) == 0 {
+		return normalized, nil
+	}
+
+	aliases, err := r.ListAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical := make(map[string]string, len(aliases))
+	for _, a := range aliases {
+		canonical[a.Alias] = a.Tag
+	}
+
+	for i, tag := range normalized {
+		if target, ok := canonical[tag]; ok {
+			normalized[i] = target
+		}
+	}
+
+	return NormalizeTags(normalized), nil
+}
+
+// ListAliases retrieves all tag aliases ordered by alias
+func (r *TagRepository) ListAliases(ctx context.Context) ([]TagAlias, error) {
+	query := `SELECT alias, tag, created_at FROM tag_aliases ORDER BY alias`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tag aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []TagAlias
+	for rows.Next() {
+		alias := TagAlias{}
+		if err := rows.Scan(&alias.Alias, &alias.Tag, &alias.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tag alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	return aliases, rows.Err()
+}
+
+// CreateAlias records an alternative spelling for a canonical tag
+// Tags already in use must be merged rather than aliased so no post or project keeps the old spelling
+func (r *TagRepository) CreateAlias(ctx context.Context, alias *TagAlias) error {
+	alias.Alias = NormalizeTag(alias.Alias)
+	alias.Tag = NormalizeTag(alias.Tag)
+
+	if err := alias.Validate(); err != nil {
+		return err
+	}
+
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		targetIsAlias, err := r.isAlias(ctx, tx, alias.Tag)
+		if err != nil {
+			return err
+		}
+		if targetIsAlias {
+			return apierrors.ValidationError{Message: fmt.Sprintf("'%s' is itself an alias", alias.Tag)}
+		}
+
+		inUse, err := tagInUse(ctx, tx, alias.Alias)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return apierrors.ConflictError{Message: fmt.Sprintf("tag '%s' is in use; merge it instead", alias.Alias)}
+		}
+
+		var targets int
+		query := `SELECT COUNT(*) FROM tag_aliases WHERE tag = $1`
+		if err := tx.QueryRowContext(ctx, query, alias.Alias).Scan(&targets); err != nil {
+			return fmt.Errorf("failed to check tag aliases: %w", err)
+		}
+		if targets > 0 {
+			return apierrors.ConflictError{Message: fmt.Sprintf("tag '%s' has aliases of its own", alias.Alias)}
+		}
+
+		alias.CreatedAt = time.Now().UTC()
+		query = `INSERT INTO tag_aliases (alias, tag, created_at) VALUES ($1, $2, $3)`
+		if _, err := tx.ExecContext(ctx, query, alias.Alias, alias.Tag, alias.CreatedAt); err != nil {
+			if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE") {
+				return apierrors.ConflictError{Message: fmt.Sprintf("alias '%s' already exists", alias.Alias)}
+			}
+			return fmt.Errorf("failed to create tag alias: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteAlias removes a tag alias
+func (r *TagRepository) DeleteAlias(ctx context.Context, alias string) error {
+	query := `DELETE FROM tag_aliases WHERE alias = $1`
+
+	result, err := r.db.ExecContext(ctx, query, NormalizeTag(alias))
+	if err != nil {
+		return fmt.Errorf("failed to delete tag alias: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "tag alias not found"}
+	}
+
+	return nil
+}
+
+// Rename renames a tag across every post and project in a single transaction
+// The old name becomes an alias of the new one; renaming onto a tag already in use is a conflict
+func (r *TagRepository) Rename(ctx context.Context, from, to string) (int64, error) {
+	from, to = NormalizeTag(from), NormalizeTag(to)
+
+	if err := validateTagName(from); err != nil {
+		return 0, err
+	}
+	if err := validateTagName(to); err != nil {
+		return 0, err
+	}
+	if from == to {
+		return 0, apierrors.ValidationError{Message: "new tag name must differ from the current one"}
+	}
+
+	var updated int64
+	err := db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		inUse, err := tagInUse(ctx, tx, to)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return apierrors.ConflictError{Message: fmt.Sprintf("tag '%s' already exists; merge instead", to)}
+		}
+
+		updated, err = r.retag(ctx, tx, []string{from}, to)
+		return err
+	})
+
+	return updated, err
+}
+
+// Merge folds the source tags into the target tag across every post and project in a single transaction
+// Each source becomes an alias of the target
+func (r *TagRepository) Merge(ctx context.Context, sources []string, target string) (int64, error) {
+	target = NormalizeTag(target)
+	if err := validateTagName(target); err != nil {
+		return 0, err
+	}
+
+	var normalized []string
+	for _, source := range NormalizeTags(sources) {
+		if source != target {
+			normalized = append(normalized, source)
+		}
+	}
+	if len(normalized) == 0 {
+		return 0, apierrors.ValidationError{Message: "at least one source tag other than the target is required"}
+	}
+
+	var updated int64
+	err := db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		var err error
+		updated, err = r.retag(ctx, tx, normalized, target)
+		return err
+	})
+
+	return updated, err
+}
+
+// retag replaces the source tags with target on every post and project, moves catalog entries
+// and repoints aliases; it must run inside a transaction
+// It returns the number of posts changed
+func (r *TagRepository) retag(ctx context.Context, tx db.QueryExecutor, sources []string, target string) (int64, error) {
+	isAlias, err := r.isAlias(ctx, tx, target)
+	if err != nil {
+		return 0, err
+	}
+	if isAlias {
+		return 0, apierrors.ValidationError{Message: fmt.Sprintf("'%s' is an alias; use its canonical tag instead", target)}
+	}
+
+	posts, err := taggedRows(ctx, tx, "posts", sources)
+	if err != nil {
+		return 0, err
+	}
+
+	projects, err := taggedRows(ctx, tx, "projects", sources)
+	if err != nil {
+		return 0, err
+	}
+
+	replace := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		replace[source] = true
+	}
+
+	retagged := func(tags []string) tagList {
+		result := make([]string, len(tags))
+		for i, tag := range tags {
+			if replace[NormalizeTag(tag)] {
+				tag = target
+			}
+			result[i] = tag
+		}
+		return tagList(NormalizeTags(result))
+	}
+
+	now := time.Now().UTC()
+	var updated int64
+	for id, tags := range posts {
+		query := `UPDATE posts SET tags = $1, updated_at = $2, version = version + 1 WHERE id = $3`
+		if _, err := tx.ExecContext(ctx, query, retagged(tags), now, id); err != nil {
+			return 0, fmt.Errorf("failed to retag post: %w", err)
+		}
+		updated++
+	}
+
+	for id, tags := range projects {
+		query := `UPDATE projects SET tags = $1, updated_at = $2 WHERE id = $3`
+		if _, err := tx.ExecContext(ctx, query, retagged(tags), now, id); err != nil {
+			return 0, fmt.Errorf("failed to retag project: %w", err)
+		}
+	}
+
+	var catalogued int64
+	for _, source := range sources {
+		// Keep the target's own description; otherwise carry the source's over
+		query := `UPDATE tags SET name = $1, updated_at = $2 WHERE name = $3 AND NOT EXISTS (SELECT 1 FROM tags WHERE name = $4)`
+		result, err := tx.ExecContext(ctx, query, target, now, source, target)
+		if err != nil {
+			return 0, fmt.Errorf("failed to move tag description: %w", err)
+		}
+		moved, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		query = `DELETE FROM tags WHERE name = $1`
+		result, err = tx.ExecContext(ctx, query, source)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete tag description: %w", err)
+		}
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		catalogued += moved + deleted
+
+		query = `UPDATE tag_aliases SET tag = $1 WHERE tag = $2`
+		if _, err := tx.ExecContext(ctx, query, target, source); err != nil {
+			return 0, fmt.Errorf("failed to repoint tag aliases: %w", err)
+		}
+
+		query = `DELETE FROM tag_aliases WHERE alias = $1`
+		if _, err := tx.ExecContext(ctx, query, source); err != nil {
+			return 0, fmt.Errorf("failed to clear tag aliases: %w", err)
+		}
+
+		query = `INSERT INTO tag_aliases (alias, tag, created_at) VALUES ($1, $2, $3)`
+		if _, err := tx.ExecContext(ctx, query, source, target, now); err != nil {
+			return 0, fmt.Errorf("failed to create tag alias: %w", err)
+		}
+	}
+
+	if updated == 0 && len(projects) == 0 && catalogued == 0 {
+		return 0, apierrors.NotFoundError{Message: "tag not found"}
+	}
+
+	return updated, nil
+}
+
+// isAlias reports whether name is recorded as an alias
+func (r *TagRepository) isAlias(ctx context.Context, exec db.QueryExecutor, name string) (bool, error) {
+	query := `SELECT COUNT(*) FROM tag_aliases WHERE alias = $1`
+
+	var count int
+	if err := exec.QueryRowContext(ctx, query, name).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check tag alias: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// publishedTagCounts counts publicly visible posts per normalized tag, using the same filter as ListPublished
+func (r *TagRepository) publishedTagCounts(ctx context.Context, now time.Time) (map[string]int, error) {
+	where, args := publishedFilter(now, "", "")
+	query := `SELECT tags FROM posts ` + where
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list post tags: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var tags tagList
+		if err := rows.Scan(&tags); err != nil {
+			return nil, fmt.Errorf("failed to scan post tags: %w", err)
+		}
+		for _, tag := range NormalizeTags(tags) {
+			counts[tag]++
+		}
+	}
+
+	return counts, rows.Err()
+}
+
+// projectTagCounts counts published projects per normalized tag
+func (r *TagRepository) projectTagCounts(ctx context.Context) (map[string]int, error) {
+	query := `SELECT tags FROM projects WHERE status = $1`
+
+	rows, err := r.db.QueryContext(ctx, query, ProjectStatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project tags: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var tags tagList
+		if err := rows.Scan(&tags); err != nil {
+			return nil, fmt.Errorf("failed to scan project tags: %w", err)
+		}
+		for _, tag := range NormalizeTags(tags) {
+			counts[tag]++
+		}
+	}
+
+	return counts, rows.Err()
+}
+
+// descriptions loads all catalog descriptions keyed by tag name
+func (r *TagRepository) descriptions(ctx context.Context) (map[string]string, error) {
+	query := `SELECT name, description FROM tags`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tag descriptions: %w", err)
+	}
+	defer rows.Close()
+
+	descriptions := make(map[string]string)
+	for rows.Next() {
+		var name string
+		var description sql.NullString
+		if err := rows.Scan(&name, &description); err != nil {
+			return nil, fmt.Errorf("failed to scan tag description: %w", err)
+		}
+		descriptions[name] = description.String
+	}
+
+	return descriptions, rows.Err()
+}
+
+// tagInUse reports whether any post or project, in any status, carries tag
+func tagInUse(ctx context.Context, exec db.QueryExecutor, tag string) (bool, error) {
+	for _, table := range []string{"posts", "projects"} {
+		rows, err := taggedRows(ctx, exec, table, []string{tag})
+		if err != nil {
+			return false, err
+		}
+		if len(rows) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// taggedRows returns the tags of every row of table (posts or projects, in any status) carrying one of
+// the given tags, keyed by ID
+// All rows are read before returning so callers may write within the same transaction
+func taggedRows(ctx context.Context, exec db.QueryExecutor, table string, tags []string) (map[uuid.UUID][]string, error) {
+	wanted := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		wanted[tag] = true
+	}
+
+	tagged := make(map[uuid.UUID][]string)
+	for _, tag := range tags {
+		query := `SELECT id, tags FROM ` + table + ` WHERE tags LIKE $1 ESCAPE '\'`
+
+		rows, err := exec.QueryContext(ctx, query, tagPattern(tag))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find tagged %s: %w", table, err)
+		}
+
+		for rows.Next() {
+			var id uuid.UUID
+			var rowTags tagList
+			if err := rows.Scan(&id, &rowTags); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan tagged %s: %w", table, err)
+			}
+
+			// LIKE is only a prefilter; confirm an exact tag match
+			for _, t := range rowTags {
+				if wanted[NormalizeTag(t)] {
+					tagged[id] = rowTags
+					break
+				}
+			}
+		}
+
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find tagged %s: %w", table, err)
+		}
+	}
+
+	return tagged, nil
+}
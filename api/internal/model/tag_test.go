@@ -0,0 +1,92 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "go", expected: "go"},
+		{input: "Go", expected: "go"},
+		{input: "  golang  ", expected: "golang"},
+		{input: "Machine  Learning", expected: "machine-learning"},
+		{input: "web\tdev\n", expected: "web-dev"},
+		{input: "   ", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeTag(tt.input); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got := NormalizeTags([]string{"Go", "go", " ", "Web Dev", "web-dev", "sql"})
+	expected := []string{"go", "web-dev", "sql"}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if NormalizeTags(nil) != nil {
+		t.Error("expected nil tags to stay nil")
+	}
+}
+
+func TestTagValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		tag       *Tag
+		shouldErr bool
+	}{
+		{name: "valid tag", tag: &Tag{Name: "go", Description: "Posts about Go"}, shouldErr: false},
+		{name: "missing name", tag: &Tag{Description: "No name"}, shouldErr: true},
+		{name: "name too long", tag: &Tag{Name: strings.Repeat("a", MaxTagLength+1)}, shouldErr: true},
+		{name: "description too long", tag: &Tag{Name: "go", Description: strings.Repeat("a", MaxTagDescriptionLength+1)}, shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tag.Validate()
+			if tt.shouldErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.shouldErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTagAliasValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		alias     *TagAlias
+		shouldErr bool
+	}{
+		{name: "valid alias", alias: &TagAlias{Alias: "golang", Tag: "go"}, shouldErr: false},
+		{name: "missing alias", alias: &TagAlias{Tag: "go"}, shouldErr: true},
+		{name: "missing tag", alias: &TagAlias{Alias: "golang"}, shouldErr: true},
+		{name: "alias of itself", alias: &TagAlias{Alias: "go", Tag: "go"}, shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.alias.Validate()
+			if tt.shouldErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.shouldErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
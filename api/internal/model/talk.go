@@ -0,0 +1,418 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// TalkType classifies a speaking or media appearance
+type TalkType string
+
+const (
+	TalkTypeTalk     TalkType = "talk"
+	TalkTypeWorkshop TalkType = "workshop"
+	TalkTypePodcast  TalkType = "podcast"
+	TalkTypeFeature  TalkType = "feature"
+)
+
+// IsValid reports whether t is a known talk type
+func (t TalkType) IsValid() bool {
+	switch t {
+	case TalkTypeTalk, TalkTypeWorkshop, TalkTypePodcast, TalkTypeFeature:
+		return true
+	}
+	return false
+}
+
+// Talk is an appearance listed in the speaking and media section
+type Talk struct {
+	ID           uuid.UUID
+	Title        string
+	Type         TalkType
+	Event        string // Conference, meetup, podcast or publication
+	EventURL     string
+	Location     string
+	Abstract     string
+	StartsAt     time.Time
+	EndsAt       *time.Time // Nil for appearances without a known end, such as a podcast episode
+	SlidesURL    string
+	VideoURL     string
+	RecordingURL string
+	PostID       *uuid.UUID
+	Post         *TalkPost // The related post, loaded with the talk when PostID is set
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// TalkPost is the post that accompanies a talk
+type TalkPost struct {
+	ID    uuid.UUID
+	Slug  string
+	Title string
+
+	post Post // Visibility fields used to hide the post from public readers until it is public
+}
+
+// IsPublic reports whether the related post may be shown to anonymous readers at the given time
+func (p *TalkPost) IsPublic(now time.Time) bool {
+	return p.post.IsPublic(now)
+}
+
+// TalkRepository handles talk data access
+type TalkRepository struct {
+	db db.QueryExecutor
+}
+
+// NewTalkRepository creates a new talk repository
+func NewTalkRepository(db db.QueryExecutor) *TalkRepository {
+	return &TalkRepository{db: db}
+}
+
+// Validate ensures the talk meets business requirements
+func (t *Talk) Validate() error {
+	if strings.TrimSpace(t.Title) == "" {
+		return apierrors.ValidationError{Message: "title is required"}
+	}
+
+	if len(t.Title) > 255 {
+		return apierrors.ValidationError{Message: "title must be 255 characters or less"}
+	}
+
+	if !t.Type.IsValid() {
+		return apierrors.ValidationError{Message: "type must be one of: talk, workshop, podcast, feature"}
+	}
+
+	if strings.TrimSpace(t.Event) == "" {
+		return apierrors.ValidationError{Message: "event is required"}
+	}
+
+	if len(t.Event) > 255 {
+		return apierrors.ValidationError{Message: "event must be 255 characters or less"}
+	}
+
+	if len(t.Location) > 255 {
+		return apierrors.ValidationError{Message: "location must be 255 characters or less"}
+	}
+
+	if len(t.Abstract) > 10000 {
+		return apierrors.ValidationError{Message: "abstract must be 10000 characters or less"}
+	}
+
+	if t.StartsAt.IsZero() {
+		return apierrors.ValidationError{Message: "starts_at is required"}
+	}
+
+	if t.EndsAt != nil && t.EndsAt.Before(t.StartsAt) {
+		return apierrors.ValidationError{Message: "ends_at must not be before starts_at"}
+	}
+
+	links := []struct{ name, url string }{
+		{"event_url", t.EventURL},
+		{"slides_url", t.SlidesURL},
+		{"video_url", t.VideoURL},
+		{"recording_url", t.RecordingURL},
+	}
+	for _, link := range links {
+		if link.url != "" && !isWebURL(link.url) {
+			return apierrors.ValidationError{Message: fmt.Sprintf("%s must be an absolute http or https URL", link.name)}
+		}
+	}
+
+	return nil
+}
+
+// End returns when the talk finishes, or when it starts if no end is recorded
+func (t *Talk) End() time.Time {
+	if t.EndsAt != nil {
+		return *t.EndsAt
+	}
+	return t.StartsAt
+}
+
+// SplitTalks divides talks ordered by start time into those not yet over at now, soonest first,
+// and those already over, most recent first
+func SplitTalks(talks []Talk, now time.Time) (upcoming, past []Talk) {
+	for _, talk := range talks {
+		if talk.End().Before(now) {
+			past = append(past, talk)
+		} else {
+			upcoming = append(upcoming, talk)
+		}
+	}
+
+	for i, j := 0, len(past)-1; i < j; i, j = i+1, j-1 {
+		past[i], past[j] = past[j], past[i]
+	}
+
+	return upcoming, past
+}
+
+// talkColumns lists the talks columns in the order scanTalk reads them, followed by the related post
+const talkColumns = `t.id, t.title, t.type, t.event, t.event_url, t.location, t.abstract, t.starts_at, t.ends_at,
+	t.slides_url, t.video_url, t.recording_url, t.post_id, t.created_at, t.updated_at,
+	p.slug, p.title, p.status, p.published_at, p.unpublish_at, p.deleted_at`
+
+// Create inserts a new talk
+func (r *TalkRepository) Create(ctx context.Context, talk *Talk) error {
+	if talk.ID == uuid.Nil {
+		talk.ID = uuid.New()
+	}
+
+	if err := talk.Validate(); err != nil {
+		return err
+	}
+
+	if err := r.checkPost(ctx, talk.PostID); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	talk.CreatedAt = now
+	talk.UpdatedAt = now
+
+	query := `
+		INSERT INTO talks (id, title, type, event, event_url, location, abstract, starts_at, ends_at,
+			slides_url, video_url, recording_url, post_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		talk.ID,
+		talk.Title,
+		talk.Type,
+		talk.Event,
+		talk.EventURL,
+		talk.Location,
+		talk.Abstract,
+		talk.StartsAt.UTC(),
+		utcPtr(talk.EndsAt),
+		talk.SlidesURL,
+		talk.VideoURL,
+		talk.RecordingURL,
+		talk.PostID,
+		talk.CreatedAt,
+		talk.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create talk: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a talk and its related post by ID
+func (r *TalkRepository) GetByID(ctx context.Context, id uuid.UUID) (*Talk, error) {
+	talk := &Talk{}
+
+	query := `
+		SELECT ` + talkColumns + `
+		FROM talks t
+		LEFT JOIN posts p ON p.id = t.post_id
+		WHERE t.id = $1
+	`
+
+	if err := scanTalk(r.db.QueryRowContext(ctx, query, id), talk); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, apierrors.NotFoundError{Message: "talk not found"}
+	}
+
+	return talk, nil
+}
+
+// List retrieves talks of the given type, or every talk when talkType is empty, by start time
+func (r *TalkRepository) List(ctx context.Context, talkType TalkType) ([]Talk, error) {
+	where := ""
+	var args []interface{}
+	if talkType != "" {
+		where = "WHERE t.type = $1"
+		args = append(args, talkType)
+	}
+
+	query := `
+		SELECT ` + talkColumns + `
+		FROM talks t
+		LEFT JOIN posts p ON p.id = t.post_id
+		` + where + `
+		ORDER BY t.starts_at ASC, t.title ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list talks: %w", err)
+	}
+	defer rows.Close()
+
+	var talks []Talk
+	for rows.Next() {
+		talk := Talk{}
+		if err := scanTalk(rows, &talk); err != nil {
+			return nil, fmt.Errorf("failed to scan talk: %w", err)
+		}
+		talks = append(talks, talk)
+	}
+
+	return talks, rows.Err()
+}
+
+// Update replaces a talk's details
+func (r *TalkRepository) Update(ctx context.Context, talk *Talk) error {
+	if talk.ID == uuid.Nil {
+		return apierrors.ValidationError{Message: "talk ID is required"}
+	}
+
+	if err := talk.Validate(); err != nil {
+		return err
+	}
+
+	if err := r.checkPost(ctx, talk.PostID); err != nil {
+		return err
+	}
+
+	talk.UpdatedAt = time.Now().UTC()
+
+	query := `
+		UPDATE talks
+		SET title = $1, type = $2, event = $3, event_url = $4, location = $5, abstract = $6, starts_at = $7, ends_at = $8,
+			slides_url = $9, video_url = $10, recording_url = $11, post_id = $12, updated_at = $13
+		WHERE id = $14
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		talk.Title,
+		talk.Type,
+		talk.Event,
+		talk.EventURL,
+		talk.Location,
+		talk.Abstract,
+		talk.StartsAt.UTC(),
+		utcPtr(talk.EndsAt),
+		talk.SlidesURL,
+		talk.VideoURL,
+		talk.RecordingURL,
+		talk.PostID,
+		talk.UpdatedAt,
+		talk.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update talk: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "talk not found"}
+	}
+
+	return nil
+}
+
+// Delete removes a talk
+func (r *TalkRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM talks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete talk: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "talk not found"}
+	}
+
+	return nil
+}
+
+// checkPost ensures a related post, when given, exists
+func (r *TalkRepository) checkPost(ctx context.Context, postID *uuid.UUID) error {
+	if postID == nil {
+		return nil
+	}
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM posts WHERE id = $1`, *postID).Scan(&count); err != nil {
+		return fmt.Errorf("failed to check post: %w", err)
+	}
+	if count == 0 {
+		return apierrors.ValidationError{Message: fmt.Sprintf("post %s not found", *postID)}
+	}
+
+	return nil
+}
+
+// scanTalk reads a row selected with talkColumns into talk
+func scanTalk(row rowScanner, talk *Talk) error {
+	var eventURL, location, abstract, slidesURL, videoURL, recordingURL *string
+	var postSlug, postTitle, postStatus *string
+	var post Post
+	err := row.Scan(
+		&talk.ID,
+		&talk.Title,
+		&talk.Type,
+		&talk.Event,
+		&eventURL,
+		&location,
+		&abstract,
+		&talk.StartsAt,
+		&talk.EndsAt,
+		&slidesURL,
+		&videoURL,
+		&recordingURL,
+		&talk.PostID,
+		&talk.CreatedAt,
+		&talk.UpdatedAt,
+		&postSlug,
+		&postTitle,
+		&postStatus,
+		&post.PublishedAt,
+		&post.UnpublishAt,
+		&post.DeletedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	talk.EventURL = derefString(eventURL)
+	talk.Location = derefString(location)
+	talk.Abstract = derefString(abstract)
+	talk.SlidesURL = derefString(slidesURL)
+	talk.VideoURL = derefString(videoURL)
+	talk.RecordingURL = derefString(recordingURL)
+
+	if talk.PostID != nil && postSlug != nil {
+		post.Status = PostStatus(derefString(postStatus))
+		talk.Post = &TalkPost{
+			ID:    *talk.PostID,
+			Slug:  *postSlug,
+			Title: derefString(postTitle),
+			post:  post,
+		}
+	}
+
+	return nil
+}
+
+// utcPtr converts an optional time to UTC
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTalkValidate(t *testing.T) {
+	start := time.Date(2026, 5, 14, 15, 0, 0, 0, time.UTC)
+	valid := func() *Talk {
+		end := start.Add(45 * time.Minute)
+		return &Talk{
+			Title:     "Boring infrastructure",
+			Type:      TalkTypeTalk,
+			Event:     "GopherCon",
+			EventURL:  "https://gophercon.com",
+			StartsAt:  start,
+			EndsAt:    &end,
+			SlidesURL: "https://sochoa.dev/slides/boring.pdf",
+		}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(tk *Talk)
+		shouldErr bool
+	}{
+		{name: "valid talk", modify: func(tk *Talk) {}, shouldErr: false},
+		{name: "no end", modify: func(tk *Talk) { tk.EndsAt = nil }, shouldErr: false},
+		{name: "missing title", modify: func(tk *Talk) { tk.Title = " " }, shouldErr: true},
+		{name: "title too long", modify: func(tk *Talk) { tk.Title = strings.Repeat("a", 256) }, shouldErr: true},
+		{name: "unknown type", modify: func(tk *Talk) { tk.Type = "keynote" }, shouldErr: true},
+		{name: "missing event", modify: func(tk *Talk) { tk.Event = "" }, shouldErr: true},
+		{name: "missing start", modify: func(tk *Talk) { tk.StartsAt = time.Time{} }, shouldErr: true},
+		{name: "ends before start", modify: func(tk *Talk) { early := start.Add(-time.Hour); tk.EndsAt = &early }, shouldErr: true},
+		{name: "relative slides", modify: func(tk *Talk) { tk.SlidesURL = "/slides/boring.pdf" }, shouldErr: true},
+		{name: "non-web recording", modify: func(tk *Talk) { tk.RecordingURL = "javascript:alert(1)" }, shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			talk := valid()
+			tt.modify(talk)
+			err := talk.Validate()
+			if tt.shouldErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.shouldErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSplitTalks(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(days int) time.Time { return now.AddDate(0, 0, days) }
+	inProgressEnd := now.Add(2 * time.Hour)
+
+	talks := []Talk{
+		{Title: "oldest", StartsAt: at(-30)},
+		{Title: "recent", StartsAt: at(-2)},
+		{Title: "in progress", StartsAt: now.Add(-time.Hour), EndsAt: &inProgressEnd},
+		{Title: "next", StartsAt: at(3)},
+		{Title: "later", StartsAt: at(40)},
+	}
+
+	upcoming, past := SplitTalks(talks, now)
+
+	titles := func(talks []Talk) string {
+		var names []string
+		for _, talk := range talks {
+			names = append(names, talk.Title)
+		}
+		return strings.Join(names, ",")
+	}
+
+	if got := titles(upcoming); got != "in progress,next,later" {
+		t.Errorf("upcoming = %s", got)
+	}
+	if got := titles(past); got != "recent,oldest" {
+		t.Errorf("past = %s", got)
+	}
+}
@@ -0,0 +1,351 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sochoa/sochoa.dev/api/internal/db"
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+)
+
+// WebmentionStatus tracks whether a mention's source has been checked for a link to its target
+type WebmentionStatus string
+
+const (
+	WebmentionPending  WebmentionStatus = "pending"
+	WebmentionVerified WebmentionStatus = "verified"
+	WebmentionFailed   WebmentionStatus = "failed"
+)
+
+// Webmention is a W3C Webmention: a page elsewhere (Source) linking to one of our posts (Target)
+// It is shown publicly only once verified and approved by an admin
+type Webmention struct {
+	ID          uuid.UUID
+	PostID      uuid.UUID
+	Source      string
+	Target      string
+	Status      WebmentionStatus
+	IsApproved  bool
+	AuthorName  string // From the source's h-entry, when it has one
+	AuthorURL   string
+	AuthorPhoto string
+	Content     string
+	Attempts    int    // Verification attempts that failed
+	LastError   string // Why the last verification attempt failed
+	VerifiedAt  *time.Time
+	SenderHash  string // VisitorHash of whoever last sent the mention, for rate limiting
+	SourceHost  string // Lowercased host of Source, for rate limiting
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// webmentionColumns lists the webmentions columns in the order scanWebmention reads them
+const webmentionColumns = `id, post_id, source, target, status, approved, author_name, author_url, author_photo, content, attempts, last_error, verified_at, sender_hash, source_host, created_at, updated_at`
+
+// WebmentionRepository handles webmention data access
+type WebmentionRepository struct {
+	db db.QueryExecutor
+}
+
+// NewWebmentionRepository creates a new webmention repository
+func NewWebmentionRepository(db db.QueryExecutor) *WebmentionRepository {
+	return &WebmentionRepository{db: db}
+}
+
+// scanWebmention reads a row selected with webmentionColumns
+func scanWebmention(row interface{ Scan(...interface{}) error }, m *Webmention) error {
+	return row.Scan(
+		&m.ID,
+		&m.PostID,
+		&m.Source,
+		&m.Target,
+		&m.Status,
+		&m.IsApproved,
+		&m.AuthorName,
+		&m.AuthorURL,
+		&m.AuthorPhoto,
+		&m.Content,
+		&m.Attempts,
+		&m.LastError,
+		&m.VerifiedAt,
+		&m.SenderHash,
+		&m.SourceHost,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+	)
+}
+
+// Queue stores a received mention for verification
+// A source resending a mention of the same target means its page changed, so the existing mention
+// goes back to pending and must be approved again once verified
+func (r *WebmentionRepository) Queue(ctx context.Context, mention *Webmention) error {
+	now := time.Now().UTC()
+
+	return db.RunInTx(ctx, r.db, func(tx db.QueryExecutor) error {
+		var existing uuid.UUID
+		err := tx.QueryRowContext(ctx, `SELECT id FROM webmentions WHERE source = $1 AND target = $2`, mention.Source, mention.Target).Scan(&existing)
+		switch {
+		case err == nil:
+			query := `
+				UPDATE webmentions
+				SET post_id = $1, status = $2, approved = false, attempts = 0, last_error = '', sender_hash = $3, source_host = $4, updated_at = $5
+				WHERE id = $6
+			`
+			if _, err := tx.ExecContext(ctx, query, mention.PostID, WebmentionPending, mention.SenderHash, mention.SourceHost, now, existing); err != nil {
+				return fmt.Errorf("failed to requeue webmention: %w", err)
+			}
+			mention.ID = existing
+		case errors.Is(err, sql.ErrNoRows):
+			if mention.ID == uuid.Nil {
+				mention.ID = uuid.New()
+			}
+			query := `
+				INSERT INTO webmentions (id, post_id, source, target, status, approved, sender_hash, source_host, created_at, updated_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			`
+			if _, err := tx.ExecContext(ctx, query, mention.ID, mention.PostID, mention.Source, mention.Target, WebmentionPending, false,
+				mention.SenderHash, mention.SourceHost, now, now); err != nil {
+				return fmt.Errorf("failed to queue webmention: %w", err)
+			}
+			mention.CreatedAt = now
+		default:
+			return fmt.Errorf("failed to look up webmention: %w", err)
+		}
+
+		mention.Status = WebmentionPending
+		mention.IsApproved = false
+		mention.Attempts = 0
+		mention.LastError = ""
+		mention.UpdatedAt = now
+		return nil
+	})
+}
+
+// GetByID retrieves a webmention by ID
+func (r *WebmentionRepository) GetByID(ctx context.Context, id uuid.UUID) (*Webmention, error) {
+	mention := &Webmention{}
+	row := r.db.QueryRowContext(ctx, `SELECT `+webmentionColumns+` FROM webmentions WHERE id = $1`, id)
+	if err := scanWebmention(row, mention); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, apierrors.NotFoundError{Message: "webmention not found"}
+	}
+	return mention, nil
+}
+
+// CountBySenderSince counts the mentions a sender has queued or re-queued since the given time
+func (r *WebmentionRepository) CountBySenderSince(ctx context.Context, senderHash string, since time.Time) (int, error) {
+	return r.count(ctx, `WHERE sender_hash = $1 AND updated_at >= $2`, senderHash, since.UTC())
+}
+
+// CountPendingBySourceHost counts the mentions from a host that are still waiting to be verified
+func (r *WebmentionRepository) CountPendingBySourceHost(ctx context.Context, host string) (int, error) {
+	return r.count(ctx, `WHERE source_host = $1 AND status = $2`, host, WebmentionPending)
+}
+
+// ListDue returns up to limit pending mentions for the verification worker, least recently touched first
+func (r *WebmentionRepository) ListDue(ctx context.Context, limit int) ([]Webmention, error) {
+	query := `SELECT ` + webmentionColumns + ` FROM webmentions WHERE status = $1 ORDER BY updated_at, id LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, WebmentionPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due webmentions: %w", err)
+	}
+	defer rows.Close()
+
+	var mentions []Webmention
+	for rows.Next() {
+		var mention Webmention
+		if err := scanWebmention(rows, &mention); err != nil {
+			return nil, fmt.Errorf("failed to scan webmention: %w", err)
+		}
+		mentions = append(mentions, mention)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list due webmentions: %w", err)
+	}
+
+	return mentions, nil
+}
+
+// MarkVerified records that the mention's source links to its target, with what was read from the source
+func (r *WebmentionRepository) MarkVerified(ctx context.Context, mention *Webmention, now time.Time) error {
+	now = now.UTC()
+	query := `
+		UPDATE webmentions
+		SET status = $1, author_name = $2, author_url = $3, author_photo = $4, content = $5,
+			last_error = '', verified_at = $6, updated_at = $7
+		WHERE id = $8 AND status = $9
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		WebmentionVerified,
+		mention.AuthorName,
+		mention.AuthorURL,
+		mention.AuthorPhoto,
+		mention.Content,
+		now,
+		now,
+		mention.ID,
+		WebmentionPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark webmention verified: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "pending webmention not found"}
+	}
+
+	return nil
+}
+
+// MarkAttemptFailed records a failed verification attempt
+// A final failure marks the mention failed; otherwise it stays pending and is retried on a later run
+func (r *WebmentionRepository) MarkAttemptFailed(ctx context.Context, id uuid.UUID, reason string, final bool, now time.Time) error {
+	status := WebmentionPending
+	if final {
+		status = WebmentionFailed
+	}
+
+	query := `
+		UPDATE webmentions
+		SET status = $1, attempts = attempts + 1, last_error = $2, updated_at = $3
+		WHERE id = $4 AND status = $5
+	`
+
+	result, err := r.db.ExecContext(ctx, query, status, reason, now.UTC(), id, WebmentionPending)
+	if err != nil {
+		return fmt.Errorf("failed to record webmention failure: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "pending webmention not found"}
+	}
+
+	return nil
+}
+
+// approvedMentionOrder lists a post's mentions oldest first, like a comment thread; moderationOrder lists the queue oldest first
+var (
+	approvedMentionOrder = pageOrder{timeColumn: "created_at", timeDesc: false, keyColumn: "id", keyDesc: false}
+	moderationOrder      = pageOrder{timeColumn: "created_at", timeDesc: false, keyColumn: "id", keyDesc: false}
+)
+
+// ListApprovedByPost retrieves a page of a post's verified, approved mentions
+func (r *WebmentionRepository) ListApprovedByPost(ctx context.Context, postID uuid.UUID, page PageRequest) ([]Webmention, *Cursor, error) {
+	mentions, next, err := r.list(ctx, `WHERE post_id = $1 AND status = $2 AND approved = true`, []interface{}{postID, WebmentionVerified}, approvedMentionOrder, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list webmentions: %w", err)
+	}
+	return mentions, next, nil
+}
+
+// CountApprovedByPost counts a post's verified, approved mentions
+func (r *WebmentionRepository) CountApprovedByPost(ctx context.Context, postID uuid.UUID) (int, error) {
+	return r.count(ctx, `WHERE post_id = $1 AND status = $2 AND approved = true`, postID, WebmentionVerified)
+}
+
+// ListAwaitingModeration retrieves a page of verified mentions that have not been approved yet
+func (r *WebmentionRepository) ListAwaitingModeration(ctx context.Context, page PageRequest) ([]Webmention, *Cursor, error) {
+	mentions, next, err := r.list(ctx, `WHERE status = $1 AND approved = false`, []interface{}{WebmentionVerified}, moderationOrder, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list webmentions awaiting moderation: %w", err)
+	}
+	return mentions, next, nil
+}
+
+// CountAwaitingModeration counts verified mentions that have not been approved yet
+func (r *WebmentionRepository) CountAwaitingModeration(ctx context.Context) (int, error) {
+	return r.count(ctx, `WHERE status = $1 AND approved = false`, WebmentionVerified)
+}
+
+// Approve publishes a verified mention
+func (r *WebmentionRepository) Approve(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE webmentions SET approved = true WHERE id = $1 AND status = $2`, id, WebmentionVerified)
+	if err != nil {
+		return fmt.Errorf("failed to approve webmention: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "verified webmention not found"}
+	}
+
+	return nil
+}
+
+// Delete permanently removes a mention; the source can send it again to requeue it
+func (r *WebmentionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webmentions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webmention: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return apierrors.NotFoundError{Message: "webmention not found"}
+	}
+
+	return nil
+}
+
+// list runs a paginated webmention query for the given WHERE clause and its arguments
+func (r *WebmentionRepository) list(ctx context.Context, where string, args []interface{}, order pageOrder, page PageRequest) ([]Webmention, *Cursor, error) {
+	query, args := order.apply(`SELECT `+webmentionColumns+` FROM webmentions `+where, args, page)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var mentions []Webmention
+	for rows.Next() {
+		var mention Webmention
+		if err := scanWebmention(rows, &mention); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan webmention: %w", err)
+		}
+		mentions = append(mentions, mention)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	mentions, next := trimPage(mentions, page.Limit, func(m *Webmention) Cursor {
+		return Cursor{Time: m.CreatedAt, Key: m.ID.String()}
+	})
+	return mentions, next, nil
+}
+
+// count counts webmentions matching the given WHERE clause
+func (r *WebmentionRepository) count(ctx context.Context, where string, args ...interface{}) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM webmentions `+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count webmentions: %w", err)
+	}
+	return count, nil
+}
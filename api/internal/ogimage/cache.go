@@ -0,0 +1,53 @@
+package ogimage
+
+import "sync"
+
+// Cache keeps recently rendered images in memory, keyed by card hash
+// A post edit changes its hash, so stale images are never served and simply age out
+type Cache struct {
+	mu       sync.Mutex
+	tmpl     Template
+	capacity int
+	images   map[string][]byte
+	order    []string // Hashes oldest first, for eviction
+}
+
+// NewCache creates a cache rendering with tmpl that holds up to capacity images
+func NewCache(tmpl Template, capacity int) *Cache {
+	return &Cache{
+		tmpl:     tmpl,
+		capacity: capacity,
+		images:   make(map[string][]byte, capacity),
+	}
+}
+
+// Get returns the card's hash and PNG, rendering it on a miss
+func (c *Cache) Get(card Card) (string, []byte, error) {
+	hash := Hash(c.tmpl, card)
+
+	c.mu.Lock()
+	data, ok := c.images[hash]
+	c.mu.Unlock()
+	if ok {
+		return hash, data, nil
+	}
+
+	// Render outside the lock; concurrent misses for one card render the same bytes
+	data, err := Render(c.tmpl, card)
+	if err != nil {
+		return "", nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.images[hash]; !ok && c.capacity > 0 {
+		if len(c.order) >= c.capacity {
+			delete(c.images, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.images[hash] = data
+		c.order = append(c.order, hash)
+	}
+
+	return hash, data, nil
+}
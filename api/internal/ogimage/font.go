@@ -0,0 +1,147 @@
+package ogimage
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strconv"
+	"strings"
+)
+
+//go:embed fonts/*.txt
+var fontFiles embed.FS
+
+const (
+	glyphWidth  = 5
+	glyphHeight = 8
+)
+
+// glyph is one character's bitmap, indexed [row][column]
+type glyph [glyphHeight][glyphWidth]bool
+
+// font maps characters to their bitmaps
+type font map[rune]glyph
+
+// pixelFont is the embedded pixel font all text is drawn with
+var pixelFont = mustLoadFont("fonts/pixel5x8.txt")
+
+// substitutes spell typographic characters that have no glyph with ones that do
+var substitutes = map[rune]string{
+	'‘': "'", '’': "'", '“': `"`, '”': `"`,
+	'–': "-", '—': "-", '…': "...", '·': "-",
+	'\u00a0': " ",
+}
+
+// mustLoadFont parses an embedded font file, panicking if it is malformed since that is a build error
+func mustLoadFont(name string) font {
+	data, err := fontFiles.ReadFile(name)
+	if err != nil {
+		panic(fmt.Sprintf("ogimage: %v", err))
+	}
+
+	f, err := parseFont(string(data))
+	if err != nil {
+		panic(fmt.Sprintf("ogimage: %s: %v", name, err))
+	}
+	return f
+}
+
+// parseFont reads the text font format: a U+XXXX line per glyph followed by one line per row,
+// with '#' for ink and '.' for paper; blank lines and # comments between glyphs are ignored
+func parseFont(data string) (font, error) {
+	f := make(font)
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		code, ok := strings.CutPrefix(text, "U+")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected a U+XXXX glyph header, got %q", line, text)
+		}
+		r, err := strconv.ParseUint(code, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid code point %q", line, code)
+		}
+
+		var g glyph
+		for row := 0; row < glyphHeight; row++ {
+			if !scanner.Scan() {
+				return nil, fmt.Errorf("glyph U+%s: expected %d rows", code, glyphHeight)
+			}
+			line++
+			bits := strings.TrimSpace(scanner.Text())
+			if len(bits) != glyphWidth {
+				return nil, fmt.Errorf("line %d: glyph row must be %d columns, got %q", line, glyphWidth, bits)
+			}
+			for col, bit := range bits {
+				g[row][col] = bit == '#'
+			}
+		}
+		f[rune(r)] = g
+	}
+
+	return f, scanner.Err()
+}
+
+// normalize replaces characters the font cannot draw, so text measures the same as it renders
+func (f font) normalize(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case f.has(r):
+			b.WriteRune(r)
+		case substitutes[r] != "":
+			b.WriteString(substitutes[r])
+		default:
+			b.WriteRune('?')
+		}
+	}
+	return b.String()
+}
+
+func (f font) has(r rune) bool {
+	_, ok := f[r]
+	return ok
+}
+
+// advance is the horizontal space one character takes at the given scale, including letter spacing
+func advance(scale int) int {
+	return (glyphWidth + 1) * scale
+}
+
+// textWidth measures normalized text at the given scale
+func textWidth(text string, scale int) int {
+	n := len([]rune(text))
+	if n == 0 {
+		return 0
+	}
+	return n*advance(scale) - scale
+}
+
+// drawText draws normalized text with its top-left corner at (x, y), each font pixel a scale x scale square
+func (f font) drawText(dst draw.Image, text string, x, y, scale int, ink color.Color) {
+	src := image.NewUniform(ink)
+	for _, r := range text {
+		g := f[r]
+		for row := 0; row < glyphHeight; row++ {
+			for col := 0; col < glyphWidth; col++ {
+				if !g[row][col] {
+					continue
+				}
+				px := x + col*scale
+				py := y + row*scale
+				draw.Draw(dst, image.Rect(px, py, px+scale, py+scale), src, image.Point{}, draw.Src)
+			}
+		}
+		x += advance(scale)
+	}
+}
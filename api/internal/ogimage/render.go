@@ -0,0 +1,177 @@
+// Package ogimage renders Open Graph share images for posts in pure Go
+package ogimage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"time"
+)
+
+// Share images use the 1.91:1 size Open Graph and Twitter large cards expect
+const (
+	Width  = 1200
+	Height = 630
+)
+
+// Layout of the card, in pixels
+const (
+	margin         = 80
+	accentWidth    = 24
+	siteScale      = 4
+	siteTop        = 64
+	titleTop       = 150
+	titleMaxHeight = 300
+	metaScale      = 4
+	tagsTop        = 480
+	dateTop        = 540
+	lineSpacing    = 2 // Font pixels between title lines, on top of the glyph height
+)
+
+// titleScales are the title sizes tried, largest first, until the title fits its box
+var titleScales = []int{10, 9, 8, 7, 6, 5}
+
+// rendererVersion is part of every card hash, so changing the layout or font invalidates cached images
+const rendererVersion = "1"
+
+// Template is the site-wide look shared by every card
+type Template struct {
+	SiteName   string
+	Background color.RGBA
+	Foreground color.RGBA
+	Muted      color.RGBA
+	Accent     color.RGBA
+}
+
+// DefaultTemplate is the sochoa.dev card style: light text on a dark background with a teal accent
+var DefaultTemplate = Template{
+	SiteName:   "sochoa.dev",
+	Background: color.RGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xff},
+	Foreground: color.RGBA{R: 0xf9, G: 0xfa, B: 0xfb, A: 0xff},
+	Muted:      color.RGBA{R: 0x9c, G: 0xa3, B: 0xaf, A: 0xff},
+	Accent:     color.RGBA{R: 0x2d, G: 0xd4, B: 0xbf, A: 0xff},
+}
+
+// Card is the post content drawn on a share image
+type Card struct {
+	Title string
+	Tags  []string
+	Date  time.Time
+}
+
+// Hash identifies the image a card renders to under a template
+// Cards with the same hash produce identical images, so it serves as both cache key and ETag
+func Hash(tmpl Template, card Card) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%v\x00%v\x00%v\x00%v\x00", rendererVersion, tmpl.SiteName, tmpl.Background, tmpl.Foreground, tmpl.Muted, tmpl.Accent)
+	fmt.Fprintf(h, "%s\x00%s\x00%s", card.Title, strings.Join(card.Tags, "\x01"), card.Date.UTC().Format(time.DateOnly))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Render draws a card and encodes it as PNG
+func Render(tmpl Template, card Card) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(tmpl.Background), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(0, 0, accentWidth, Height), image.NewUniform(tmpl.Accent), image.Point{}, draw.Src)
+
+	maxWidth := Width - 2*margin
+	pixelFont.drawText(img, fitLine(pixelFont.normalize(tmpl.SiteName), maxWidth, siteScale), margin, siteTop, siteScale, tmpl.Accent)
+
+	scale, lines := layoutTitle(pixelFont.normalize(card.Title), maxWidth, titleMaxHeight)
+	for i, line := range lines {
+		pixelFont.drawText(img, line, margin, titleTop+i*lineHeight(scale), scale, tmpl.Foreground)
+	}
+
+	if len(card.Tags) > 0 {
+		tags := make([]string, len(card.Tags))
+		for i, tag := range card.Tags {
+			tags[i] = "#" + tag
+		}
+		pixelFont.drawText(img, fitLine(pixelFont.normalize(strings.Join(tags, "  ")), maxWidth, metaScale), margin, tagsTop, metaScale, tmpl.Accent)
+	}
+
+	if !card.Date.IsZero() {
+		pixelFont.drawText(img, card.Date.UTC().Format("January 2, 2006"), margin, dateTop, metaScale, tmpl.Muted)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode share image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// lineHeight is the distance between title baselines at a scale
+func lineHeight(scale int) int {
+	return (glyphHeight + lineSpacing) * scale
+}
+
+// layoutTitle picks the largest scale at which the title wraps into the box, falling back to the
+// smallest scale with the overflowing lines cut and the last one ending in an ellipsis
+func layoutTitle(title string, maxWidth, maxHeight int) (int, []string) {
+	for _, scale := range titleScales {
+		lines := wrap(title, maxWidth/advance(scale))
+		if len(lines)*lineHeight(scale) <= maxHeight {
+			return scale, lines
+		}
+	}
+
+	scale := titleScales[len(titleScales)-1]
+	maxLines := maxHeight / lineHeight(scale)
+	lines := wrap(title, maxWidth/advance(scale))
+	lines = lines[:maxLines]
+	lines[maxLines-1] = fitLine(lines[maxLines-1]+"...", maxWidth, scale)
+	return scale, lines
+}
+
+// wrap breaks text into lines of at most width characters at spaces, splitting words longer than a line
+func wrap(text string, width int) []string {
+	var lines []string
+	var line []rune
+
+	for _, word := range strings.Fields(text) {
+		runes := []rune(word)
+		for len(runes) > width {
+			if len(line) > 0 {
+				lines = append(lines, string(line))
+				line = nil
+			}
+			lines = append(lines, string(runes[:width]))
+			runes = runes[width:]
+		}
+
+		switch {
+		case len(line) == 0:
+			line = runes
+		case len(line)+1+len(runes) <= width:
+			line = append(append(line, ' '), runes...)
+		default:
+			lines = append(lines, string(line))
+			line = runes
+		}
+	}
+
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// fitLine shortens a single line to fit maxWidth at a scale, ending it in an ellipsis when cut
+func fitLine(text string, maxWidth, scale int) string {
+	if textWidth(text, scale) <= maxWidth {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && textWidth(string(runes)+"...", scale) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimRight(string(runes), " ") + "..."
+}
@@ -0,0 +1,152 @@
+package ogimage
+
+import (
+	"bytes"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPixelFontCoversPrintableASCII(t *testing.T) {
+	for r := rune(0x20); r < 0x7f; r++ {
+		if !pixelFont.has(r) {
+			t.Errorf("font is missing %q", r)
+		}
+	}
+}
+
+func TestParseFontRejectsMalformedGlyphs(t *testing.T) {
+	if _, err := parseFont("U+0041\n#####\n"); err == nil {
+		t.Error("expected an error for a glyph with too few rows")
+	}
+	if _, err := parseFont("A\n"); err == nil {
+		t.Error("expected an error for a missing glyph header")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	got := pixelFont.normalize("It’s “done” — finally… 🎉")
+	want := `It's "done" - finally... ?`
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{"fits", "short title", 20, []string{"short title"}},
+		{"breaks at spaces", "the quick brown fox", 10, []string{"the quick", "brown fox"}},
+		{"splits long words", "supercalifragilistic go", 8, []string{"supercal", "ifragili", "stic go"}},
+		{"empty", "", 10, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrap(tt.text, tt.width); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLayoutTitle(t *testing.T) {
+	scale, lines := layoutTitle("Short", Width-2*margin, titleMaxHeight)
+	if scale != titleScales[0] || len(lines) != 1 {
+		t.Errorf("expected a short title at the largest scale on one line, got scale %d with %d lines", scale, len(lines))
+	}
+
+	long := strings.Repeat("overflowing words ", 60)
+	scale, lines = layoutTitle(long, Width-2*margin, titleMaxHeight)
+	if len(lines)*lineHeight(scale) > titleMaxHeight {
+		t.Errorf("expected the title to fit its box, got %d lines at scale %d", len(lines), scale)
+	}
+	if !strings.HasSuffix(lines[len(lines)-1], "...") {
+		t.Errorf("expected a truncated title to end in an ellipsis, got %q", lines[len(lines)-1])
+	}
+	for _, line := range lines {
+		if textWidth(line, scale) > Width-2*margin {
+			t.Errorf("line %q overflows at scale %d", line, scale)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	card := Card{Title: "Hello, share cards", Tags: []string{"go", "images"}, Date: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)}
+
+	data, err := Render(DefaultTemplate, card)
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to decode rendered PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != Width || b.Dy() != Height {
+		t.Errorf("expected %dx%d, got %dx%d", Width, Height, b.Dx(), b.Dy())
+	}
+
+	again, _ := Render(DefaultTemplate, card)
+	if !bytes.Equal(data, again) {
+		t.Error("expected rendering to be deterministic")
+	}
+}
+
+func TestHash(t *testing.T) {
+	card := Card{Title: "Title", Tags: []string{"go"}, Date: time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)}
+	hash := Hash(DefaultTemplate, card)
+
+	sameDay := card
+	sameDay.Date = card.Date.Add(time.Hour)
+	if Hash(DefaultTemplate, sameDay) != hash {
+		t.Error("expected the time of day, which is not drawn, not to change the hash")
+	}
+
+	edited := card
+	edited.Title = "New title"
+	if Hash(DefaultTemplate, edited) == hash {
+		t.Error("expected a title change to change the hash")
+	}
+
+	retagged := card
+	retagged.Tags = []string{"go", "aws"}
+	if Hash(DefaultTemplate, retagged) == hash {
+		t.Error("expected a tag change to change the hash")
+	}
+
+	restyled := DefaultTemplate
+	restyled.SiteName = "example.com"
+	if Hash(restyled, card) == hash {
+		t.Error("expected a template change to change the hash")
+	}
+}
+
+func TestCache(t *testing.T) {
+	cache := NewCache(DefaultTemplate, 1)
+
+	first, image, err := cache.Get(Card{Title: "First"})
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	if cached, again, _ := cache.Get(Card{Title: "First"}); cached != first || !bytes.Equal(image, again) {
+		t.Error("expected a repeated card to be served from the cache")
+	}
+
+	second, _, _ := cache.Get(Card{Title: "Second"})
+	if second == first {
+		t.Error("expected different cards to hash differently")
+	}
+	if _, ok := cache.images[first]; ok {
+		t.Error("expected the oldest image to be evicted at capacity")
+	}
+	if len(cache.images) != 1 || len(cache.order) != 1 {
+		t.Errorf("expected 1 cached image, got %d", len(cache.images))
+	}
+}
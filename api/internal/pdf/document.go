@@ -0,0 +1,163 @@
+// Package pdf writes simple text documents as PDF 1.4 using only the standard library
+// It supports what generated documents such as resumes need: pages of positioned text in the
+// standard Helvetica fonts, rules, and gray levels. Output is deterministic for the same input
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// US Letter page size in points
+const (
+	PageWidth  = 612.0
+	PageHeight = 792.0
+)
+
+// Style is how a run of text is set
+type Style struct {
+	Font Font
+	Size float64 // Points
+	Gray float64 // 0 is black, 1 is white
+}
+
+// Document is a PDF under construction
+type Document struct {
+	title string
+	pages []*Page
+}
+
+// Page is one page of a document
+// Coordinates are in points from the top-left corner; a text position is the start of its baseline
+type Page struct {
+	content bytes.Buffer
+}
+
+// New creates an empty document with the given title in its metadata
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// AddPage appends a blank page and returns it
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// PageCount returns the number of pages added so far
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text draws text at (x, y) in style
+func (p *Page) Text(x, y float64, style Style, text string) {
+	fmt.Fprintf(&p.content, "BT /F%d %s Tf %s g %s %s Td ", style.Font+1, number(style.Size), number(style.Gray), number(x), number(PageHeight-y))
+	p.content.Write(literal(encode(text)))
+	p.content.WriteString(" Tj ET\n")
+}
+
+// Line draws a straight rule from (x1, y1) to (x2, y2)
+func (p *Page) Line(x1, y1, x2, y2, width, gray float64) {
+	fmt.Fprintf(&p.content, "%s G %s w %s %s m %s %s l S\n",
+		number(gray), number(width), number(x1), number(PageHeight-y1), number(x2), number(PageHeight-y2))
+}
+
+// Bytes serializes the document; a document without pages gets one blank page
+func (d *Document) Bytes() ([]byte, error) {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*Page{{}}
+	}
+
+	// Objects: 1 catalog, 2 page tree, 3 info, then the fonts, then a page and its content stream per page
+	fontStart := 4
+	pageStart := fontStart + len(baseFonts)
+	objects := make([][]byte, pageStart-1+2*len(pages))
+	set := func(id int, body string) { objects[id-1] = []byte(body) }
+
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageStart+2*i)
+	}
+	set(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	set(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", joinRefs(kids), len(pages)))
+	set(3, "<< /Title "+string(literal(encode(d.title)))+" /Producer (sochoa.dev) >>")
+
+	fonts := ""
+	for i, name := range baseFonts {
+		set(fontStart+i, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+		fonts += fmt.Sprintf(" /F%d %d 0 R", i+1, fontStart+i)
+	}
+
+	for i, page := range pages {
+		pageID := pageStart + 2*i
+		set(pageID, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font <<%s >> >> /Contents %d 0 R >>",
+			number(PageWidth), number(PageHeight), fonts, pageID+1))
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.content.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to compress page: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress page: %w", err)
+		}
+		stream := fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+		objects[pageID] = append(append([]byte(stream), compressed.Bytes()...), "\nendstream"...)
+	}
+
+	var out bytes.Buffer
+	// The binary comment marks the file as binary for transfer tools
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	offsets := make([]int, len(objects))
+	for i, body := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n", i+1)
+		out.Write(body)
+		out.WriteString("\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return out.Bytes(), nil
+}
+
+// joinRefs separates object references with spaces
+func joinRefs(refs []string) string {
+	var b bytes.Buffer
+	for i, ref := range refs {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(ref)
+	}
+	return b.String()
+}
+
+// literal writes encoded text as a PDF literal string, escaping the characters that delimit it
+func literal(text []byte) []byte {
+	escaped := make([]byte, 0, len(text)+2)
+	escaped = append(escaped, '(')
+	for _, c := range text {
+		if c == '(' || c == ')' || c == '\\' {
+			escaped = append(escaped, '\\')
+		}
+		escaped = append(escaped, c)
+	}
+	return append(escaped, ')')
+}
+
+// number formats a coordinate or size with at most two decimals and never an exponent, which PDF does not allow
+func number(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
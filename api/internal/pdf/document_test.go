@@ -0,0 +1,123 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	got := encode("Café – “naïve” 🎉\tok")
+	want := []byte{'C', 'a', 'f', 0xE9, ' ', 0x96, ' ', 0x93, 'n', 'a', 0xEF, 'v', 'e', 0x94, ' ', '?', ' ', 'o', 'k'}
+	if !bytes.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		font Font
+		text string
+		want float64
+	}{
+		{Helvetica, "", 0},
+		{Helvetica, "Hi", (722 + 222) * 10.0 / 1000},
+		{HelveticaBold, "Hi", (722 + 278) * 10.0 / 1000},
+		{HelveticaOblique, "é", 556 * 10.0 / 1000},
+		{Helvetica, "—", 1000 * 10.0 / 1000},
+	}
+
+	for _, tt := range tests {
+		if got := Width(tt.font, 10, tt.text); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Width(%d, %q): expected %v, got %v", tt.font, tt.text, tt.want, got)
+		}
+	}
+}
+
+func TestLiteralEscapesDelimiters(t *testing.T) {
+	got := string(literal([]byte(`a (b) \c`)))
+	want := `(a \(b\) \\c)`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestDocumentStructure(t *testing.T) {
+	doc := New("Résumé")
+	first := doc.AddPage()
+	first.Text(72, 72, Style{Font: HelveticaBold, Size: 18}, "Jane (Doe)")
+	first.Line(72, 80, 540, 80, 0.5, 0.6)
+	doc.AddPage().Text(72, 72, Style{Font: Helvetica, Size: 10}, "Page two")
+
+	out, err := doc.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) {
+		t.Error("expected a PDF 1.4 header")
+	}
+	if !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Error("expected the file to end with the EOF marker")
+	}
+	if !bytes.Contains(out, []byte("/Count 2")) {
+		t.Error("expected a page tree with two pages")
+	}
+
+	// startxref must point at the cross-reference table, and each entry at its object
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	if match == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(out[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	if len(entries) != 3+len(baseFonts)+2*2 {
+		t.Fatalf("expected %d xref entries, got %d", 3+len(baseFonts)+4, len(entries))
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if header := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(out[offset:], []byte(header)) {
+			t.Errorf("xref entry %d does not point at %q", i+1, header)
+		}
+	}
+
+	// The first content stream draws the text with its parentheses escaped
+	start := bytes.Index(out, []byte("stream\n")) + len("stream\n")
+	end := bytes.Index(out[start:], []byte("\nendstream"))
+	zr, err := zlib.NewReader(bytes.NewReader(out[start : start+end]))
+	if err != nil {
+		t.Fatalf("content stream is not deflated: %v", err)
+	}
+	content, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("failed to inflate content stream: %v", err)
+	}
+	if want := "BT /F2 18 Tf 0 g 72 720 Td (Jane \\(Doe\\)) Tj ET\n"; !bytes.Contains(content, []byte(want)) {
+		t.Errorf("expected content to contain %q, got %q", want, content)
+	}
+}
+
+func TestDocumentIsDeterministic(t *testing.T) {
+	render := func() []byte {
+		doc := New("Same")
+		doc.AddPage().Text(72, 72, Style{Size: 12}, "Hello")
+		out, err := doc.Bytes()
+		if err != nil {
+			t.Fatalf("Bytes failed: %v", err)
+		}
+		return out
+	}
+
+	if !bytes.Equal(render(), render()) {
+		t.Error("expected identical output for identical documents")
+	}
+}
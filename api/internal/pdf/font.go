@@ -0,0 +1,117 @@
+package pdf
+
+// Font is one of the standard Type 1 fonts every PDF reader provides, so nothing has to be embedded
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+	HelveticaOblique
+)
+
+// baseFonts are the PostScript names of the fonts, in Font order
+var baseFonts = [...]string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique"}
+
+// Glyph widths of printable ASCII (32-126) in thousandths of the font size, from the Adobe font metrics
+// Helvetica-Oblique shares Helvetica's widths
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space to /
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0 to 9
+		278, 278, 584, 584, 584, 556, 1015, // : to @
+		667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, // A to M
+		722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N to Z
+		278, 278, 278, 469, 556, 333, // [ to `
+		556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, // a to m
+		556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, // n to z
+		334, 260, 334, 584, // { to ~
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556,
+		333, 333, 584, 584, 584, 611, 975,
+		722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833,
+		722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611,
+		333, 278, 333, 584, 556, 333,
+		556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889,
+		611, 611, 611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500,
+		389, 280, 389, 584,
+	}
+)
+
+// winAnsiPunctuation maps the typographic characters WinAnsiEncoding places in 0x80-0x9F to their codes,
+// with their Helvetica and Helvetica-Bold widths
+var winAnsiPunctuation = map[rune]struct {
+	code        byte
+	width, bold int
+}{
+	'€': {0x80, 556, 556},
+	'…': {0x85, 1000, 1000},
+	'‘': {0x91, 222, 278},
+	'’': {0x92, 222, 278},
+	'“': {0x93, 333, 500},
+	'”': {0x94, 333, 500},
+	'•': {0x95, 350, 350},
+	'–': {0x96, 556, 556},
+	'—': {0x97, 1000, 1000},
+	'™': {0x99, 1000, 1000},
+}
+
+// latin1Base gives, for each Latin-1 letter from 0xC0 to 0xFF, an ASCII character of about the same width
+// Accented letters measure as their base letter; ligatures and signs use a close stand-in
+const latin1Base = "AAAAAAWCEEEEIIIIDNOOOOO+OUUUUYPpaaaaaamceeeeiiiionooooo+ouuuuypy"
+
+// encode converts text to WinAnsiEncoding bytes, replacing characters the encoding lacks with '?'
+func encode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r >= 32 && r <= 126, r >= 0xA0 && r <= 0xFF:
+			encoded = append(encoded, byte(r))
+		case r == '\t' || r == '\n':
+			encoded = append(encoded, ' ')
+		default:
+			if p, ok := winAnsiPunctuation[r]; ok {
+				encoded = append(encoded, p.code)
+			} else {
+				encoded = append(encoded, '?')
+			}
+		}
+	}
+	return encoded
+}
+
+// glyphWidth returns the width of an encoded character in thousandths of the font size
+func glyphWidth(font Font, code byte) int {
+	widths := &helveticaWidths
+	if font == HelveticaBold {
+		widths = &helveticaBoldWidths
+	}
+
+	switch {
+	case code >= 32 && code <= 126:
+		return widths[code-32]
+	case code >= 0xC0:
+		return widths[latin1Base[code-0xC0]-32]
+	case code >= 0x80 && code <= 0x9F:
+		for _, p := range winAnsiPunctuation {
+			if p.code == code {
+				if font == HelveticaBold {
+					return p.bold
+				}
+				return p.width
+			}
+		}
+	}
+	// Latin-1 signs such as © and ° are close enough to a digit's width
+	return widths['0'-32]
+}
+
+// Width returns the width of text set in font at size, in points
+func Width(font Font, size float64, text string) float64 {
+	total := 0
+	for _, code := range encode(text) {
+		total += glyphWidth(font, code)
+	}
+	return float64(total) * size / 1000
+}
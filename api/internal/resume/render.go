@@ -0,0 +1,212 @@
+// Package resume lays out resume variants as printable PDF documents
+package resume
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/pdf"
+)
+
+// Layout in points; pages are US Letter with 0.75in margins
+const (
+	margin       = 54.0
+	contentWidth = pdf.PageWidth - 2*margin
+	lineSpacing  = 1.35 // Baseline-to-baseline distance as a multiple of the font size
+	bulletIndent = 12.0
+	sectionGap   = 14.0
+	entryGap     = 8.0
+)
+
+var (
+	nameStyle     = pdf.Style{Font: pdf.HelveticaBold, Size: 20}
+	headlineStyle = pdf.Style{Font: pdf.Helvetica, Size: 11, Gray: 0.35}
+	headingStyle  = pdf.Style{Font: pdf.HelveticaBold, Size: 12}
+	titleStyle    = pdf.Style{Font: pdf.HelveticaBold, Size: 10.5}
+	datesStyle    = pdf.Style{Font: pdf.Helvetica, Size: 9.5, Gray: 0.35}
+	placeStyle    = pdf.Style{Font: pdf.HelveticaOblique, Size: 9.5, Gray: 0.35}
+	bodyStyle     = pdf.Style{Font: pdf.Helvetica, Size: 9.5}
+)
+
+// headings name each kind of section
+var headings = map[model.ResumeSectionKind]string{
+	model.ResumeSectionExperience: "Experience",
+	model.ResumeSectionSkills:     "Skills",
+	model.ResumeSectionEducation:  "Education",
+}
+
+// Render lays out a variant and its sections, in order, as a PDF
+// A heading is drawn whenever the kind of section changes, so a variant controls grouping through its ordering
+func Render(variant *model.ResumeVariant) ([]byte, error) {
+	w := &writer{doc: pdf.New(variant.Name)}
+	w.newPage()
+
+	w.paragraph(nameStyle, 0, variant.Name)
+	if variant.Headline != "" {
+		w.paragraph(headlineStyle, 0, variant.Headline)
+	}
+	if variant.Summary != "" {
+		w.y += entryGap
+		w.paragraph(bodyStyle, 0, variant.Summary)
+	}
+
+	var kind model.ResumeSectionKind
+	for _, section := range variant.Sections {
+		if section.Kind != kind {
+			kind = section.Kind
+			w.heading(headings[kind])
+		} else {
+			w.y += entryGap
+		}
+		w.section(section)
+	}
+
+	return w.doc.Bytes()
+}
+
+// writer tracks the current page and the baseline of the last line drawn on it
+type writer struct {
+	doc  *pdf.Document
+	page *pdf.Page
+	y    float64
+}
+
+// newPage starts a page with the cursor at the top margin
+func (w *writer) newPage() {
+	w.page = w.doc.AddPage()
+	w.y = margin
+}
+
+// advance moves the cursor down to the next baseline for style, starting a new page when it would not fit
+func (w *writer) advance(style pdf.Style) {
+	w.y += style.Size * lineSpacing
+	if w.y > pdf.PageHeight-margin {
+		w.newPage()
+		w.y += style.Size * lineSpacing
+	}
+}
+
+// paragraph draws text wrapped to the content width, indented by indent
+func (w *writer) paragraph(style pdf.Style, indent float64, text string) {
+	for _, line := range wrap(style, contentWidth-indent, text) {
+		w.advance(style)
+		w.page.Text(margin+indent, w.y, style, line)
+	}
+}
+
+// heading draws a section heading with a rule beneath it
+// It moves to a new page when there is no room for the heading and a first line under it
+func (w *writer) heading(text string) {
+	w.y += sectionGap
+	if w.y+headingStyle.Size*lineSpacing+3*titleStyle.Size*lineSpacing > pdf.PageHeight-margin {
+		w.newPage()
+	}
+	w.advance(headingStyle)
+	w.page.Text(margin, w.y, headingStyle, strings.ToUpper(text))
+	w.page.Line(margin, w.y+4, margin+contentWidth, w.y+4, 0.75, 0.6)
+	w.y += 4
+}
+
+// section draws one entry: its title and dates, where it took place, its summary and its highlights
+func (w *writer) section(section model.ResumeSection) {
+	dates := formatDates(section.StartDate, section.EndDate)
+	datesWidth := pdf.Width(datesStyle.Font, datesStyle.Size, dates)
+
+	titleWidth := contentWidth
+	if dates != "" {
+		titleWidth -= datesWidth + bulletIndent
+	}
+	for i, line := range wrap(titleStyle, titleWidth, section.Title) {
+		w.advance(titleStyle)
+		w.page.Text(margin, w.y, titleStyle, line)
+		if i == 0 && dates != "" {
+			w.page.Text(margin+contentWidth-datesWidth, w.y, datesStyle, dates)
+		}
+	}
+
+	var place []string
+	for _, part := range []string{section.Organization, section.Location} {
+		if part != "" {
+			place = append(place, part)
+		}
+	}
+	if len(place) > 0 {
+		w.paragraph(placeStyle, 0, strings.Join(place, " · "))
+	}
+
+	if section.Summary != "" {
+		w.paragraph(bodyStyle, 0, section.Summary)
+	}
+
+	for _, highlight := range section.Highlights {
+		for i, line := range wrap(bodyStyle, contentWidth-bulletIndent, highlight) {
+			w.advance(bodyStyle)
+			if i == 0 {
+				w.page.Text(margin+3, w.y, bodyStyle, "•")
+			}
+			w.page.Text(margin+bulletIndent, w.y, bodyStyle, line)
+		}
+	}
+}
+
+// wrap breaks text into lines no wider than width, splitting at spaces and, for words longer than a line, within words
+func wrap(style pdf.Style, width float64, text string) []string {
+	fits := func(s string) bool { return pdf.Width(style.Font, style.Size, s) <= width }
+
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if fits(candidate) {
+			line = candidate
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = word
+		for !fits(line) {
+			runes := []rune(line)
+			cut := len(runes) - 1
+			for cut > 1 && !fits(string(runes[:cut])) {
+				cut--
+			}
+			lines = append(lines, string(runes[:cut]))
+			line = string(runes[cut:])
+		}
+	}
+
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// formatDates renders a YYYY-MM range such as "Jan 2020 – Present"
+func formatDates(start, end string) string {
+	switch {
+	case start == "" && end == "":
+		return ""
+	case start == "":
+		return formatMonth(end)
+	case end == "":
+		return formatMonth(start) + " – Present"
+	case start == end:
+		return formatMonth(start)
+	}
+	return formatMonth(start) + " – " + formatMonth(end)
+}
+
+// formatMonth renders a YYYY-MM date as "Jan 2020"
+func formatMonth(date string) string {
+	t, err := time.Parse("2006-01", date)
+	if err != nil {
+		return date
+	}
+	return t.Format("Jan 2006")
+}
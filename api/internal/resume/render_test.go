@@ -0,0 +1,104 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/pdf"
+)
+
+func TestWrap(t *testing.T) {
+	style := pdf.Style{Font: pdf.Helvetica, Size: 10}
+	// "0" is 5.56pt wide at 10pt, so 30pt fits five of them
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"fits", "00 00", []string{"00 00"}},
+		{"breaks at spaces", "00 00 00", []string{"00 00", "00"}},
+		{"splits long words", "000000000000", []string{"00000", "00000", "00"}},
+		{"collapses whitespace", "  00 \n 0 ", []string{"00 0"}},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrap(style, 30, tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatDates(t *testing.T) {
+	tests := []struct {
+		start, end, want string
+	}{
+		{"", "", ""},
+		{"2020-01", "2023-06", "Jan 2020 – Jun 2023"},
+		{"2020-01", "", "Jan 2020 – Present"},
+		{"", "2012-05", "May 2012"},
+		{"2019-09", "2019-09", "Sep 2019"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDates(tt.start, tt.end); got != tt.want {
+			t.Errorf("formatDates(%q, %q): expected %q, got %q", tt.start, tt.end, tt.want, got)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	variant := &model.ResumeVariant{
+		Name:     "Engineering Leadership",
+		Headline: "Engineering manager and staff engineer",
+		Summary:  "Builds teams and platforms.",
+		Sections: []model.ResumeSection{
+			{Kind: model.ResumeSectionExperience, Title: "Director of Engineering", Organization: "Acme", Location: "Remote", StartDate: "2021-02", Highlights: []string{"Grew the team from 4 to 20 engineers"}},
+			{Kind: model.ResumeSectionSkills, Title: "Languages", Summary: "Go, TypeScript, SQL"},
+		},
+	}
+
+	out, err := Render(variant)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !bytes.HasPrefix(out, []byte("%PDF-")) {
+		t.Fatalf("expected a PDF, got %q", out[:min(len(out), 16)])
+	}
+	if !bytes.Contains(out, []byte("/Count 1")) {
+		t.Error("expected a single page")
+	}
+
+	again, err := Render(variant)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Error("expected identical output for the same variant")
+	}
+}
+
+func TestRenderBreaksPages(t *testing.T) {
+	variant := &model.ResumeVariant{Name: "Long"}
+	for i := 0; i < 40; i++ {
+		variant.Sections = append(variant.Sections, model.ResumeSection{
+			Kind:       model.ResumeSectionExperience,
+			Title:      fmt.Sprintf("Role %d", i),
+			Highlights: []string{strings.Repeat("Shipped things that mattered. ", 8)},
+		})
+	}
+
+	out, err := Render(variant)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if bytes.Contains(out, []byte("/Count 1 ")) {
+		t.Error("expected the sections to flow onto more than one page")
+	}
+}
@@ -0,0 +1,238 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/sochoa/sochoa.dev/api/internal/events"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/webmention"
+)
+
+// Job is a unit of periodic background work
+type Job struct {
+	Name string
+	Run  func(ctx context.Context, now time.Time) error
+}
+
+// Scheduler runs background jobs on a fixed interval
+// Long-running servers call Start; Lambda deployments call RunOnce from a scheduled event
+type Scheduler struct {
+	log      *slog.Logger
+	interval time.Duration
+	jobs     []Job
+	now      func() time.Time
+}
+
+// New creates a new scheduler for the given jobs
+func New(log *slog.Logger, interval time.Duration, jobs ...Job) *Scheduler {
+	return &Scheduler{
+		log:      log,
+		interval: interval,
+		jobs:     jobs,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Start runs all jobs immediately and then on every tick until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunOnce(ctx); err != nil {
+			s.log.Error("scheduler run failed", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce runs every job a single time
+// A failing job does not prevent the remaining jobs from running
+func (s *Scheduler) RunOnce(ctx context.Context) error {
+	now := s.now()
+
+	var failed []string
+	for _, job := range s.jobs {
+		if err := job.Run(ctx, now); err != nil {
+			s.log.Error("scheduled job failed",
+				slog.String("job", job.Name),
+				slog.String("error", err.Error()),
+			)
+			failed = append(failed, job.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("scheduled jobs failed: %v", failed)
+	}
+
+	return nil
+}
+
+// PublishScheduledPosts returns a job that publishes scheduled posts whose time has come
+// and archives published posts whose unpublish_at has passed, emitting an event for each post it moves
+func PublishScheduledPosts(log *slog.Logger, postRepo *model.PostRepository, bus *events.Bus) Job {
+	return Job{
+		Name: "publish_scheduled_posts",
+		Run: func(ctx context.Context, now time.Time) error {
+			// Posts moved before an error are saved, so their events are emitted either way
+			published, err := postRepo.PublishDue(ctx, now)
+			publishStatusChanges(ctx, bus, events.PostPublished, published)
+			if err != nil {
+				return err
+			}
+
+			unpublished, err := postRepo.UnpublishDue(ctx, now)
+			publishStatusChanges(ctx, bus, events.PostArchived, unpublished)
+			if err != nil {
+				return err
+			}
+
+			if len(published) > 0 || len(unpublished) > 0 {
+				log.Info("scheduled posts updated",
+					slog.Int("published", len(published)),
+					slog.Int("unpublished", len(unpublished)),
+				)
+			}
+
+			return nil
+		},
+	}
+}
+
+// publishStatusChanges emits an event of the given type for each post the scheduler moved
+func publishStatusChanges(ctx context.Context, bus *events.Bus, eventType events.Type, changes []model.StatusChange) {
+	for _, change := range changes {
+		bus.Publish(ctx, events.Event{
+			Type:   eventType,
+			PostID: change.PostID,
+			Slug:   change.Slug,
+			From:   change.From,
+			To:     change.To,
+			At:     change.At,
+		})
+	}
+}
+
+// ComputeReadingMetadata returns a job that fills in word counts, reading times and
+// tables of contents for posts written before they were stored
+func ComputeReadingMetadata(log *slog.Logger, postRepo *model.PostRepository) Job {
+	return Job{
+		Name: "compute_reading_metadata",
+		Run: func(ctx context.Context, now time.Time) error {
+			updated, err := postRepo.ComputeMissingReadingMetadata(ctx)
+			if err != nil {
+				return err
+			}
+
+			if updated > 0 {
+				log.Info("reading metadata computed", slog.Int64("posts", updated))
+			}
+
+			return nil
+		},
+	}
+}
+
+// RefreshRelatedPosts returns a job that rebuilds the related-posts index after posts change
+func RefreshRelatedPosts(log *slog.Logger, relatedRepo *model.RelatedPostRepository) Job {
+	return Job{
+		Name: "refresh_related_posts",
+		Run: func(ctx context.Context, now time.Time) error {
+			rebuilt, err := relatedRepo.RefreshIfStale(ctx, now)
+			if err != nil {
+				return err
+			}
+
+			if rebuilt {
+				log.Info("related posts index rebuilt")
+			}
+
+			return nil
+		},
+	}
+}
+
+// PurgeTrashedPosts returns a job that permanently deletes posts that have been in the trash longer than retention
+func PurgeTrashedPosts(log *slog.Logger, postRepo *model.PostRepository, retention time.Duration) Job {
+	return Job{
+		Name: "purge_trashed_posts",
+		Run: func(ctx context.Context, now time.Time) error {
+			purged, err := postRepo.PurgeTrashedBefore(ctx, now.Add(-retention))
+			if err != nil {
+				return err
+			}
+
+			if purged > 0 {
+				log.Info("trashed posts purged", slog.Int64("posts", purged))
+			}
+
+			return nil
+		},
+	}
+}
+
+// PurgeUnconfirmedSubscribers returns a job that deletes newsletter addresses never confirmed within their retention
+func PurgeUnconfirmedSubscribers(log *slog.Logger, newsletterRepo *model.NewsletterRepository) Job {
+	return Job{
+		Name: "purge_unconfirmed_subscribers",
+		Run: func(ctx context.Context, now time.Time) error {
+			purged, err := newsletterRepo.PurgeExpired(ctx, now)
+			if err != nil {
+				return err
+			}
+
+			if purged > 0 {
+				log.Info("unconfirmed subscribers purged", slog.Int64("subscribers", purged))
+			}
+
+			return nil
+		},
+	}
+}
+
+// PurgeReactionSalts returns a job that discards the visitor-hash salts of past days
+func PurgeReactionSalts(log *slog.Logger, reactionRepo *model.ReactionRepository) Job {
+	return Job{
+		Name: "purge_reaction_salts",
+		Run: func(ctx context.Context, now time.Time) error {
+			purged, err := reactionRepo.PurgeSalts(ctx, now)
+			if err != nil {
+				return err
+			}
+
+			if purged > 0 {
+				log.Info("reaction salts purged", slog.Int64("salts", purged))
+			}
+
+			return nil
+		},
+	}
+}
+
+// VerifyWebmentions returns a job that fetches the sources of queued webmentions and verifies their links
+func VerifyWebmentions(log *slog.Logger, worker *webmention.Worker) Job {
+	return Job{
+		Name: "verify_webmentions",
+		Run: func(ctx context.Context, now time.Time) error {
+			verified, failed, err := worker.Run(ctx, now)
+			if err != nil {
+				return err
+			}
+
+			if verified > 0 || failed > 0 {
+				log.Info("webmentions verified", slog.Int64("verified", verified), slog.Int64("failed", failed))
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func TestRunOnceRunsAllJobs(t *testing.T) {
+	var ran []string
+	failing := Job{
+		Name: "failing",
+		Run: func(_ context.Context, _ time.Time) error {
+			ran = append(ran, "failing")
+			return errors.New("boom")
+		},
+	}
+	succeeding := Job{
+		Name: "succeeding",
+		Run: func(_ context.Context, _ time.Time) error {
+			ran = append(ran, "succeeding")
+			return nil
+		},
+	}
+
+	s := New(testLogger(), time.Minute, failing, succeeding)
+
+	if err := s.RunOnce(context.Background()); err == nil {
+		t.Fatal("expected error from failing job")
+	}
+
+	if len(ran) != 2 {
+		t.Fatalf("expected both jobs to run, got %v", ran)
+	}
+}
+
+func TestRunOncePassesCurrentTime(t *testing.T) {
+	fixed := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	var got time.Time
+	s := New(testLogger(), time.Minute, Job{
+		Name: "capture",
+		Run: func(_ context.Context, now time.Time) error {
+			got = now
+			return nil
+		},
+	})
+	s.now = func() time.Time { return fixed }
+
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !got.Equal(fixed) {
+		t.Fatalf("expected %v, got %v", fixed, got)
+	}
+}
+
+func TestStartStopsOnCancel(t *testing.T) {
+	runs := make(chan struct{}, 1)
+	s := New(testLogger(), time.Hour, Job{
+		Name: "tick",
+		Run: func(_ context.Context, _ time.Time) error {
+			select {
+			case runs <- struct{}{}:
+			default:
+			}
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Start(ctx)
+		close(done)
+	}()
+
+	<-runs
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after cancel")
+	}
+}
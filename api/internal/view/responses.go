@@ -7,6 +7,13 @@ import (
 	"github.com/sochoa/sochoa.dev/api/internal/model"
 )
 
+// PageResponse wraps a page of list results for cursor-paginated requests
+type PageResponse struct {
+	Items      interface{} `json:"items"`
+	NextCursor *string     `json:"next_cursor"`
+	Total      *int        `json:"total,omitempty"`
+}
+
 // PostResponse represents a post in JSON format
 type PostResponse struct {
 	ID          uuid.UUID `json:"id"`
@@ -16,9 +23,19 @@ type PostResponse struct {
 	Body        string    `json:"body"`
 	Tags        []string  `json:"tags"`
 	Status      string    `json:"status"`
+	Locale      string    `json:"locale"` // Language of the title, summary and body
+	Alternates  []PostAlternateResponse `json:"alternates,omitempty"` // Every language the post is available in, for hreflang links
 	PublishedAt *time.Time `json:"published_at,omitempty"`
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+	WordCount   int       `json:"word_count"`
+	ReadingTime int       `json:"reading_time_minutes"`
+	TOC         []TOCEntryResponse `json:"toc"`
+	Series      *SeriesNavigationResponse `json:"series,omitempty"`
+	Reactions   map[string]int `json:"reactions,omitempty"` // Count per allowed emoji
 	UpdatedAt   time.Time `json:"updated_at"`
 	CreatedAt   time.Time `json:"created_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"` // Set for posts in the trash
 }
 
 // ToPostResponse converts a Post model to a JSON response
@@ -35,10 +52,41 @@ func ToPostResponse(p *model.Post) *PostResponse {
 		Body:        p.Body,
 		Tags:        tags,
 		Status:      string(p.Status),
+		Locale:      p.Locale,
 		PublishedAt: p.PublishedAt,
+		PublishAt:   p.PublishAt,
+		UnpublishAt: p.UnpublishAt,
+		WordCount:   p.WordCount,
+		ReadingTime: p.ReadingTime,
+		TOC:         ToTOCEntryResponses(p.TOC),
 		UpdatedAt:   p.UpdatedAt,
 		CreatedAt:   p.CreatedAt,
+		DeletedAt:   p.DeletedAt,
+	}
+}
+
+// TOCEntryResponse represents a table of contents heading in JSON format
+type TOCEntryResponse struct {
+	Level    int                `json:"level"`
+	Text     string             `json:"text"`
+	Anchor   string             `json:"anchor"`
+	Children []TOCEntryResponse `json:"children,omitempty"`
+}
+
+// ToTOCEntryResponses converts a table of contents to JSON responses
+func ToTOCEntryResponses(toc []model.TOCEntry) []TOCEntryResponse {
+	responses := make([]TOCEntryResponse, len(toc))
+	for i, entry := range toc {
+		responses[i] = TOCEntryResponse{
+			Level:  entry.Level,
+			Text:   entry.Text,
+			Anchor: entry.Anchor,
+		}
+		if len(entry.Children) > 0 {
+			responses[i].Children = ToTOCEntryResponses(entry.Children)
+		}
 	}
+	return responses
 }
 
 // ToPostResponses converts multiple Post models to JSON responses
@@ -50,6 +98,356 @@ func ToPostResponses(posts []model.Post) []PostResponse {
 	return responses
 }
 
+// PostAlternateResponse is one language version of a post in JSON format
+type PostAlternateResponse struct {
+	Locale string `json:"locale"`
+	Slug   string `json:"slug"`
+}
+
+// ToPostAlternateResponses lists a post's own language and those of its translations
+func ToPostAlternateResponses(p *model.Post, translations []model.PostTranslation) []PostAlternateResponse {
+	alternates := []PostAlternateResponse{{Locale: p.Locale, Slug: p.Slug}}
+	for _, t := range translations {
+		alternates = append(alternates, PostAlternateResponse{Locale: t.Locale, Slug: t.Slug})
+	}
+	return alternates
+}
+
+// PostTranslationResponse represents a post translation in JSON format
+type PostTranslationResponse struct {
+	PostID      uuid.UUID          `json:"post_id"`
+	Locale      string             `json:"locale"`
+	Slug        string             `json:"slug"`
+	Title       string             `json:"title"`
+	Summary     string             `json:"summary"`
+	Body        string             `json:"body"`
+	WordCount   int                `json:"word_count"`
+	ReadingTime int                `json:"reading_time_minutes"`
+	TOC         []TOCEntryResponse `json:"toc"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// ToPostTranslationResponse converts a PostTranslation model to a JSON response
+func ToPostTranslationResponse(t *model.PostTranslation) *PostTranslationResponse {
+	return &PostTranslationResponse{
+		PostID:      t.PostID,
+		Locale:      t.Locale,
+		Slug:        t.Slug,
+		Title:       t.Title,
+		Summary:     t.Summary,
+		Body:        t.Body,
+		WordCount:   t.WordCount,
+		ReadingTime: t.ReadingTime,
+		TOC:         ToTOCEntryResponses(t.TOC),
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+// ToPostTranslationResponses converts multiple PostTranslation models to JSON responses
+func ToPostTranslationResponses(translations []model.PostTranslation) []PostTranslationResponse {
+	responses := make([]PostTranslationResponse, len(translations))
+	for i := range translations {
+		responses[i] = *ToPostTranslationResponse(&translations[i])
+	}
+	return responses
+}
+
+// TagResponse represents a tag and its published post and project counts in JSON format
+type TagResponse struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	PostCount    int    `json:"post_count"`
+	ProjectCount int    `json:"project_count"`
+}
+
+// ToTagResponse converts a Tag model to a JSON response
+func ToTagResponse(t *model.Tag) *TagResponse {
+	return &TagResponse{
+		Name:         t.Name,
+		Description:  t.Description,
+		PostCount:    t.PostCount,
+		ProjectCount: t.ProjectCount,
+	}
+}
+
+// ToTagResponses converts multiple Tag models to JSON responses
+func ToTagResponses(tags []model.Tag) []TagResponse {
+	responses := make([]TagResponse, len(tags))
+	for i, t := range tags {
+		responses[i] = *ToTagResponse(&t)
+	}
+	return responses
+}
+
+// TagAliasResponse represents a tag alias in JSON format
+type TagAliasResponse struct {
+	Alias     string    `json:"alias"`
+	Tag       string    `json:"tag"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToTagAliasResponse converts a TagAlias model to a JSON response
+func ToTagAliasResponse(a *model.TagAlias) *TagAliasResponse {
+	return &TagAliasResponse{
+		Alias:     a.Alias,
+		Tag:       a.Tag,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+// ToTagAliasResponses converts multiple TagAlias models to JSON responses
+func ToTagAliasResponses(aliases []model.TagAlias) []TagAliasResponse {
+	responses := make([]TagAliasResponse, len(aliases))
+	for i, a := range aliases {
+		responses[i] = *ToTagAliasResponse(&a)
+	}
+	return responses
+}
+
+// RetagResponse reports the outcome of a tag rename or merge
+type RetagResponse struct {
+	Tag          string   `json:"tag"`
+	Replaced     []string `json:"replaced"`
+	PostsUpdated int64    `json:"posts_updated"`
+}
+
+// SeriesPostResponse represents a post's place within a series in JSON format
+type SeriesPostResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Slug     string    `json:"slug"`
+	Title    string    `json:"title"`
+	Position int       `json:"position"`
+}
+
+// ToSeriesPostResponse converts a SeriesPost model to a JSON response
+func ToSeriesPostResponse(p *model.SeriesPost) *SeriesPostResponse {
+	if p == nil {
+		return nil
+	}
+	return &SeriesPostResponse{
+		ID:       p.PostID,
+		Slug:     p.Slug,
+		Title:    p.Title,
+		Position: p.Position,
+	}
+}
+
+// SeriesNavigationResponse locates a post within its series in JSON format
+type SeriesNavigationResponse struct {
+	ID       uuid.UUID           `json:"id"`
+	Slug     string              `json:"slug"`
+	Title    string              `json:"title"`
+	Position int                 `json:"position"`
+	Total    int                 `json:"total"`
+	Previous *SeriesPostResponse `json:"previous,omitempty"`
+	Next     *SeriesPostResponse `json:"next,omitempty"`
+}
+
+// ToSeriesNavigationResponse converts a SeriesNavigation model to a JSON response
+func ToSeriesNavigationResponse(n *model.SeriesNavigation) *SeriesNavigationResponse {
+	if n == nil {
+		return nil
+	}
+	return &SeriesNavigationResponse{
+		ID:       n.SeriesID,
+		Slug:     n.SeriesSlug,
+		Title:    n.SeriesTitle,
+		Position: n.Position,
+		Total:    n.Total,
+		Previous: ToSeriesPostResponse(n.Previous),
+		Next:     ToSeriesPostResponse(n.Next),
+	}
+}
+
+// ReactionResponse reports a post's reaction counts after a reader reacts
+type ReactionResponse struct {
+	Emoji     string         `json:"emoji"`
+	Reactions map[string]int `json:"reactions"` // Count per allowed emoji
+}
+
+// SeriesResponse represents a series and its ordered posts in JSON format
+type SeriesResponse struct {
+	ID          uuid.UUID            `json:"id"`
+	Slug        string               `json:"slug"`
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	Posts       []SeriesPostResponse `json:"posts"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+	CreatedAt   time.Time            `json:"created_at"`
+}
+
+// ToSeriesResponse converts a Series model to a JSON response
+func ToSeriesResponse(s *model.Series) *SeriesResponse {
+	posts := make([]SeriesPostResponse, len(s.Posts))
+	for i := range s.Posts {
+		posts[i] = *ToSeriesPostResponse(&s.Posts[i])
+	}
+	return &SeriesResponse{
+		ID:          s.ID,
+		Slug:        s.Slug,
+		Title:       s.Title,
+		Description: s.Description,
+		Posts:       posts,
+		UpdatedAt:   s.UpdatedAt,
+		CreatedAt:   s.CreatedAt,
+	}
+}
+
+// ToSeriesResponses converts multiple Series models to JSON responses
+func ToSeriesResponses(list []model.Series) []SeriesResponse {
+	responses := make([]SeriesResponse, len(list))
+	for i, s := range list {
+		responses[i] = *ToSeriesResponse(&s)
+	}
+	return responses
+}
+
+// RelatedPostResponse represents a recommended post in JSON format
+type RelatedPostResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Slug        string     `json:"slug"`
+	Title       string     `json:"title"`
+	Summary     string     `json:"summary"`
+	Tags        []string   `json:"tags"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	Score       float64    `json:"score"`
+}
+
+// ToRelatedPostResponses converts related posts to JSON responses
+func ToRelatedPostResponses(related []model.RelatedPost) []RelatedPostResponse {
+	responses := make([]RelatedPostResponse, len(related))
+	for i, r := range related {
+		tags := r.Post.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		responses[i] = RelatedPostResponse{
+			ID:          r.Post.ID,
+			Slug:        r.Post.Slug,
+			Title:       r.Post.Title,
+			Summary:     r.Post.Summary,
+			Tags:        tags,
+			PublishedAt: r.Post.PublishedAt,
+			Score:       r.Score,
+		}
+	}
+	return responses
+}
+
+// PostRedirectResponse points a request for a post's old slug at its current one
+type PostRedirectResponse struct {
+	Slug     string `json:"slug"`
+	Location string `json:"location"`
+}
+
+// PreviewTokenResponse represents a draft preview link in JSON format
+type PreviewTokenResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	PostID     uuid.UUID  `json:"post_id"`
+	Token      string     `json:"token"`
+	PreviewURL string     `json:"preview_url"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ToPreviewTokenResponse converts a PreviewToken model and its signed token to a JSON response
+func ToPreviewTokenResponse(p *model.PreviewToken, token string, previewURL string) *PreviewTokenResponse {
+	return &PreviewTokenResponse{
+		ID:         p.ID,
+		PostID:     p.PostID,
+		Token:      token,
+		PreviewURL: previewURL,
+		ExpiresAt:  p.ExpiresAt,
+		RevokedAt:  p.RevokedAt,
+		CreatedAt:  p.CreatedAt,
+	}
+}
+
+// MediaVariantResponse represents a resized copy of an image in JSON format
+type MediaVariantResponse struct {
+	Name        string `json:"name"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+}
+
+// MediaReferenceResponse represents a post, post translation or page referencing a media asset in JSON format
+type MediaReferenceResponse struct {
+	Type   string    `json:"type"` // post, translation or page
+	ID     uuid.UUID `json:"id"`   // The post's ID for posts and translations, or the page's ID
+	Slug   string    `json:"slug"` // The post or translation slug, or the page path
+	Title  string    `json:"title"`
+	Locale string    `json:"locale,omitempty"`
+}
+
+// ToMediaReferenceResponses converts media references to JSON responses
+func ToMediaReferenceResponses(references []model.MediaReference) []MediaReferenceResponse {
+	refs := make([]MediaReferenceResponse, 0, len(references))
+	for _, r := range references {
+		refs = append(refs, MediaReferenceResponse{Type: string(r.Kind), ID: r.ID, Slug: r.Slug, Title: r.Title, Locale: r.Locale})
+	}
+	return refs
+}
+
+// MediaAssetResponse represents a media library asset in JSON format
+type MediaAssetResponse struct {
+	ID          uuid.UUID                `json:"id"`
+	SHA256      string                   `json:"sha256"`
+	Filename    string                   `json:"filename"`
+	ContentType string                   `json:"content_type"`
+	Size        int64                    `json:"size"`
+	Width       int                      `json:"width,omitempty"`
+	Height      int                      `json:"height,omitempty"`
+	URL         string                   `json:"url"`
+	Variants    []MediaVariantResponse   `json:"variants"`
+	References  []MediaReferenceResponse `json:"references"`
+	CreatedAt   time.Time                `json:"created_at"`
+}
+
+// ToMediaAssetResponse converts a MediaAsset model and the content referencing it to a JSON response
+// urlFor maps a storage key to the URL the file is served from
+func ToMediaAssetResponse(a *model.MediaAsset, references []model.MediaReference, urlFor func(string) string) *MediaAssetResponse {
+	variants := make([]MediaVariantResponse, 0, len(a.Variants))
+	for _, v := range a.Variants {
+		variants = append(variants, MediaVariantResponse{
+			Name:        v.Name,
+			Width:       v.Width,
+			Height:      v.Height,
+			ContentType: v.ContentType,
+			Size:        v.Size,
+			URL:         urlFor(v.StorageKey),
+		})
+	}
+
+	return &MediaAssetResponse{
+		ID:          a.ID,
+		SHA256:      a.SHA256,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Width:       a.Width,
+		Height:      a.Height,
+		URL:         urlFor(a.StorageKey),
+		Variants:    variants,
+		References:  ToMediaReferenceResponses(references),
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// ToMediaAssetResponses converts a slice of MediaAsset models to JSON responses, with each asset's references
+func ToMediaAssetResponses(assets []model.MediaAsset, references map[uuid.UUID][]model.MediaReference, urlFor func(string) string) []MediaAssetResponse {
+	responses := make([]MediaAssetResponse, len(assets))
+	for i, a := range assets {
+		responses[i] = *ToMediaAssetResponse(&a, references[a.ID], urlFor)
+	}
+	return responses
+}
+
 // GuestbookEntryResponse represents a guestbook entry in JSON format
 type GuestbookEntryResponse struct {
 	ID           uuid.UUID `json:"id"`
@@ -181,3 +579,330 @@ type HealthResponse struct {
 	Status string `json:"status"`
 	Time   time.Time `json:"time"`
 }
+
+// WebmentionResponse represents a received webmention in JSON format
+type WebmentionResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Source      string     `json:"source"`
+	Target      string     `json:"target"`
+	Status      string     `json:"status"`
+	IsApproved  bool       `json:"is_approved"`
+	AuthorName  string     `json:"author_name,omitempty"`
+	AuthorURL   string     `json:"author_url,omitempty"`
+	AuthorPhoto string     `json:"author_photo,omitempty"`
+	Content     string     `json:"content,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ToWebmentionResponse converts a Webmention model to a JSON response
+func ToWebmentionResponse(m *model.Webmention) *WebmentionResponse {
+	return &WebmentionResponse{
+		ID:          m.ID,
+		Source:      m.Source,
+		Target:      m.Target,
+		Status:      string(m.Status),
+		IsApproved:  m.IsApproved,
+		AuthorName:  m.AuthorName,
+		AuthorURL:   m.AuthorURL,
+		AuthorPhoto: m.AuthorPhoto,
+		Content:     m.Content,
+		LastError:   m.LastError,
+		VerifiedAt:  m.VerifiedAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+// ToWebmentionResponses converts multiple Webmention models to JSON responses
+func ToWebmentionResponses(mentions []model.Webmention) []WebmentionResponse {
+	responses := make([]WebmentionResponse, len(mentions))
+	for i, m := range mentions {
+		responses[i] = *ToWebmentionResponse(&m)
+	}
+	return responses
+}
+
+// ResumeSectionResponse represents a resume section in JSON format
+type ResumeSectionResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Kind         string    `json:"kind"`
+	Title        string    `json:"title"`
+	Organization string    `json:"organization,omitempty"`
+	Location     string    `json:"location,omitempty"`
+	StartDate    string    `json:"start_date,omitempty"`
+	EndDate      string    `json:"end_date,omitempty"`
+	Summary      string    `json:"summary,omitempty"`
+	Highlights   []string  `json:"highlights"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ToResumeSectionResponse converts a ResumeSection model to a JSON response
+func ToResumeSectionResponse(s *model.ResumeSection) *ResumeSectionResponse {
+	highlights := s.Highlights
+	if highlights == nil {
+		highlights = []string{}
+	}
+	return &ResumeSectionResponse{
+		ID:           s.ID,
+		Kind:         string(s.Kind),
+		Title:        s.Title,
+		Organization: s.Organization,
+		Location:     s.Location,
+		StartDate:    s.StartDate,
+		EndDate:      s.EndDate,
+		Summary:      s.Summary,
+		Highlights:   highlights,
+		UpdatedAt:    s.UpdatedAt,
+		CreatedAt:    s.CreatedAt,
+	}
+}
+
+// ToResumeSectionResponses converts multiple ResumeSection models to JSON responses
+func ToResumeSectionResponses(sections []model.ResumeSection) []ResumeSectionResponse {
+	responses := make([]ResumeSectionResponse, len(sections))
+	for i, s := range sections {
+		responses[i] = *ToResumeSectionResponse(&s)
+	}
+	return responses
+}
+
+// ResumeVariantResponse represents a resume variant and its ordered sections in JSON format
+type ResumeVariantResponse struct {
+	ID        uuid.UUID               `json:"id"`
+	Slug      string                  `json:"slug"`
+	Name      string                  `json:"name"`
+	Headline  string                  `json:"headline,omitempty"`
+	Summary   string                  `json:"summary,omitempty"`
+	Sections  []ResumeSectionResponse `json:"sections"`
+	PDFURL    string                  `json:"pdf_url"`
+	UpdatedAt time.Time               `json:"updated_at"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+// ToResumeVariantResponse converts a ResumeVariant model to a JSON response
+func ToResumeVariantResponse(v *model.ResumeVariant) *ResumeVariantResponse {
+	return &ResumeVariantResponse{
+		ID:        v.ID,
+		Slug:      v.Slug,
+		Name:      v.Name,
+		Headline:  v.Headline,
+		Summary:   v.Summary,
+		Sections:  ToResumeSectionResponses(v.Sections),
+		PDFURL:    "/api/resumes/" + v.Slug + "/pdf",
+		UpdatedAt: v.UpdatedAt,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+// ToResumeVariantResponses converts multiple ResumeVariant models to JSON responses
+func ToResumeVariantResponses(variants []model.ResumeVariant) []ResumeVariantResponse {
+	responses := make([]ResumeVariantResponse, len(variants))
+	for i, v := range variants {
+		responses[i] = *ToResumeVariantResponse(&v)
+	}
+	return responses
+}
+
+// ProjectLinkResponse represents a project link in JSON format
+type ProjectLinkResponse struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// ProjectResponse represents a project case study in JSON format
+type ProjectResponse struct {
+	ID          uuid.UUID             `json:"id"`
+	Slug        string                `json:"slug"`
+	Title       string                `json:"title"`
+	Summary     string                `json:"summary"`
+	Problem     string                `json:"problem,omitempty"`
+	Role        string                `json:"role,omitempty"`
+	Stack       []string              `json:"stack"`
+	Outcomes    []string              `json:"outcomes"`
+	Links       []ProjectLinkResponse `json:"links"`
+	Tags        []string              `json:"tags"`
+	Status      string                `json:"status"`
+	IsFeatured  bool                  `json:"is_featured"`
+	Position    int                   `json:"position"`
+	PublishedAt *time.Time            `json:"published_at,omitempty"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+	CreatedAt   time.Time             `json:"created_at"`
+}
+
+// ToProjectResponse converts a Project model to a JSON response
+func ToProjectResponse(p *model.Project) *ProjectResponse {
+	links := make([]ProjectLinkResponse, len(p.Links))
+	for i, link := range p.Links {
+		links[i] = ProjectLinkResponse{Label: link.Label, URL: link.URL}
+	}
+	return &ProjectResponse{
+		ID:          p.ID,
+		Slug:        p.Slug,
+		Title:       p.Title,
+		Summary:     p.Summary,
+		Problem:     p.Problem,
+		Role:        p.Role,
+		Stack:       nonNilStrings(p.Stack),
+		Outcomes:    nonNilStrings(p.Outcomes),
+		Links:       links,
+		Tags:        nonNilStrings(p.Tags),
+		Status:      string(p.Status),
+		IsFeatured:  p.Featured,
+		Position:    p.Position,
+		PublishedAt: p.PublishedAt,
+		UpdatedAt:   p.UpdatedAt,
+		CreatedAt:   p.CreatedAt,
+	}
+}
+
+// ToProjectResponses converts multiple Project models to JSON responses
+func ToProjectResponses(projects []model.Project) []ProjectResponse {
+	responses := make([]ProjectResponse, len(projects))
+	for i, p := range projects {
+		responses[i] = *ToProjectResponse(&p)
+	}
+	return responses
+}
+
+// nonNilStrings returns s, or an empty slice so JSON shows [] rather than null
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// TalkPostResponse represents the post accompanying a talk in JSON format
+type TalkPostResponse struct {
+	ID    uuid.UUID `json:"id"`
+	Slug  string    `json:"slug"`
+	Title string    `json:"title"`
+}
+
+// TalkResponse represents a speaking or media appearance in JSON format
+type TalkResponse struct {
+	ID           uuid.UUID         `json:"id"`
+	Title        string            `json:"title"`
+	Type         string            `json:"type"`
+	Event        string            `json:"event"`
+	EventURL     string            `json:"event_url,omitempty"`
+	Location     string            `json:"location,omitempty"`
+	Abstract     string            `json:"abstract,omitempty"`
+	StartsAt     time.Time         `json:"starts_at"`
+	EndsAt       *time.Time        `json:"ends_at,omitempty"`
+	SlidesURL    string            `json:"slides_url,omitempty"`
+	VideoURL     string            `json:"video_url,omitempty"`
+	RecordingURL string            `json:"recording_url,omitempty"`
+	Post         *TalkPostResponse `json:"post,omitempty"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// TalkListResponse splits appearances into those still to come and those already over
+type TalkListResponse struct {
+	Upcoming []TalkResponse `json:"upcoming"` // Soonest first
+	Past     []TalkResponse `json:"past"`     // Most recent first
+}
+
+// ToTalkResponse converts a Talk model to a JSON response
+func ToTalkResponse(t *model.Talk) *TalkResponse {
+	var post *TalkPostResponse
+	if t.Post != nil {
+		post = &TalkPostResponse{ID: t.Post.ID, Slug: t.Post.Slug, Title: t.Post.Title}
+	}
+	return &TalkResponse{
+		ID:           t.ID,
+		Title:        t.Title,
+		Type:         string(t.Type),
+		Event:        t.Event,
+		EventURL:     t.EventURL,
+		Location:     t.Location,
+		Abstract:     t.Abstract,
+		StartsAt:     t.StartsAt,
+		EndsAt:       t.EndsAt,
+		SlidesURL:    t.SlidesURL,
+		VideoURL:     t.VideoURL,
+		RecordingURL: t.RecordingURL,
+		Post:         post,
+		UpdatedAt:    t.UpdatedAt,
+		CreatedAt:    t.CreatedAt,
+	}
+}
+
+// ToTalkResponses converts multiple Talk models to JSON responses
+func ToTalkResponses(talks []model.Talk) []TalkResponse {
+	responses := make([]TalkResponse, len(talks))
+	for i, t := range talks {
+		responses[i] = *ToTalkResponse(&t)
+	}
+	return responses
+}
+
+// SitePageLinkResponse represents a link to a CMS page in JSON format
+type SitePageLinkResponse struct {
+	Path  string `json:"path"`
+	Title string `json:"title"`
+}
+
+// SitePageResponse represents a CMS page in JSON format
+type SitePageResponse struct {
+	ID             uuid.UUID              `json:"id"`
+	Path           string                 `json:"path"`
+	ParentPath     string                 `json:"parent_path,omitempty"`
+	Title          string                 `json:"title"`
+	Body           string                 `json:"body"`
+	Status         string                 `json:"status"`
+	SEOTitle       string                 `json:"seo_title,omitempty"`
+	SEODescription string                 `json:"seo_description,omitempty"`
+	CanonicalURL   string                 `json:"canonical_url,omitempty"`
+	NoIndex        bool                   `json:"noindex"`
+	WordCount      int                    `json:"word_count"`
+	ReadingTime    int                    `json:"reading_time_minutes"`
+	TOC            []TOCEntryResponse     `json:"toc"`
+	Children       []SitePageLinkResponse `json:"children,omitempty"` // Pages directly beneath this one, for sub-navigation
+	PublishedAt    *time.Time             `json:"published_at,omitempty"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+// ToSitePageResponse converts a SitePage model to a JSON response
+func ToSitePageResponse(p *model.SitePage) *SitePageResponse {
+	return &SitePageResponse{
+		ID:             p.ID,
+		Path:           p.Path,
+		ParentPath:     p.ParentPath(),
+		Title:          p.Title,
+		Body:           p.Body,
+		Status:         string(p.Status),
+		SEOTitle:       p.SEOTitle,
+		SEODescription: p.SEODescription,
+		CanonicalURL:   p.CanonicalURL,
+		NoIndex:        p.NoIndex,
+		WordCount:      p.WordCount,
+		ReadingTime:    p.ReadingTime,
+		TOC:            ToTOCEntryResponses(p.TOC),
+		PublishedAt:    p.PublishedAt,
+		UpdatedAt:      p.UpdatedAt,
+		CreatedAt:      p.CreatedAt,
+	}
+}
+
+// ToSitePageResponses converts multiple SitePage models to JSON responses
+func ToSitePageResponses(pages []model.SitePage) []SitePageResponse {
+	responses := make([]SitePageResponse, len(pages))
+	for i, p := range pages {
+		responses[i] = *ToSitePageResponse(&p)
+	}
+	return responses
+}
+
+// ToSitePageLinkResponses converts pages to links, or an empty slice when there are none
+func ToSitePageLinkResponses(pages []model.SitePage) []SitePageLinkResponse {
+	responses := make([]SitePageLinkResponse, len(pages))
+	for i, p := range pages {
+		responses[i] = SitePageLinkResponse{Path: p.Path, Title: p.Title}
+	}
+	return responses
+}
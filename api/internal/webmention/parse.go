@@ -0,0 +1,198 @@
+package webmention
+
+import (
+	"bytes"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxContentRunes bounds the excerpt kept from a mention's content
+const maxContentRunes = 500
+
+// Entry is what a source's microformats2 h-entry says about the mention
+// Fields are empty when the source has no h-entry or leaves them out
+type Entry struct {
+	AuthorName  string
+	AuthorURL   string
+	AuthorPhoto string
+	Content     string
+}
+
+// parseHTML checks an HTML source for a link to target and extracts its first h-entry
+func parseHTML(body []byte, base *url.URL, target string) (*Entry, error) {
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, ErrUnsupportedContent
+	}
+
+	if !linksTo(doc, base, target) {
+		return nil, ErrNoLink
+	}
+
+	entry := &Entry{}
+	hEntry := find(doc, func(n *html.Node) bool { return hasClass(n, "h-entry") })
+	if hEntry == nil {
+		return entry, nil
+	}
+
+	content := find(hEntry, func(n *html.Node) bool { return hasClass(n, "e-content") })
+	if content == nil {
+		content = find(hEntry, func(n *html.Node) bool { return hasClass(n, "p-summary") })
+	}
+	if content == nil {
+		content = find(hEntry, func(n *html.Node) bool { return hasClass(n, "p-name") })
+	}
+	if content != nil {
+		entry.Content = truncate(text(content), maxContentRunes)
+	}
+
+	if author := find(hEntry, func(n *html.Node) bool { return hasClass(n, "p-author") }); author != nil {
+		entry.AuthorName, entry.AuthorURL, entry.AuthorPhoto = parseAuthor(author, base)
+	}
+
+	return entry, nil
+}
+
+// parseAuthor reads an author given as an h-card, or as a plain link or text
+func parseAuthor(author *html.Node, base *url.URL) (name, link, photo string) {
+	if hasClass(author, "h-card") {
+		if n := find(author, func(n *html.Node) bool { return n != author && hasClass(n, "p-name") }); n != nil {
+			name = text(n)
+		}
+		if n := find(author, func(n *html.Node) bool { return hasClass(n, "u-url") }); n != nil {
+			link = resolve(base, attr(n, "href"))
+		}
+		if n := find(author, func(n *html.Node) bool { return hasClass(n, "u-photo") }); n != nil {
+			photo = resolve(base, attr(n, "src"))
+		}
+	}
+
+	if name == "" {
+		name = text(author)
+	}
+	if link == "" && author.DataAtom == atom.A {
+		link = resolve(base, attr(author, "href"))
+	}
+	return truncate(name, 255), link, photo
+}
+
+// linksTo reports whether any link or embed in the document points at target
+func linksTo(doc *html.Node, base *url.URL, target string) bool {
+	want, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	return find(doc, func(n *html.Node) bool {
+		var ref string
+		switch n.DataAtom {
+		case atom.A, atom.Link, atom.Area:
+			ref = attr(n, "href")
+		case atom.Img, atom.Audio, atom.Video, atom.Source, atom.Iframe:
+			ref = attr(n, "src")
+		default:
+			return false
+		}
+		if ref == "" {
+			return false
+		}
+		got, err := base.Parse(ref)
+		return err == nil && sameURL(got, want)
+	}) != nil
+}
+
+// sameURL compares URLs ignoring fragments, host case and a trailing slash
+func sameURL(a, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) &&
+		strings.EqualFold(a.Host, b.Host) &&
+		strings.TrimSuffix(a.EscapedPath(), "/") == strings.TrimSuffix(b.EscapedPath(), "/") &&
+		a.RawQuery == b.RawQuery
+}
+
+// find returns the first node in document order, starting at n, for which match is true
+func find(n *html.Node, match func(*html.Node) bool) *html.Node {
+	if n.Type == html.ElementNode && match(n) {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := find(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// hasClass reports whether an element's class attribute contains name
+func hasClass(n *html.Node, name string) bool {
+	return slices.Contains(strings.Fields(attr(n, "class")), name)
+}
+
+// attr returns an element's attribute value, or "" when it is not set
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// blockElements are the elements text extraction puts a space around
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Br: true, atom.Li: true, atom.Blockquote: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Td: true, atom.Th: true, atom.Tr: true, atom.Pre: true,
+}
+
+// text returns an element's visible text with whitespace collapsed
+func text(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			b.WriteString(n.Data)
+		case n.Type == html.ElementNode && (n.DataAtom == atom.Script || n.DataAtom == atom.Style || n.DataAtom == atom.Template):
+			return
+		}
+
+		// Block elements separate words even when the markup has no whitespace between them
+		block := n.Type == html.ElementNode && blockElements[n.DataAtom]
+		if block {
+			b.WriteByte(' ')
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if block {
+			b.WriteByte(' ')
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// resolve makes ref absolute against base, dropping anything that is not an http(s) URL
+func resolve(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := base.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return u.String()
+}
+
+// truncate shortens s to at most n runes, ending it in an ellipsis when cut
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:n-1])) + "…"
+}
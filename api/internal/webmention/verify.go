@@ -0,0 +1,155 @@
+// Package webmention verifies received W3C Webmentions by fetching their source pages
+package webmention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// maxSourceBytes bounds how much of a source page is read; links to us are expected near the top of a post anyway
+const maxSourceBytes = 1 << 20
+
+// userAgent identifies our fetches to the sites that mention us
+const userAgent = "sochoa.dev-webmention/1.0 (+https://sochoa.dev)"
+
+// HTTPClient is the part of *http.Client the verifier uses, so tests can serve sources without a network
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Errors that mean the mention can never verify as sent, so it is not retried
+var (
+	ErrSourceGone         = errors.New("source page no longer exists")
+	ErrNoLink             = errors.New("source does not link to target")
+	ErrUnsupportedContent = errors.New("source is not HTML or plain text")
+	ErrSourceRejected     = errors.New("source request was rejected")
+)
+
+// IsPermanent reports whether a verification error is final rather than worth retrying
+func IsPermanent(err error) bool {
+	return errors.Is(err, ErrSourceGone) || errors.Is(err, ErrNoLink) ||
+		errors.Is(err, ErrUnsupportedContent) || errors.Is(err, ErrSourceRejected)
+}
+
+// Verifier fetches mention sources and checks that they link to their targets
+type Verifier struct {
+	client HTTPClient
+}
+
+// NewVerifier creates a verifier that fetches sources with client
+func NewVerifier(client HTTPClient) *Verifier {
+	return &Verifier{client: client}
+}
+
+// Verify fetches source and confirms it links to target, returning the source's h-entry details
+func (v *Verifier) Verify(ctx context.Context, source, target string) (*Entry, error) {
+	sourceURL, err := url.Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid source URL", ErrSourceRejected)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSourceRejected, err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html, text/plain;q=0.5")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		if errors.Is(err, errBlockedAddress) {
+			return nil, fmt.Errorf("%w: %v", ErrSourceRejected, err)
+		}
+		return nil, fmt.Errorf("failed to fetch source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return nil, ErrSourceGone
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, fmt.Errorf("source returned status %d", resp.StatusCode)
+	case resp.StatusCode >= 400:
+		return nil, fmt.Errorf("%w: status %d", ErrSourceRejected, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSourceBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source: %w", err)
+	}
+
+	// Redirects move the base that relative links resolve against
+	if resp.Request != nil && resp.Request.URL != nil {
+		sourceURL = resp.Request.URL
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/html", "application/xhtml+xml", "":
+		return parseHTML(body, sourceURL, target)
+	case "text/plain":
+		if !strings.Contains(string(body), target) {
+			return nil, ErrNoLink
+		}
+		return &Entry{}, nil
+	default:
+		return nil, ErrUnsupportedContent
+	}
+}
+
+// errBlockedAddress is returned when a source resolves to an address the verifier must not reach
+var errBlockedAddress = errors.New("source resolves to a private or local address")
+
+// NewClient returns an HTTP client for fetching sources that refuses to connect to loopback, private,
+// link-local and other internal addresses, so mentions cannot make the API probe its own network.
+// The check runs on the resolved address of every connection, including redirects.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("%w: %s", errBlockedAddress, host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return errors.New("too many redirects")
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("%w: redirect to %s", ErrSourceRejected, req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// isPublicIP reports whether ip is a globally routable unicast address
+func isPublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast()
+}
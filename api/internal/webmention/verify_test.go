@@ -0,0 +1,153 @@
+package webmention
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testTarget = "https://sochoa.dev/blog/hello-world"
+
+// fakeClient serves canned responses keyed by URL
+type fakeClient map[string]*http.Response
+
+func (f fakeClient) Do(req *http.Request) (*http.Response, error) {
+	resp, ok := f[req.URL.String()]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	resp.Request = req
+	return resp, nil
+}
+
+func page(status int, contentType, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestVerify(t *testing.T) {
+	hEntry := `<html><body>
+		<article class="h-entry">
+			<a class="p-author h-card" href="/about"><img class="u-photo" src="/me.jpg"> <span class="p-name">Ada Lovelace</span></a>
+			<div class="e-content"><p>Great   post! I wrote about it
+				<a href="https://SOCHOA.dev/blog/hello-world/#comments">here</a>.</p><script>track()</script></div>
+		</article>
+	</body></html>`
+
+	client := fakeClient{
+		"https://example.com/reply":     page(http.StatusOK, "text/html; charset=utf-8", hEntry),
+		"https://example.com/plain":     page(http.StatusOK, "text/plain", "see "+testTarget),
+		"https://example.com/relative":  page(http.StatusOK, "text/html", `<a href="//sochoa.dev/blog/hello-world">x</a>`),
+		"https://example.com/unrelated": page(http.StatusOK, "text/html", `<a href="https://sochoa.dev/blog/other">x</a>`),
+		"https://example.com/deleted":   page(http.StatusGone, "text/html", ""),
+		"https://example.com/forbidden": page(http.StatusForbidden, "text/html", ""),
+		"https://example.com/busy":      page(http.StatusServiceUnavailable, "text/html", ""),
+		"https://example.com/image":     page(http.StatusOK, "image/png", "\x89PNG"),
+	}
+	verifier := NewVerifier(client)
+
+	entry, err := verifier.Verify(context.Background(), "https://example.com/reply", testTarget)
+	if err != nil {
+		t.Fatalf("expected the reply to verify, got %v", err)
+	}
+	want := Entry{
+		AuthorName:  "Ada Lovelace",
+		AuthorURL:   "https://example.com/about",
+		AuthorPhoto: "https://example.com/me.jpg",
+		Content:     "Great post! I wrote about it here.",
+	}
+	if *entry != want {
+		t.Errorf("expected %+v, got %+v", want, *entry)
+	}
+
+	for _, source := range []string{"https://example.com/plain", "https://example.com/relative"} {
+		if _, err := verifier.Verify(context.Background(), source, testTarget); err != nil {
+			t.Errorf("%s: expected to verify, got %v", source, err)
+		}
+	}
+
+	tests := []struct {
+		source    string
+		want      error
+		permanent bool
+	}{
+		{"https://example.com/unrelated", ErrNoLink, true},
+		{"https://example.com/deleted", ErrSourceGone, true},
+		{"https://example.com/forbidden", ErrSourceRejected, true},
+		{"https://example.com/image", ErrUnsupportedContent, true},
+		{"https://example.com/busy", nil, false},
+		{"https://example.com/unreachable", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			_, err := verifier.Verify(context.Background(), tt.source, testTarget)
+			if err == nil {
+				t.Fatal("expected verification to fail")
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if IsPermanent(err) != tt.permanent {
+				t.Errorf("expected permanent %v for %v", tt.permanent, err)
+			}
+		})
+	}
+}
+
+func TestEntryWithoutMicroformats(t *testing.T) {
+	client := fakeClient{"https://example.com/bare": page(http.StatusOK, "text/html", `<p>Linking <a href="`+testTarget+`">this</a></p>`)}
+
+	entry, err := NewVerifier(client).Verify(context.Background(), "https://example.com/bare", testTarget)
+	if err != nil {
+		t.Fatalf("expected a plain link to verify, got %v", err)
+	}
+	if *entry != (Entry{}) {
+		t.Errorf("expected an empty entry without an h-entry, got %+v", *entry)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("short", 10); got != "short" {
+		t.Errorf("expected short text unchanged, got %q", got)
+	}
+	if got := truncate("héllo wörld", 6); got != "héllo…" {
+		t.Errorf("expected rune-aware truncation, got %q", got)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "10.0.0.1", "192.168.1.1", "172.16.0.1", "169.254.169.254", "::1", "fd00::1", "fe80::1", "0.0.0.0"} {
+		if isPublicIP(net.ParseIP(addr)) {
+			t.Errorf("expected %s to be blocked", addr)
+		}
+	}
+	for _, addr := range []string{"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"} {
+		if !isPublicIP(net.ParseIP(addr)) {
+			t.Errorf("expected %s to be allowed", addr)
+		}
+	}
+}
+
+func TestNewClientRefusesLocalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<a href="` + testTarget + `">x</a>`))
+	}))
+	defer server.Close()
+
+	_, err := NewVerifier(NewClient(time.Second)).Verify(context.Background(), server.URL, testTarget)
+	if !errors.Is(err, ErrSourceRejected) {
+		t.Fatalf("expected a loopback source to be rejected, got %v", err)
+	}
+	if !IsPermanent(err) {
+		t.Error("expected a blocked address to fail permanently")
+	}
+}
@@ -0,0 +1,73 @@
+package webmention
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apierrors "github.com/sochoa/sochoa.dev/api/internal/errors"
+	"github.com/sochoa/sochoa.dev/api/internal/model"
+)
+
+// Worker verification limits
+const (
+	batchSize   = 20 // Mentions verified per run, so a flood of mentions cannot stall the scheduler
+	maxAttempts = 5  // Transient failures retried before a mention is marked failed
+)
+
+// Worker verifies queued mentions in the background
+type Worker struct {
+	repo     *model.WebmentionRepository
+	verifier *Verifier
+}
+
+// NewWorker creates a worker verifying the repository's pending mentions with verifier
+func NewWorker(repo *model.WebmentionRepository, verifier *Verifier) *Worker {
+	return &Worker{repo: repo, verifier: verifier}
+}
+
+// Run verifies one batch of pending mentions, returning how many were verified and how many failed for good
+// A mention whose source cannot be reached stays pending and is retried on later runs
+func (w *Worker) Run(ctx context.Context, now time.Time) (verified, failed int64, err error) {
+	mentions, err := w.repo.ListDue(ctx, batchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for i := range mentions {
+		mention := &mentions[i]
+
+		entry, verifyErr := w.verifier.Verify(ctx, mention.Source, mention.Target)
+		if ctx.Err() != nil {
+			return verified, failed, ctx.Err()
+		}
+
+		if verifyErr != nil {
+			final := IsPermanent(verifyErr) || mention.Attempts+1 >= maxAttempts
+			if err := w.repo.MarkAttemptFailed(ctx, mention.ID, verifyErr.Error(), final, now); err != nil && !isGone(err) {
+				return verified, failed, err
+			}
+			if final {
+				failed++
+			}
+			continue
+		}
+
+		mention.AuthorName = entry.AuthorName
+		mention.AuthorURL = entry.AuthorURL
+		mention.AuthorPhoto = entry.AuthorPhoto
+		mention.Content = entry.Content
+		if err := w.repo.MarkVerified(ctx, mention, now); err != nil && !isGone(err) {
+			return verified, failed, err
+		}
+		verified++
+	}
+
+	return verified, failed, nil
+}
+
+// isGone reports whether a mention was deleted or already settled while it was being verified
+func isGone(err error) bool {
+	var notFound apierrors.NotFoundError
+	return errors.As(err, &notFound)
+}
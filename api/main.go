@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -17,11 +19,17 @@ import (
 	"github.com/sochoa/sochoa.dev/api/internal/auth"
 	"github.com/sochoa/sochoa.dev/api/internal/config"
 	"github.com/sochoa/sochoa.dev/api/internal/db"
+	appevents "github.com/sochoa/sochoa.dev/api/internal/events"
 	"github.com/sochoa/sochoa.dev/api/internal/handler"
 	"github.com/sochoa/sochoa.dev/api/internal/logger"
+	"github.com/sochoa/sochoa.dev/api/internal/mail"
+	"github.com/sochoa/sochoa.dev/api/internal/media"
 	lambdaadapter "github.com/sochoa/sochoa.dev/api/internal/lambda"
 	"github.com/sochoa/sochoa.dev/api/internal/middleware"
 	"github.com/sochoa/sochoa.dev/api/internal/model"
+	"github.com/sochoa/sochoa.dev/api/internal/scheduler"
+	"github.com/sochoa/sochoa.dev/api/internal/storage"
+	"github.com/sochoa/sochoa.dev/api/internal/webmention"
 	_ "github.com/sochoa/sochoa.dev/api/docs"
 )
 
@@ -36,7 +44,8 @@ var (
 	}
 
 	// Global state for Lambda handler (initialized once at cold start)
-	lambdaAdapter *lambdaadapter.HandlerAdapter
+	lambdaAdapter   *lambdaadapter.HandlerAdapter
+	lambdaScheduler *scheduler.Scheduler
 )
 
 func init() {
@@ -82,10 +91,38 @@ func initializeLambdaAdapter() error {
 	guestbookRepo := model.NewGuestbookRepository(database)
 	contactRepo := model.NewContactRepository(database)
 	statsRepo := model.NewStatsRepository(database)
+	tagRepo := model.NewTagRepository(database)
+	seriesRepo := model.NewSeriesRepository(database)
+	relatedRepo := model.NewRelatedPostRepository(database)
+	previewRepo := model.NewPreviewTokenRepository(database)
+	reactionRepo := model.NewReactionRepository(database)
+	webmentionRepo := model.NewWebmentionRepository(database)
+	resumeRepo := model.NewResumeRepository(database)
+	projectRepo := model.NewProjectRepository(database)
+	talkRepo := model.NewTalkRepository(database)
+	newsletterRepo := model.NewNewsletterRepository(database)
+	sitePageRepo := model.NewSitePageRepository(database)
 
 	// Initialize token verifier
 	tokenVerifier := auth.NewCognitoVerifier(cfg.CognitoUserPoolID, cfg.AWSRegion)
 
+	// Initialize preview link signer
+	previewSigner, err := newPreviewSigner(cfg, log)
+	if err != nil {
+		log.Error("failed to initialize preview signer", slog.String("error", err.Error()))
+		database.Close()
+		return err
+	}
+
+	// Initialize media library; the rest of the API keeps serving without it
+	mediaLibrary, err := newMediaLibrary(cfg, database)
+	if err != nil {
+		log.Error("failed to initialize media library, media routes will be unavailable", slog.String("error", err.Error()))
+	}
+
+	// Post lifecycle events come from both the API and the scheduler
+	eventBus := newEventBus(log, relatedRepo)
+
 	// Create router and register routes
 	apiRouter := handler.NewRouter(
 		log,
@@ -94,17 +131,57 @@ func initializeLambdaAdapter() error {
 		guestbookRepo,
 		contactRepo,
 		statsRepo,
+		tagRepo,
+		seriesRepo,
+		relatedRepo,
+		model.RelatedOptions{Limit: cfg.RelatedPostsLimit, RecencyHalfLife: cfg.RelatedPostsHalfLife},
+		previewRepo,
+		previewSigner,
+		mediaLibrary,
+		reactionRepo,
+		webmentionRepo,
+		resumeRepo,
+		projectRepo,
+		talkRepo,
+		newsletterRepo,
+		sitePageRepo,
+		newMailer(cfg, log),
+		cfg.MailFrom,
+		cfg.SiteURL,
+		cfg.RequireIfMatch,
+		cachePolicies(cfg),
+		eventBus,
 	)
 	ginEngine := apiRouter.Register()
 
 	// Create Lambda adapter
 	lambdaAdapter = lambdaadapter.NewHandlerAdapter(ginEngine, log)
 
+	// Background jobs run from EventBridge scheduled events instead of a ticker
+	lambdaScheduler = scheduler.New(log, cfg.SchedulerInterval,
+		scheduler.PublishScheduledPosts(log, postRepo, eventBus),
+		scheduler.ComputeReadingMetadata(log, postRepo),
+		scheduler.RefreshRelatedPosts(log, relatedRepo),
+		scheduler.PurgeReactionSalts(log, reactionRepo),
+		scheduler.PurgeTrashedPosts(log, postRepo, time.Duration(cfg.TrashRetentionDays)*24*time.Hour),
+		scheduler.PurgeUnconfirmedSubscribers(log, newsletterRepo),
+		scheduler.VerifyWebmentions(log, webmention.NewWorker(webmentionRepo, webmention.NewVerifier(webmention.NewClient(10*time.Second)))),
+	)
+
 	return nil
 }
 
-// handleLambdaRequest handles a single Lambda HTTP API request
-func handleLambdaRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+// handleLambdaEvent dispatches a Lambda invocation to the scheduler or the HTTP adapter
+func handleLambdaEvent(ctx context.Context, payload json.RawMessage) (interface{}, error) {
+	if lambdaadapter.IsScheduledEvent(payload) {
+		return nil, lambdaScheduler.RunOnce(ctx)
+	}
+
+	var request events.APIGatewayProxyRequest
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return nil, fmt.Errorf("failed to decode API Gateway request: %w", err)
+	}
+
 	return lambdaAdapter.Handle(ctx, request)
 }
 
@@ -149,10 +226,37 @@ func serve(_ *cobra.Command, _ []string) error {
 	guestbookRepo := model.NewGuestbookRepository(database)
 	contactRepo := model.NewContactRepository(database)
 	statsRepo := model.NewStatsRepository(database)
+	tagRepo := model.NewTagRepository(database)
+	seriesRepo := model.NewSeriesRepository(database)
+	relatedRepo := model.NewRelatedPostRepository(database)
+	previewRepo := model.NewPreviewTokenRepository(database)
+	reactionRepo := model.NewReactionRepository(database)
+	webmentionRepo := model.NewWebmentionRepository(database)
+	resumeRepo := model.NewResumeRepository(database)
+	projectRepo := model.NewProjectRepository(database)
+	talkRepo := model.NewTalkRepository(database)
+	newsletterRepo := model.NewNewsletterRepository(database)
+	sitePageRepo := model.NewSitePageRepository(database)
 
 	// Initialize token verifier
 	tokenVerifier := auth.NewCognitoVerifier(cfg.CognitoUserPoolID, cfg.AWSRegion)
 
+	// Initialize preview link signer
+	previewSigner, err := newPreviewSigner(cfg, log)
+	if err != nil {
+		log.Error("failed to initialize preview signer", slog.String("error", err.Error()))
+		return err
+	}
+
+	// Initialize media library; the rest of the API keeps serving without it
+	mediaLibrary, err := newMediaLibrary(cfg, database)
+	if err != nil {
+		log.Error("failed to initialize media library, media routes will be unavailable", slog.String("error", err.Error()))
+	}
+
+	// Post lifecycle events come from both the API and the scheduler
+	eventBus := newEventBus(log, relatedRepo)
+
 	// Create router and register routes
 	apiRouter := handler.NewRouter(
 		log,
@@ -161,6 +265,26 @@ func serve(_ *cobra.Command, _ []string) error {
 		guestbookRepo,
 		contactRepo,
 		statsRepo,
+		tagRepo,
+		seriesRepo,
+		relatedRepo,
+		model.RelatedOptions{Limit: cfg.RelatedPostsLimit, RecencyHalfLife: cfg.RelatedPostsHalfLife},
+		previewRepo,
+		previewSigner,
+		mediaLibrary,
+		reactionRepo,
+		webmentionRepo,
+		resumeRepo,
+		projectRepo,
+		talkRepo,
+		newsletterRepo,
+		sitePageRepo,
+		newMailer(cfg, log),
+		cfg.MailFrom,
+		cfg.SiteURL,
+		cfg.RequireIfMatch,
+		cachePolicies(cfg),
+		eventBus,
 	)
 	ginEngine := apiRouter.Register()
 
@@ -173,6 +297,20 @@ func serve(_ *cobra.Command, _ []string) error {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Start background jobs (scheduled publishing, etc.)
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	jobs := scheduler.New(log, cfg.SchedulerInterval,
+		scheduler.PublishScheduledPosts(log, postRepo, eventBus),
+		scheduler.ComputeReadingMetadata(log, postRepo),
+		scheduler.RefreshRelatedPosts(log, relatedRepo),
+		scheduler.PurgeReactionSalts(log, reactionRepo),
+		scheduler.PurgeTrashedPosts(log, postRepo, time.Duration(cfg.TrashRetentionDays)*24*time.Hour),
+		scheduler.PurgeUnconfirmedSubscribers(log, newsletterRepo),
+		scheduler.VerifyWebmentions(log, webmention.NewWorker(webmentionRepo, webmention.NewVerifier(webmention.NewClient(10*time.Second)))),
+	)
+	go jobs.Start(schedulerCtx)
+
 	// Start server in goroutine
 	go func() {
 		log.Info("starting server", slog.String("address", server.Addr))
@@ -188,6 +326,7 @@ func serve(_ *cobra.Command, _ []string) error {
 
 	// Graceful shutdown
 	log.Info("shutting down server")
+	stopScheduler()
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -200,6 +339,113 @@ func serve(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// newPreviewSigner creates the draft preview link signer from configuration
+// Without PREVIEW_TOKEN_SECRET, which only DEV_MODE allows, a random secret is used, so links only work until the next restart
+func newPreviewSigner(cfg *config.Config, log *slog.Logger) (*auth.PreviewSigner, error) {
+	if cfg.PreviewSecret != "" {
+		return auth.NewPreviewSigner([]byte(cfg.PreviewSecret)), nil
+	}
+
+	log.Warn("PREVIEW_TOKEN_SECRET not set, preview links will not survive restarts")
+	secret, err := auth.NewRandomPreviewSecret()
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewPreviewSigner(secret), nil
+}
+
+// newMediaLibrary creates the media library with the storage backend selected by MEDIA_STORAGE
+// S3 credentials come from the standard AWS_* variables, which Lambda sets for its execution role
+func newMediaLibrary(cfg *config.Config, database db.QueryExecutor) (*media.Library, error) {
+	var store storage.Storage
+	switch cfg.MediaStorage {
+	case "s3":
+		region := cfg.MediaS3Region
+		if region == "" {
+			region = cfg.AWSRegion
+		}
+		s3, err := storage.NewS3(storage.S3Config{
+			Endpoint:        cfg.MediaS3Endpoint,
+			Region:          region,
+			Bucket:          cfg.MediaS3Bucket,
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			PathStyle:       cfg.MediaS3PathStyle,
+		}, &http.Client{Timeout: 30 * time.Second})
+		if err != nil {
+			return nil, err
+		}
+		store = s3
+	default:
+		dir := cfg.MediaDir
+		if _, isLambda := os.LookupEnv("AWS_LAMBDA_FUNCTION_NAME"); dir == "" && isLambda {
+			// /tmp is the only writable path on Lambda; files there do not outlive the instance
+			dir = filepath.Join(os.TempDir(), "sochoa.dev", "media")
+		} else if dir == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get home directory: %w", err)
+			}
+			dir = filepath.Join(home, ".cache", "sochoa.dev", "media")
+		}
+		local, err := storage.NewLocal(dir)
+		if err != nil {
+			return nil, err
+		}
+		store = local
+	}
+
+	return media.NewLibrary(model.NewMediaRepository(database), store, media.Options{
+		MaxUploadSize: int64(cfg.MediaMaxUploadBytes),
+		BaseURL:       cfg.MediaBaseURL,
+	}), nil
+}
+
+// newMailer creates the SMTP mailer, or an in-memory mailer when SMTP_HOST is not set
+func newMailer(cfg *config.Config, log *slog.Logger) mail.Mailer {
+	if cfg.SMTPHost == "" {
+		log.Warn("SMTP_HOST not set, emails will not be delivered")
+		return mail.NewMemory()
+	}
+
+	smtp, err := mail.NewSMTP(mail.SMTPConfig{
+		Host:        cfg.SMTPHost,
+		Port:        cfg.SMTPPort,
+		Username:    cfg.SMTPUsername,
+		Password:    cfg.SMTPPassword,
+		ImplicitTLS: cfg.SMTPImplicitTLS,
+	})
+	if err != nil {
+		log.Error("failed to initialize SMTP mailer, emails will not be delivered", slog.String("error", err.Error()))
+		return mail.NewMemory()
+	}
+	return smtp
+}
+
+// newEventBus creates the event bus and registers the in-process subscribers
+func newEventBus(log *slog.Logger, relatedRepo *model.RelatedPostRepository) *appevents.Bus {
+	bus := appevents.NewBus(log)
+	for _, eventType := range []appevents.Type{appevents.PostPublished, appevents.PostUnpublished, appevents.PostArchived} {
+		bus.Subscribe(eventType, appevents.LogSubscriber(log))
+		bus.Subscribe(eventType, appevents.RefreshRelatedSubscriber(relatedRepo))
+	}
+	return bus
+}
+
+// cachePolicies builds the Cache-Control policy of each public read route from configuration
+func cachePolicies(cfg *config.Config) handler.CachePolicies {
+	policy := func(maxAge time.Duration) middleware.CachePolicy {
+		return middleware.CachePolicy{MaxAge: maxAge, StaleWhileRevalidate: cfg.CacheStaleWhileRevalidate}
+	}
+
+	return handler.CachePolicies{
+		PostList:  policy(cfg.CachePostListMaxAge),
+		Post:      policy(cfg.CachePostMaxAge),
+		Guestbook: policy(cfg.CacheGuestbookMaxAge),
+	}
+}
+
 func main() {
 	// Check if running in Lambda environment
 	if _, isLambda := os.LookupEnv("AWS_LAMBDA_FUNCTION_NAME"); isLambda {
@@ -210,7 +456,7 @@ func main() {
 		}
 
 		// Start Lambda handler
-		lambda.Start(handleLambdaRequest)
+		lambda.Start(handleLambdaEvent)
 		return
 	}
 
@@ -49,7 +49,9 @@ export class ApiGatewayConstruct extends Construct {
           'X-Api-Key',
           'X-Amz-Security-Token',
           'X-Amz-User-Agent',
+          'If-Match',
         ],
+        exposeHeaders: ['ETag', 'Last-Modified', 'Link', 'Location', 'X-Total-Count'],
         allowCredentials: true,
         maxAge: cdk.Duration.hours(24),
       },
@@ -4,6 +4,10 @@ import * as ec2 from 'aws-cdk-lib/aws-ec2';
 import * as logs from 'aws-cdk-lib/aws-logs';
 import * as iam from 'aws-cdk-lib/aws-iam';
 import * as kms from 'aws-cdk-lib/aws-kms';
+import * as s3 from 'aws-cdk-lib/aws-s3';
+import * as secretsmanager from 'aws-cdk-lib/aws-secretsmanager';
+import * as events from 'aws-cdk-lib/aws-events';
+import * as targets from 'aws-cdk-lib/aws-events-targets';
 import { Construct } from 'constructs';
 
 export interface LambdaConstructProps {
@@ -14,6 +18,8 @@ export interface LambdaConstructProps {
   dbName: string;
   dbSecretArn: string;
   apiKeysSecretArn: string;
+  previewTokenSecret: secretsmanager.ISecret;
+  mediaBucket: s3.IBucket;
   encryptionKey: kms.IKey;
   environment: string;
 }
@@ -68,12 +74,29 @@ export class LambdaConstruct extends Construct {
           DB_SECRET_ARN: props.dbSecretArn,
           API_KEYS_SECRET_ARN: props.apiKeysSecretArn,
           ENVIRONMENT: props.environment,
+          // Resolved by CloudFormation at deploy time; required because DEV_MODE is off
+          PREVIEW_TOKEN_SECRET: props.previewTokenSecret.secretValue.unsafeUnwrap(),
+          // The Lambda filesystem is read-only outside /tmp, so uploads go to S3
+          MEDIA_STORAGE: 's3',
+          MEDIA_S3_BUCKET: props.mediaBucket.bucketName,
         },
         logGroup: logGroup,
         tracing: lambda.Tracing.ACTIVE, // Enable X-Ray tracing
       }
     );
 
+    // Media uploads, listing and deletes; also grants use of the bucket's KMS key
+    props.mediaBucket.grantReadWrite(this.function);
+    props.mediaBucket.grantDelete(this.function);
+
+    // Scheduled invocation for background jobs (scheduled publishing, etc.)
+    // The API detects EventBridge scheduled events and runs its jobs once per tick
+    new events.Rule(this, 'SchedulerRule', {
+      description: 'Runs sochoa.dev API background jobs',
+      schedule: events.Schedule.rate(cdk.Duration.minutes(1)),
+      targets: [new targets.LambdaFunction(this.function)],
+    });
+
     // Outputs
     new cdk.CfnOutput(this, 'LambdaFunctionName', {
       value: this.function.functionName,
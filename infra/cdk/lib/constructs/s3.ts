@@ -12,6 +12,7 @@ export interface S3ConstructProps {
 export class S3Construct extends Construct {
   public readonly uiBucket: s3.Bucket;
   public readonly logsBucket: s3.Bucket;
+  public readonly mediaBucket: s3.Bucket;
 
   constructor(scope: Construct, id: string, props: S3ConstructProps) {
     super(scope, id);
@@ -43,6 +44,18 @@ export class S3Construct extends Construct {
       serverAccessLogsPrefix: 'ui-bucket-logs/',
     });
 
+    // S3 bucket for uploaded media; the API reads and writes it and serves files itself
+    this.mediaBucket = new s3.Bucket(this, 'MediaBucket', {
+      bucketName: `sochoa-dev-media-${cdk.Stack.of(this).account}-${cdk.Stack.of(this).region}`,
+      blockPublicAccess: s3.BlockPublicAccess.BLOCK_ALL,
+      encryption: s3.BucketEncryption.KMS,
+      encryptionKey: props.encryptionKey,
+      enforceSSL: true,
+      removalPolicy: cdk.RemovalPolicy.RETAIN, // Uploads are not rebuilt from the repo
+      serverAccessLogsBucket: this.logsBucket,
+      serverAccessLogsPrefix: 'media-bucket-logs/',
+    });
+
     // Enable public read access only through CloudFront OAC
     // (we'll configure this in CloudFrontConstruct)
 
@@ -72,6 +85,11 @@ export class S3Construct extends Construct {
       exportName: 'UiBucketArn',
     });
 
+    new cdk.CfnOutput(this, 'MediaBucketName', {
+      value: this.mediaBucket.bucketName,
+      exportName: 'MediaBucketName',
+    });
+
     new cdk.CfnOutput(this, 'LogsBucketName', {
       value: this.logsBucket.bucketName,
       exportName: 'UiLogsBucketName',
@@ -10,6 +10,7 @@ export class SecretsConstruct extends Construct {
   public readonly dbPasswordSecret: secretsmanager.Secret;
   public readonly cognitoSecrets: secretsmanager.Secret;
   public readonly apiKeysSecret: secretsmanager.Secret;
+  public readonly previewTokenSecret: secretsmanager.Secret;
 
   constructor(scope: Construct, id: string, props: SecretsConstructProps) {
     super(scope, id);
@@ -54,6 +55,16 @@ export class SecretsConstruct extends Construct {
       removalPolicy: cdk.RemovalPolicy.RETAIN,
     });
 
+    // HMAC secret for draft preview links; shared by every Lambda instance so links survive cold starts and deploys
+    this.previewTokenSecret = new secretsmanager.Secret(this, 'PreviewTokenSecret', {
+      description: 'Draft preview link signing secret',
+      generateSecretString: {
+        passwordLength: 64,
+        excludePunctuation: true,
+      },
+      removalPolicy: cdk.RemovalPolicy.RETAIN,
+    });
+
     // Outputs for reference
     new cdk.CfnOutput(this, 'DbPasswordSecretArn', {
       value: this.dbPasswordSecret.secretArn,
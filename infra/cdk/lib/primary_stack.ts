@@ -70,6 +70,8 @@ export class PrimaryStack extends cdk.Stack {
       dbName: 'sochoa',
       dbSecretArn: secrets.dbPasswordSecret.secretArn,
       apiKeysSecretArn: secrets.apiKeysSecret.secretArn,
+      previewTokenSecret: secrets.previewTokenSecret,
+      mediaBucket: s3.mediaBucket,
       encryptionKey: kms.encryptionKey,
       environment,
     });